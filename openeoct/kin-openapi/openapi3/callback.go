@@ -1,15 +1,175 @@
 package openapi3
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Callback is specified by OpenAPI/Swagger standard version 3.0.
 type Callback map[string]*PathItem
 
 func (value Callback) Validate(c context.Context) error {
-	for _, v := range value {
+	for _, expr := range sortedMapKeys(value) {
+		v := value[expr]
+		if err := validateRuntimeExpressionTemplate(expr); err != nil {
+			return fmt.Errorf("invalid callback expression: %v", err)
+		}
 		if err := v.Validate(c); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// validateCallbackTargets checks that the runtime expressions of the callbacks
+// of operation reference parameters and request body properties it declares.
+// $response expressions aren't checked, see ValidateResponseExpressionTarget.
+func validateCallbackTargets(operation *Operation, parameters Parameters) error {
+	for _, name := range sortedMapKeys(operation.Callbacks) {
+		v := operation.Callbacks[name]
+		if v == nil || v.Value == nil {
+			continue
+		}
+		for _, template := range sortedMapKeys(*v.Value) {
+			exprs, err := RuntimeExpressions(template)
+			if err != nil {
+				return fmt.Errorf("invalid callback %q: %v", name, err)
+			}
+			for _, expr := range exprs {
+				if err := validateRequestExpressionTarget(operation, parameters, expr); err != nil {
+					return fmt.Errorf("invalid callback %q: %v", name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateRequestExpressionTarget(operation *Operation, parameters Parameters, expr string) error {
+	if !strings.HasPrefix(expr, "$request.") {
+		return nil
+	}
+	source := expr[len("$request."):]
+	for _, in := range []string{ParameterInHeader, ParameterInQuery, ParameterInPath} {
+		if !strings.HasPrefix(source, in+".") {
+			continue
+		}
+		name := source[len(in)+1:]
+		for _, ref := range parameters {
+			if p := ref.Value; p != nil && p.In == in && (p.Name == name || (in == ParameterInHeader && strings.EqualFold(p.Name, name))) {
+				return nil
+			}
+		}
+		return fmt.Errorf("runtime expression %q references undefined %s parameter %q", expr, in, name)
+	}
+	if source != "body" && !strings.HasPrefix(source, "body#") {
+		return nil
+	}
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return fmt.Errorf("runtime expression %q references the request body, but the operation has none", expr)
+	}
+	if source == "body" || source == "body#" {
+		return nil
+	}
+	pointer := source[len("body#"):]
+	mediaType := operation.RequestBody.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil {
+		return nil
+	}
+	if !schemaMayHavePointer(mediaType.Schema.Value, strings.Split(pointer[1:], "/")) {
+		return fmt.Errorf("runtime expression %q references a location not in the request body schema", expr)
+	}
+	return nil
+}
+
+// ValidateResponseExpressionTarget checks that a $response expression
+// references a header declared by a response of the operation, or a location
+// which the JSON schema of a response body may have. Other expressions are
+// accepted.
+func ValidateResponseExpressionTarget(operation *Operation, expr string) error {
+	if !strings.HasPrefix(expr, "$response.") {
+		return nil
+	}
+	source := expr[len("$response."):]
+	var responses []*Response
+	for _, status := range sortedMapKeys(operation.Responses) {
+		if ref := operation.Responses[status]; ref != nil && ref.Value != nil {
+			responses = append(responses, ref.Value)
+		}
+	}
+	if strings.HasPrefix(source, "header.") {
+		name := source[len("header."):]
+		for _, response := range responses {
+			for header := range response.Headers {
+				if strings.EqualFold(header, name) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("runtime expression %q references the header %q, which no response of the operation declares", expr, name)
+	}
+	if source != "body" && !strings.HasPrefix(source, "body#") {
+		return nil
+	}
+	var schemas []*Schema
+	hasContent := false
+	for _, response := range responses {
+		if len(response.Content) == 0 {
+			continue
+		}
+		hasContent = true
+		if mediaType := response.Content.Get("application/json"); mediaType != nil {
+			if mediaType.Schema == nil || mediaType.Schema.Value == nil {
+				// Any JSON value
+				return nil
+			}
+			schemas = append(schemas, mediaType.Schema.Value)
+		}
+	}
+	if !hasContent {
+		return fmt.Errorf("runtime expression %q references the response body, but no response of the operation has content", expr)
+	}
+	if source == "body" || source == "body#" || len(schemas) == 0 {
+		return nil
+	}
+	tokens := strings.Split(source[len("body#/"):], "/")
+	for _, schema := range schemas {
+		if schemaMayHavePointer(schema, tokens) {
+			return nil
+		}
+	}
+	return fmt.Errorf("runtime expression %q references a location not in any response body schema", expr)
+}
+
+// schemaMayHavePointer reports whether values of schema can have a value at the
+// JSON pointer made of tokens. Schemas using composition are not inspected.
+func schemaMayHavePointer(schema *Schema, tokens []string) bool {
+	for _, token := range tokens {
+		if schema == nil || len(schema.AllOf) != 0 || len(schema.AnyOf) != 0 || len(schema.OneOf) != 0 {
+			return true
+		}
+		token = unescapeRefString(token)
+		if items := schema.Items; items != nil {
+			if _, err := strconv.Atoi(token); err == nil {
+				schema = items.Value
+				continue
+			}
+		}
+		if property := schema.Properties[token]; property != nil {
+			schema = property.Value
+			continue
+		}
+		// Unless explicitly allowed, tokens not matching the declared
+		// properties are assumed to be typos.
+		if len(schema.Properties) == 0 || schema.AdditionalProperties != nil {
+			return true
+		}
+		if apa := schema.AdditionalPropertiesAllowed; apa != nil && *apa {
+			return true
+		}
+		return false
+	}
+	return true
+}
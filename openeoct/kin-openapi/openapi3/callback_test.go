@@ -0,0 +1,167 @@
+package openapi3_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRuntimeExpression(t *testing.T) {
+	for _, expr := range []string{
+		"$url", "$method", "$statusCode",
+		"$request.body", "$request.body#/url", "$request.body#/items/0/a~1b",
+		"$request.query.limit", "$request.path.job_id", "$request.header.X-Callback",
+		"$response.header.Location", "$response.body#/id",
+	} {
+		require.NoError(t, openapi3.ValidateRuntimeExpression(expr), expr)
+	}
+	for _, expr := range []string{
+		"", "$URL", "$request", "$request.body#url", "$request.body#/a~2b",
+		"$request.query.", "$request.header.X Callback", "$request.cookie.session",
+	} {
+		require.Error(t, openapi3.ValidateRuntimeExpression(expr), expr)
+	}
+}
+
+func TestCallbackValidation(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{expression: "{$request.body#/callback_url}"},
+		{expression: "http://example.com/notify?job={$request.path.job_id}&token={$request.header.x-token}"},
+		{expression: "$request.body#/callback_url"},
+		{expression: "{$request.body#/callbak_url}", wantErr: "references a location not in the request body schema"},
+		{expression: "{$request.query.job_id}", wantErr: `undefined query parameter "job_id"`},
+		{expression: "{$request.body#/callback_url", wantErr: "unbalanced braces"},
+		{expression: "{$request.bdy#/callback_url}", wantErr: "invalid source"},
+		{expression: "{$response.body#/links/0/href}"},
+		{expression: "{$response.body#/id}"},
+		{expression: "{$response.header.location}"},
+		// Response targets are checked by ValidateResponseExpressionTarget only
+		{expression: "{$response.body#/job_id}"},
+		{expression: "{$response.header.OpenEO-Identifier}"},
+		{expression: "{$response.heder.location}", wantErr: "invalid source"},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}/results:
+    parameters:
+      - {name: job_id, in: path, required: true, schema: {type: string}}
+    post:
+      parameters:
+        - {name: X-Token, in: header, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                callback_url: {type: string}
+      responses:
+        '202':
+          description: accepted
+          headers:
+            Location: {schema: {type: string}}
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties: false
+                properties:
+                  links: {type: array, items: {type: object, properties: {href: {type: string}}}}
+        4XX:
+          description: error
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: string}
+      callbacks:
+        finished:
+          'EXPRESSION':
+            post:
+              responses:
+                '200': {description: ok}
+`
+			spec = strings.Replace(spec, "EXPRESSION", test.expression, 1)
+			swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+			require.NoError(t, err)
+			err = swagger.Validate(context.Background())
+			if test.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResponseExpressionTarget(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          headers:
+            Location: {schema: {type: string}}
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties: false
+                properties:
+                  links: {type: array, items: {type: object, properties: {href: {type: string}}}}
+        4XX:
+          description: error
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: string}
+    delete:
+      responses:
+        '204': {description: deleted}
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	post, del := swagger.Paths["/jobs"].Post, swagger.Paths["/jobs"].Delete
+
+	for _, expr := range []string{"$response.body#/links/0/href", "$response.body#/id", "$response.header.location", "$request.body#/url", "$url"} {
+		require.NoError(t, openapi3.ValidateResponseExpressionTarget(post, expr), expr)
+	}
+	require.EqualError(t, openapi3.ValidateResponseExpressionTarget(post, "$response.body#/job_id"),
+		`runtime expression "$response.body#/job_id" references a location not in any response body schema`)
+	require.EqualError(t, openapi3.ValidateResponseExpressionTarget(post, "$response.header.OpenEO-Identifier"),
+		`runtime expression "$response.header.OpenEO-Identifier" references the header "OpenEO-Identifier", which no response of the operation declares`)
+	require.EqualError(t, openapi3.ValidateResponseExpressionTarget(del, "$response.body#/id"),
+		`runtime expression "$response.body#/id" references the response body, but no response of the operation has content`)
+}
+
+func TestCallbackValidationOrder(t *testing.T) {
+	callback := openapi3.Callback{
+		"{$request.query.job_id": &openapi3.PathItem{},
+		"{$request.bdy}":         &openapi3.PathItem{},
+		"{$statuscode}":          &openapi3.PathItem{},
+	}
+	for i := 0; i < 20; i++ {
+		err := callback.Validate(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "$request.bdy")
+	}
+}
@@ -0,0 +1,81 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// CanonicalJSON returns a deterministic JSON serialization of the document,
+// including extensions. Object keys are sorted, insignificant whitespace is
+// removed and numbers are normalized, e.g. 1.0 and 1e0 are both written as 1,
+// so semantically identical documents have identical serializations.
+//
+// References are serialized as such, without the referenced values.
+func CanonicalJSON(swagger *Swagger) ([]byte, error) {
+	return canonicalJSON(swagger)
+}
+
+// CanonicalSchemaJSON returns the deterministic JSON serialization of a
+// schema, like CanonicalJSON, e.g. to find structurally identical schemas.
+// Nested references are serialized as such.
+func CanonicalSchemaJSON(schema *Schema) ([]byte, error) {
+	return canonicalJSON(schema)
+}
+
+// canonicalJSON returns the deterministic JSON serialization of a value.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(canonicalJSONValue(value)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// canonicalJSONValue normalizes the numbers of a decoded JSON value, sorting
+// keys is left to the encoding of maps.
+func canonicalJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			value[k] = canonicalJSONValue(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = canonicalJSONValue(v)
+		}
+	case json.Number:
+		return canonicalJSONNumber(value)
+	}
+	return value
+}
+
+func canonicalJSONNumber(number json.Number) json.Number {
+	// Integers are kept exactly, even beyond the precision of float64
+	if i, ok := new(big.Int).SetString(number.String(), 10); ok {
+		return json.Number(i.String())
+	}
+	f, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return number
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1e21 {
+		return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
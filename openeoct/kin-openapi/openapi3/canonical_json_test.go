@@ -0,0 +1,57 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	load := func(spec string) *openapi3.Swagger {
+		doc, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+		require.NoError(t, err)
+		return doc
+	}
+	doc := load(`{
+  "openapi": "3.0.0",
+  "info": {"version": "1.0", "title": "openEO", "x-api-id": {"name": "openeo", "level": 1.0}},
+  "paths": {},
+  "components": {"schemas": {"budget": {"type": "number", "minimum": 0, "maximum": 1e3}}}
+}`)
+	reordered := load(`
+components:
+  schemas:
+    budget: {maximum: 1000, minimum: 0.0, type: number}
+info:
+  x-api-id: {level: 1, name: openeo}
+  title: openEO
+  version: "1.0"
+openapi: 3.0.0
+paths: {}
+`)
+
+	data, err := openapi3.CanonicalJSON(doc)
+	require.NoError(t, err)
+	require.Equal(t, `{"components":{"schemas":{"budget":{"maximum":1000,"minimum":0,"type":"number"}}},`+
+		`"info":{"title":"openEO","version":"1.0","x-api-id":{"level":1,"name":"openeo"}},"openapi":"3.0.0","paths":{}}`,
+		string(data))
+	reorderedData, err := openapi3.CanonicalJSON(reordered)
+	require.NoError(t, err)
+	require.Equal(t, string(data), string(reorderedData))
+
+	schemaData, err := openapi3.CanonicalSchemaJSON(reordered.Components.Schemas["budget"].Value)
+	require.NoError(t, err)
+	require.Equal(t, `{"maximum":1000,"minimum":0,"type":"number"}`, string(schemaData))
+
+	hash, err := doc.Hash()
+	require.NoError(t, err)
+	reorderedHash, err := reordered.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, reorderedHash)
+
+	reordered.Info.Extensions["x-api-id"] = map[string]interface{}{"level": 2, "name": "openeo"}
+	changedHash, err := reordered.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changedHash)
+}
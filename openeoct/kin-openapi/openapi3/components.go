@@ -35,61 +35,46 @@ func (components *Components) UnmarshalJSON(data []byte) error {
 }
 
 func (components *Components) Validate(c context.Context) (err error) {
-	for k, v := range components.Schemas {
-		if err = ValidateIdentifier(k); err != nil {
-			return
-		}
-		if err = v.Validate(c); err != nil {
-			return
-		}
-	}
+	return runValidationTasks(c, components.validationTasks(c), 1)
+}
 
-	for k, v := range components.Parameters {
-		if err = ValidateIdentifier(k); err != nil {
-			return
-		}
-		if err = v.Validate(c); err != nil {
-			return
-		}
+// validationTasks returns tasks validating the components, in a stable order.
+func (components *Components) validationTasks(c context.Context) []validationTask {
+	if validationSkipped(c, "#/components") {
+		return nil
 	}
-
-	for k, v := range components.RequestBodies {
-		if err = ValidateIdentifier(k); err != nil {
-			return
-		}
-		if err = v.Validate(c); err != nil {
+	var tasks []validationTask
+	add := func(kind string, name string, value interface{}, validate func(context.Context) error) {
+		pointer := validationPointer(c, "components", kind, name)
+		if validationSkipped(c, pointer) {
 			return
 		}
+		tasks = append(tasks, validationTask{pointer: pointer, value: value, run: func(c context.Context) error {
+			if err := ValidateIdentifier(name); err != nil {
+				return err
+			}
+			return validate(c)
+		}})
 	}
-
-	for k, v := range components.Responses {
-		if err = ValidateIdentifier(k); err != nil {
-			return
-		}
-		if err = v.Validate(c); err != nil {
-			return
-		}
+	for _, k := range sortedMapKeys(components.Schemas) {
+		add("schemas", k, components.Schemas[k], components.Schemas[k].Validate)
 	}
-
-	for k, v := range components.Headers {
-		if err = ValidateIdentifier(k); err != nil {
-			return
-		}
-		if err = v.Validate(c); err != nil {
-			return
-		}
+	for _, k := range sortedMapKeys(components.Parameters) {
+		add("parameters", k, components.Parameters[k], components.Parameters[k].Validate)
 	}
-
-	for k, v := range components.SecuritySchemes {
-		if err = ValidateIdentifier(k); err != nil {
-			return
-		}
-		if err = v.Validate(c); err != nil {
-			return
-		}
+	for _, k := range sortedMapKeys(components.RequestBodies) {
+		add("requestBodies", k, components.RequestBodies[k], components.RequestBodies[k].Validate)
 	}
-
-	return
+	for _, k := range sortedMapKeys(components.Responses) {
+		add("responses", k, components.Responses[k], components.Responses[k].Validate)
+	}
+	for _, k := range sortedMapKeys(components.Headers) {
+		add("headers", k, components.Headers[k], components.Headers[k].Validate)
+	}
+	for _, k := range sortedMapKeys(components.SecuritySchemes) {
+		add("securitySchemes", k, components.SecuritySchemes[k], components.SecuritySchemes[k].Validate)
+	}
+	return tasks
 }
 
 const identifierPattern = `^[a-zA-Z0-9.\-_]+$`
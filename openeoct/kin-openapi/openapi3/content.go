@@ -2,6 +2,9 @@ package openapi3
 
 import (
 	"context"
+	"fmt"
+	"mime"
+	"sort"
 	"strings"
 )
 
@@ -66,8 +69,69 @@ func (content Content) Get(mime string) *MediaType {
 	return content["*/*"]
 }
 
+// GetProfile returns the media type of the content with the same "profile"
+// parameter as mime, e.g. `application/json; profile="https://openeo.org/v1"`,
+// nil if none of the content declares it. Other parameters and the case of the
+// type are ignored. It's like Get if mime has no profile.
+func (content Content) GetProfile(mime string) *MediaType {
+	profile := MediaTypeProfile(mime)
+	if profile == "" {
+		return content.Get(mime)
+	}
+	base := strings.ToLower(strings.TrimSpace(strings.SplitN(mime, ";", 2)[0]))
+	for _, key := range sortedMapKeys(content) {
+		if MediaTypeProfile(key) == profile && strings.ToLower(strings.TrimSpace(strings.SplitN(key, ";", 2)[0])) == base {
+			return content[key]
+		}
+	}
+	return nil
+}
+
+// MediaTypeProfile returns the "profile" parameter of the media type, quoted
+// or not, an empty string if it has none.
+func MediaTypeProfile(mediaType string) string {
+	if _, params, err := mime.ParseMediaType(mediaType); err == nil {
+		return params["profile"]
+	}
+	// Profiles are URIs, which are often not quoted although they must be
+	for _, param := range strings.Split(mediaType, ";")[1:] {
+		if kv := strings.SplitN(param, "=", 2); len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "profile") {
+			return strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		}
+	}
+	return ""
+}
+
+// MediaTypes returns the media types of the content in the order Get matches
+// them, i.e. media types like "image/png" before ranges like "image/*" before
+// "*/*", each alphabetically.
+func (content Content) MediaTypes() []string {
+	mediaTypes := sortedMapKeys(content)
+	sort.SliceStable(mediaTypes, func(i, j int) bool {
+		return mediaRangeRank(mediaTypes[i]) < mediaRangeRank(mediaTypes[j])
+	})
+	return mediaTypes
+}
+
+// mediaRangeRank returns 0 for media types, 1 for ranges of subtypes and 2 for
+// the range of all media types.
+func mediaRangeRank(mime string) int {
+	mime = strings.TrimSpace(strings.SplitN(mime, ";", 2)[0])
+	switch {
+	case mime == "*/*":
+		return 2
+	case strings.HasSuffix(mime, "/*"):
+		return 1
+	}
+	return 0
+}
+
 func (content Content) Validate(c context.Context) error {
-	for _, v := range content {
+	for _, mime := range sortedMapKeys(content) {
+		v := content[mime]
+		if err := validateMediaTypeEncoding(mime, v); err != nil {
+			return err
+		}
 		// Validate MediaType
 		if err := v.Validate(c); err != nil {
 			return err
@@ -75,3 +139,25 @@ func (content Content) Validate(c context.Context) error {
 	}
 	return nil
 }
+
+// validateMediaTypeEncoding checks that the media type only has an encoding if
+// it's a multipart or form-urlencoded media type, and its encoding only has
+// headers if it's a multipart media type.
+func validateMediaTypeEncoding(mime string, mediaType *MediaType) error {
+	if mediaType == nil || len(mediaType.Encoding) == 0 {
+		return nil
+	}
+	mime = strings.ToLower(strings.TrimSpace(strings.SplitN(mime, ";", 2)[0]))
+	multipart := strings.HasPrefix(mime, "multipart/")
+	if !multipart && mime != "application/x-www-form-urlencoded" {
+		return fmt.Errorf("media type %q has an encoding, which only applies to multipart and application/x-www-form-urlencoded media types", mime)
+	}
+	if !multipart {
+		for _, name := range sortedMapKeys(mediaType.Encoding) {
+			if encoding := mediaType.Encoding[name]; encoding != nil && len(encoding.Headers) != 0 {
+				return fmt.Errorf("encoding of property %q of media type %q has headers, which only apply to multipart media types", name, mime)
+			}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -111,3 +112,40 @@ func TestContent_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestContentGetProfile(t *testing.T) {
+	plain := NewMediaType()
+	v1 := NewMediaType()
+	v2 := NewMediaType()
+	content := Content{
+		"application/json": plain,
+		`application/json; profile="https://openeo.org/v1"`:     v1,
+		"application/json;profile=https://openeo.org/v2":        v2,
+		`application/geo+json; profile="https://openeo.org/v1"`: NewMediaType(),
+	}
+	require.Equal(t, "https://openeo.org/v1", MediaTypeProfile(`application/json;charset=utf-8; profile="https://openeo.org/v1"`))
+	require.Equal(t, "", MediaTypeProfile("application/json"))
+	require.Equal(t, "", MediaTypeProfile("application/json; profile"))
+
+	require.True(t, v1 == content.GetProfile(`Application/JSON;profile="https://openeo.org/v1"; charset=utf-8`))
+	require.True(t, v2 == content.GetProfile(`application/json; profile="https://openeo.org/v2"`))
+	require.True(t, plain == content.GetProfile("application/json; charset=utf-8"))
+	require.Nil(t, content.GetProfile(`application/json; profile="https://openeo.org/v3"`))
+	// Get falls back to the base type
+	require.True(t, plain == content.Get(`application/json; profile="https://openeo.org/v3"`))
+}
+
+func TestContentEncodingMediaTypes(t *testing.T) {
+	headers := &Encoding{Headers: map[string]*HeaderRef{"X-Rate-Limit": {Value: &Header{}}}}
+	withEncoding := func(encoding *Encoding) *MediaType {
+		return NewMediaType().WithSchema(NewObjectSchema()).WithEncoding("file", encoding)
+	}
+
+	require.NoError(t, Content{"multipart/form-data": withEncoding(headers)}.Validate(context.Background()))
+	require.NoError(t, Content{"application/x-www-form-urlencoded; charset=utf-8": withEncoding(&Encoding{Style: "form"})}.Validate(context.Background()))
+
+	err := Content{"application/json": withEncoding(&Encoding{ContentType: "image/png"})}.Validate(context.Background())
+	require.EqualError(t, err, `media type "application/json" has an encoding, which only applies to multipart and application/x-www-form-urlencoded media types`)
+	err = Content{"application/x-www-form-urlencoded": withEncoding(headers)}.Validate(context.Background())
+	require.EqualError(t, err, `encoding of property "file" of media type "application/x-www-form-urlencoded" has headers, which only apply to multipart media types`)
+}
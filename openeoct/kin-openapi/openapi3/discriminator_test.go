@@ -1,6 +1,8 @@
 package openapi3_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
@@ -41,3 +43,106 @@ func TestParsingDiscriminator(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, len(loader.Components.Schemas["MyResponseType"].Value.OneOf))
 }
+
+func TestVisitJSONDiscriminator(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Result:
+      oneOf:
+        - $ref: '#/components/schemas/Collection'
+        - $ref: '#/components/schemas/Feature'
+      discriminator:
+        propertyName: type
+        mapping:
+          FeatureCollection: '#/components/schemas/Collection'
+    Collection:
+      type: object
+      required: [type, features]
+      properties:
+        type: {type: string}
+        features: {type: array}
+    Feature:
+      type: object
+      required: [type]
+      properties:
+        type: {type: string}
+        id: {type: string}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	schema := swagger.Components.Schemas["Result"].Value
+
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"type": "FeatureCollection", "features": []interface{}{}}))
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"type": "Feature", "id": "f1"}))
+
+	err = schema.VisitJSON(map[string]interface{}{"type": "Feature", "id": 1})
+	schemaErr, ok := err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "discriminator", schemaErr.SchemaField)
+	origin, ok := schemaErr.Origin.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, []string{"id"}, origin.JSONPointer())
+	require.Equal(t, "type", origin.SchemaField)
+
+	err = schema.VisitJSON(map[string]interface{}{"type": "FeatureCollection"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Property 'features' is missing`)
+
+	err = schema.VisitJSON(map[string]interface{}{"id": "f1"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Property 'type' is missing`)
+
+	err = schema.VisitJSON(map[string]interface{}{"type": "Point"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Discriminator value "Point" doesn't select any of the oneOf schemas`)
+	require.Equal(t, "type", err.(*openapi3.SchemaError).Params()["property"])
+
+	require.Error(t, schema.VisitJSON(map[string]interface{}{"type": 1}, openapi3.FailFast()))
+}
+
+func TestDiscriminatorMappingEnum(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Result:
+      allOf:
+        - $ref: '#/components/schemas/Typed'
+      oneOf:
+        - $ref: '#/components/schemas/Collection'
+        - $ref: '#/components/schemas/Feature'
+      discriminator:
+        propertyName: type
+        mapping:
+          FeatureCollection: '#/components/schemas/Collection'
+          %s: '#/components/schemas/Feature'
+    Typed:
+      type: object
+      properties:
+        type: {type: string, enum: [FeatureCollection, Feature]}
+    Collection:
+      type: object
+      properties:
+        features: {type: array, items: {}}
+    Feature:
+      type: object
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(fmt.Sprintf(spec, "Feature")))
+	require.NoError(t, err)
+	require.NoError(t, swagger.Validate(context.Background()))
+
+	swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(fmt.Sprintf(spec, "feature")))
+	require.NoError(t, err)
+	err = swagger.Components.Schemas["Result"].Value.Validate(context.Background())
+	require.EqualError(t, err, "Schema 'discriminator' mapping key 'feature' isn't a member of the enum of property 'type'")
+
+	// Without an enum any key is fine
+	swagger.Components.Schemas["Typed"].Value.Properties["type"].Value.Enum = nil
+	require.NoError(t, swagger.Components.Schemas["Result"].Value.Validate(context.Background()))
+}
@@ -0,0 +1,147 @@
+package openapi3
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// MessageFormatter returns the message of a schema error with the given code and
+// parameters, see (*SchemaError).Code and (*SchemaError).Params, or false if it
+// has no message for the code.
+type MessageFormatter func(code string, params map[string]interface{}) (string, bool)
+
+// DefaultLocale is the locale of the messages of the errors themselves.
+const DefaultLocale = "en"
+
+// MessageFormatters are the formatters of localized error messages by locale,
+// e.g. "de" or "de-AT", see RegisterMessageFormatter.
+var MessageFormatters = make(map[string]MessageFormatter, 4)
+
+// RegisterMessageFormatter makes LocalizedMessage use formatter for the locale.
+func RegisterMessageFormatter(locale string, formatter MessageFormatter) {
+	MessageFormatters[locale] = formatter
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context which makes LocalizedMessage use the locale,
+// e.g. "de-AT".
+func WithLocale(c context.Context, locale string) context.Context {
+	return context.WithValue(c, localeKey{}, locale)
+}
+
+// Locale returns the locale of the context, DefaultLocale if it has none.
+func Locale(c context.Context) string {
+	if c != nil {
+		if locale, _ := c.Value(localeKey{}).(string); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// LocalizedMessage returns the message of the schema error in the chain of err,
+// formatted by the formatter of the locale of the context. A formatter for the
+// language is used for locales without one, e.g. "de" for "de-AT". Without
+// formatter or schema error the message is err.Error().
+func LocalizedMessage(c context.Context, err error) string {
+	var schemaError *SchemaError
+	if !errors.As(err, &schemaError) {
+		return err.Error()
+	}
+	for schemaError.Origin != nil {
+		origin, ok := schemaError.Origin.(*SchemaError)
+		if !ok {
+			return err.Error()
+		}
+		schemaError = origin
+	}
+	locale := Locale(c)
+	formatter := MessageFormatters[locale]
+	if formatter == nil {
+		if i := strings.IndexAny(locale, "-_"); i >= 0 {
+			formatter = MessageFormatters[locale[:i]]
+		}
+	}
+	if formatter != nil {
+		if message, ok := formatter(schemaError.Code(), schemaError.Params()); ok {
+			return message
+		}
+	}
+	return err.Error()
+}
+
+// Code returns a stable identifier of the kind of the error, the schema keyword
+// which the value doesn't satisfy, e.g. "minLength".
+func (err *SchemaError) Code() string {
+	return err.SchemaField
+}
+
+// Params returns the parameters of the error message: "value" and "pointer",
+// the JSON pointer of the value, "limit" with the value of the schema keyword,
+// e.g. the maximum length for "maxLength", and "property" for the errors of a
+// single property. "reason" is the English reason, if any.
+func (err *SchemaError) Params() map[string]interface{} {
+	params := map[string]interface{}{
+		"value":   err.Value,
+		"pointer": "/" + strings.Join(err.JSONPointer(), "/"),
+	}
+	if err.Reason != "" {
+		params["reason"] = err.Reason
+	}
+	for name, value := range err.params {
+		params[name] = value
+	}
+	if schema := err.Schema; schema != nil {
+		var limit interface{}
+		switch err.SchemaField {
+		case "type":
+			limit = schema.Type
+		case "enum":
+			limit = schema.Enum
+		case "exclusiveMinimum", "minimum":
+			limit = schema.Min
+		case "exclusiveMaximum", "maximum":
+			limit = schema.Max
+		case "multipleOf":
+			limit = schema.MultipleOf
+		case "minLength":
+			limit = schema.MinLength
+		case "maxLength":
+			limit = schema.MaxLength
+		case "pattern":
+			limit = schema.Pattern
+		case "format":
+			limit = schema.Format
+		case "contentEncoding":
+			limit = schema.ContentEncoding
+		case "contentMediaType":
+			limit = schema.ContentMediaType
+		case "minItems":
+			limit = schema.MinItems
+		case "maxItems":
+			limit = schema.MaxItems
+		case "minProperties":
+			limit = schema.MinProps
+		case "maxProperties":
+			limit = schema.MaxProps
+		}
+		switch v := limit.(type) {
+		case *float64:
+			limit = nil
+			if v != nil {
+				limit = *v
+			}
+		case *uint64:
+			limit = nil
+			if v != nil {
+				limit = *v
+			}
+		}
+		if limit != nil {
+			params["limit"] = limit
+		}
+	}
+	return params
+}
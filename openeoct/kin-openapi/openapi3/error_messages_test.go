@@ -0,0 +1,42 @@
+package openapi3
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizedMessage(t *testing.T) {
+	RegisterMessageFormatter("de", func(code string, params map[string]interface{}) (string, bool) {
+		switch code {
+		case "maxLength":
+			return fmt.Sprintf("%s: Die Zeichenkette darf höchstens %d Zeichen lang sein", params["pointer"], params["limit"]), true
+		case "required":
+			return fmt.Sprintf("%s: Die Eigenschaft '%s' fehlt", params["pointer"], params["property"]), true
+		}
+		return "", false
+	})
+	defer delete(MessageFormatters, "de")
+
+	schema := NewObjectSchema().
+		WithProperty("title", NewStringSchema().WithMaxLength(3)).
+		WithProperty("id", NewStringSchema())
+	schema.Required = []string{"id"}
+
+	err := schema.VisitJSON(map[string]interface{}{"id": "a", "title": "abcd"})
+	require.Error(t, err)
+	c := WithLocale(context.Background(), "de-AT")
+	require.Equal(t, "/title: Die Zeichenkette darf höchstens 3 Zeichen lang sein", LocalizedMessage(c, err))
+	require.Equal(t, err.Error(), LocalizedMessage(context.Background(), err))
+	require.Equal(t, err.Error(), LocalizedMessage(WithLocale(context.Background(), "fr"), err))
+
+	err = fmt.Errorf("response body: %w", schema.VisitJSON(map[string]interface{}{}))
+	require.Equal(t, "/id: Die Eigenschaft 'id' fehlt", LocalizedMessage(c, err))
+
+	err = schema.VisitJSON("abc")
+	require.Equal(t, "type", err.(*SchemaError).Code())
+	require.Equal(t, "object", err.(*SchemaError).Params()["limit"])
+	require.Equal(t, err.Error(), LocalizedMessage(c, err))
+}
@@ -0,0 +1,560 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ghodss/yaml"
+)
+
+// ExampleError is an example which doesn't match its schema, or whose external
+// value can't be read, see ValidateExamples.
+type ExampleError struct {
+	// Pointer locates the example, e.g. "#/components/schemas/Job/example".
+	Pointer string
+	Err     error
+}
+
+func (err *ExampleError) Error() string {
+	return fmt.Sprintf("invalid example at %s: %v", err.Pointer, err.Err)
+}
+
+func (err *ExampleError) Unwrap() error {
+	return err.Err
+}
+
+// ValidateExamples validates every example of the document against the schema
+// it illustrates: the example and examples (OpenAPI 3.1) of schemas, and the
+// example and examples of parameters, headers and media types, including the
+// values of examples with an externalValue, which is read relative to the base
+// URI of the document.
+// The "x-example" and "x-examples" extensions of these objects, a list or a map
+// of example values, are validated too. Examples shared through references are
+// validated for each of their schemas.
+//
+// Examples of request bodies and parameters must not include readOnly
+// properties, and examples of responses must not include writeOnly properties.
+// Every required property an example omits is reported, in request examples
+// except readOnly ones and in response examples except writeOnly ones.
+// Schema examples are checked for the directions the schema is used in.
+//
+// String values of the format byte must be base64 encoded, and string values
+// of the format binary without a contentEncoding must not be raw binary
+// content, i.e. have control characters or invalid UTF-8.
+//
+// Unlike Validate it returns all errors, nil if all examples are valid. It's
+// not part of Validate as it validates every example value, which is costly for
+// large documents.
+func (swagger *Swagger) ValidateExamples(c context.Context, opts ...SchemaValidationOption) []*ExampleError {
+	v := &exampleValidator{
+		swagger:   swagger,
+		opts:      opts,
+		visited:   make(map[interface{}]bool),
+		validated: make(map[*Schema]bool),
+	}
+	components := swagger.Components
+	for _, name := range sortedMapKeys(components.Schemas) {
+		v.schema(validationPointer(c, "components", "schemas", name), components.Schemas[name], undirected)
+	}
+	for _, name := range sortedMapKeys(components.Parameters) {
+		if ref := components.Parameters[name]; ref != nil {
+			v.parameter(validationPointer(c, "components", "parameters", name), ref.Value)
+		}
+	}
+	for _, name := range sortedMapKeys(components.Headers) {
+		if ref := components.Headers[name]; ref != nil {
+			v.header(validationPointer(c, "components", "headers", name), ref.Value)
+		}
+	}
+	for _, name := range sortedMapKeys(components.RequestBodies) {
+		if ref := components.RequestBodies[name]; ref != nil && ref.Value != nil {
+			v.content(validationPointer(c, "components", "requestBodies", name, "content"), ref.Value.Content, requestDirection)
+		}
+	}
+	for _, name := range sortedMapKeys(components.Responses) {
+		if ref := components.Responses[name]; ref != nil {
+			v.response(validationPointer(c, "components", "responses", name), ref.Value)
+		}
+	}
+	for _, path := range sortedMapKeys(swagger.Paths) {
+		v.pathItem(validationPointer(c, "paths", path), swagger.Paths[path])
+	}
+	return v.errs
+}
+
+// exampleDirection is the direction of the messages an example is used in.
+type exampleDirection int
+
+const (
+	undirected exampleDirection = iota
+	requestDirection
+	responseDirection
+)
+
+type exampleValidator struct {
+	swagger *Swagger
+	opts    []SchemaValidationOption
+	// visited are the parameters, headers, media types and responses visited,
+	// and the schemas visited by direction.
+	visited map[interface{}]bool
+	// validated are the schemas whose examples were validated.
+	validated map[*Schema]bool
+	errs      []*ExampleError
+}
+
+type directedSchema struct {
+	schema    *Schema
+	direction exampleDirection
+}
+
+func (v *exampleValidator) report(pointer string, err error) {
+	v.errs = append(v.errs, &ExampleError{Pointer: pointer, Err: err})
+}
+
+// validate validates the example value at pointer against the schema, and
+// checks that it only has properties of the direction unless undirected. All
+// required properties the example omits are reported, each with its pointer.
+func (v *exampleValidator) validate(pointer string, schema *SchemaRef, value interface{}, direction exampleDirection) {
+	if value == nil || schema == nil || schema.Value == nil {
+		return
+	}
+	checked := v.required(pointer, schema, value, direction)
+	err := schema.Value.VisitJSON(value, v.opts...)
+	if err != nil && checked[requiredErrorPath(err)] {
+		// Omitted properties are reported already, look for other errors
+		opts := append([]SchemaValidationOption{DisableRequiredValidation()}, v.opts...)
+		err = schema.Value.VisitJSON(value, opts...)
+	}
+	if err != nil {
+		v.report(pointer, err)
+	}
+	v.binary(pointer, schema, value)
+	v.direction(pointer, schema, value, direction)
+}
+
+// binary reports the strings of the example value of the format byte which
+// aren't base64 encoded, and those of the format binary which are raw binary
+// content. Strings of the format byte which don't match its pattern are left
+// to the format validation.
+func (v *exampleValidator) binary(pointer string, schema *SchemaRef, value interface{}) {
+	if value == nil || schema == nil || schema.Value == nil {
+		return
+	}
+	walkExample(schema.Value, value, func(path string, schemas []*Schema, value interface{}) {
+		text, ok := value.(string)
+		if !ok {
+			return
+		}
+		what := "example"
+		if path != "" {
+			what = fmt.Sprintf("example value %q", path)
+		}
+		for _, schema := range schemas {
+			switch schema.Format {
+			case "byte":
+				if re := SchemaStringFormats["byte"]; re != nil && !re.MatchString(text) {
+					return
+				}
+				if err := decodeBase64(text); err != nil {
+					v.report(pointer, fmt.Errorf("%s of format byte isn't base64 encoded: %v", what, err))
+				}
+				return
+			case "binary":
+				if schema.ContentEncoding == "" && isRawBinary(text) {
+					v.report(pointer, fmt.Errorf("%s of format binary is raw binary content, encode it with the contentEncoding base64 or omit it", what))
+				}
+				return
+			}
+		}
+	})
+}
+
+// decodeBase64 returns an error if the string isn't base64 or base64url
+// encoded, with or without padding.
+func decodeBase64(text string) error {
+	text = strings.TrimRight(text, "=")
+	_, err := base64.RawStdEncoding.DecodeString(text)
+	if err != nil {
+		if _, urlErr := base64.RawURLEncoding.DecodeString(text); urlErr == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// isRawBinary returns whether the string has control characters other than
+// whitespace or invalid UTF-8, which JSON and YAML decode to U+FFFD.
+func isRawBinary(text string) bool {
+	for _, r := range text {
+		if r == unicode.ReplacementChar || unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return true
+		}
+	}
+	return false
+}
+
+// required reports the required properties the example value omits, except
+// readOnly properties of request examples and writeOnly properties of response
+// examples. It returns the pointers within the value of all omitted required
+// properties, reported or not.
+func (v *exampleValidator) required(pointer string, schema *SchemaRef, value interface{}, direction exampleDirection) map[string]bool {
+	omitted := make(map[string]bool)
+	walkExample(schema.Value, value, func(path string, schemas []*Schema, value interface{}) {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for _, schema := range schemas {
+			for _, name := range schema.Required {
+				property := path + "/" + escapeJSONPointerToken(name)
+				if _, ok := object[name]; ok || omitted[property] {
+					continue
+				}
+				omitted[property] = true
+				if ref := exampleProperty(schemas, name); ref != nil && ref.Value != nil &&
+					(direction == requestDirection && ref.Value.ReadOnly || direction == responseDirection && ref.Value.WriteOnly) {
+					continue
+				}
+				v.report(pointer, fmt.Errorf("example omits the required property %q", property))
+			}
+		}
+	})
+	return omitted
+}
+
+// requiredErrorPath returns the pointer of the missing property if err is a
+// "required" error of the value or of an allOf member, "" otherwise.
+func requiredErrorPath(err error) string {
+	var path []string
+	for {
+		schemaErr, ok := err.(*SchemaError)
+		if !ok {
+			return ""
+		}
+		path = append(path, schemaErr.JSONPointer()...)
+		switch schemaErr.SchemaField {
+		case "required":
+			for i, token := range path {
+				path[i] = escapeJSONPointerToken(token)
+			}
+			return "/" + strings.Join(path, "/")
+		case "allOf":
+			err = schemaErr.Origin
+		default:
+			return ""
+		}
+	}
+}
+
+// direction reports the readOnly properties of request examples and the
+// writeOnly properties of response examples.
+func (v *exampleValidator) direction(pointer string, schema *SchemaRef, value interface{}, direction exampleDirection) {
+	if direction == undirected || value == nil || schema == nil || schema.Value == nil {
+		return
+	}
+	walkExample(schema.Value, value, func(path string, schemas []*Schema, value interface{}) {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for _, name := range sortedMapKeys(object) {
+			ref := exampleProperty(schemas, name)
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			property := path + "/" + escapeJSONPointerToken(name)
+			if direction == requestDirection && ref.Value.ReadOnly {
+				v.report(pointer, fmt.Errorf("request example has the readOnly property %q", property))
+			} else if direction == responseDirection && ref.Value.WriteOnly {
+				v.report(pointer, fmt.Errorf("response example has the writeOnly property %q", property))
+			}
+		}
+	})
+}
+
+// walkExample calls fn for the example value and its nested values with the
+// schemas applying to them, a schema and its allOf members, and walks into the
+// values of the properties and items declared by these schemas.
+func walkExample(schema *Schema, value interface{}, fn func(path string, schemas []*Schema, value interface{})) {
+	var walk func(path string, schema *Schema, value interface{}, depth int)
+	walk = func(path string, schema *Schema, value interface{}, depth int) {
+		if depth > maxExampleDepth {
+			return
+		}
+		var schemas []*Schema
+		var collect func(schema *Schema)
+		collect = func(schema *Schema) {
+			if len(schemas) > maxExampleDepth {
+				return
+			}
+			schemas = append(schemas, schema)
+			for _, member := range schema.AllOf {
+				if member != nil && member.Value != nil {
+					collect(member.Value)
+				}
+			}
+		}
+		collect(schema)
+		fn(path, schemas, value)
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for _, name := range sortedMapKeys(value) {
+				if ref := exampleProperty(schemas, name); ref != nil && ref.Value != nil {
+					walk(path+"/"+escapeJSONPointerToken(name), ref.Value, value[name], depth+1)
+				}
+			}
+		case []interface{}:
+			for _, schema := range schemas {
+				if items := schema.Items; items != nil && items.Value != nil {
+					for i, item := range value {
+						walk(path+"/"+strconv.Itoa(i), items.Value, item, depth+1)
+					}
+					break
+				}
+			}
+		}
+	}
+	walk("", schema, value, 0)
+}
+
+// exampleProperty returns the first declaration of the property by the schemas.
+func exampleProperty(schemas []*Schema, name string) *SchemaRef {
+	for _, schema := range schemas {
+		if ref := schema.Properties[name]; ref != nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+// maxExampleDepth limits the nesting of example values checked for properties
+// of the wrong direction and omitted properties, as the schemas of the values
+// may be recursive.
+const maxExampleDepth = 64
+
+// examples validates the examples map of a parameter, header or media type.
+func (v *exampleValidator) examples(pointer string, schema *SchemaRef, examples map[string]*ExampleRef, direction exampleDirection) {
+	for _, name := range sortedMapKeys(examples) {
+		ref := examples[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		ptr := pointer + "/" + escapeJSONPointerToken(name)
+		example := ref.Value
+		if example.Value != nil {
+			v.validate(ptr+"/value", schema, example.Value, direction)
+		} else if example.ExternalValue != "" && schema != nil {
+			value, err := v.externalValue(example.ExternalValue)
+			if err != nil {
+				v.report(ptr+"/externalValue", err)
+				continue
+			}
+			v.validate(ptr+"/externalValue", schema, value, direction)
+		}
+	}
+}
+
+// externalValue reads and decodes the JSON or YAML value of an external example.
+func (v *exampleValidator) externalValue(externalValue string) (interface{}, error) {
+	location, err := url.Parse(externalValue)
+	if err != nil {
+		return nil, err
+	}
+	if location, err = resolvePath(v.swagger.BaseURI(), location); err != nil {
+		return nil, err
+	}
+	data, err := readURL(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read externalValue %q: %v", externalValue, err)
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("externalValue %q is neither JSON nor YAML: %v", externalValue, err)
+	}
+	return value, nil
+}
+
+// extensionExamples returns the values of the "x-example" and "x-examples"
+// extensions with their pointers.
+func extensionExamples(pointer string, props ExtensionProps) (pointers []string, values []interface{}) {
+	if raw, ok := props.Extensions["x-example"].(json.RawMessage); ok {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err == nil {
+			pointers, values = append(pointers, pointer+"/x-example"), append(values, value)
+		}
+	}
+	if raw, ok := props.Extensions["x-examples"].(json.RawMessage); ok {
+		var list []interface{}
+		var named map[string]interface{}
+		if err := json.Unmarshal(raw, &list); err == nil {
+			for i, value := range list {
+				pointers, values = append(pointers, pointer+"/x-examples/"+strconv.Itoa(i)), append(values, value)
+			}
+		} else if err := json.Unmarshal(raw, &named); err == nil {
+			for _, name := range sortedMapKeys(named) {
+				pointers, values = append(pointers, pointer+"/x-examples/"+escapeJSONPointerToken(name)), append(values, named[name])
+			}
+		}
+	}
+	return
+}
+
+// extensions validates the "x-example" and "x-examples" extensions.
+func (v *exampleValidator) extensions(pointer string, schema *SchemaRef, props ExtensionProps, direction exampleDirection) {
+	pointers, values := extensionExamples(pointer, props)
+	for i, ptr := range pointers {
+		v.validate(ptr, schema, values[i], direction)
+	}
+}
+
+// schema validates the examples of the schema and its subschemas once, and
+// checks them for each direction the schema is used in.
+func (v *exampleValidator) schema(pointer string, ref *SchemaRef, direction exampleDirection) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	if ref.Ref != "" && strings.HasPrefix(ref.Ref, "#/components/schemas/") {
+		// Validated at its definition, only checked for the direction here
+		if direction == undirected {
+			return
+		}
+		pointer = ref.Ref
+	}
+	schema := ref.Value
+	key := directedSchema{schema: schema, direction: direction}
+	if v.visited[key] {
+		return
+	}
+	v.visited[key] = true
+	validated := v.validated[schema]
+	v.validated[schema] = true
+
+	pointers, values := extensionExamples(pointer, schema.ExtensionProps)
+	pointers, values = append([]string{pointer + "/example"}, pointers...), append([]interface{}{schema.Example}, values...)
+	for i, value := range schema.Examples {
+		pointers, values = append(pointers, pointer+"/examples/"+strconv.Itoa(i)), append(values, value)
+	}
+	for i, ptr := range pointers {
+		if validated {
+			v.direction(ptr, ref, values[i], direction)
+		} else {
+			v.validate(ptr, ref, values[i], direction)
+		}
+	}
+
+	for _, name := range sortedMapKeys(schema.Properties) {
+		v.schema(pointer+"/properties/"+escapeJSONPointerToken(name), schema.Properties[name], direction)
+	}
+	v.schema(pointer+"/items", schema.Items, direction)
+	for i, item := range schema.PrefixItems {
+		v.schema(pointer+"/prefixItems/"+strconv.Itoa(i), item, direction)
+	}
+	v.schema(pointer+"/additionalProperties", schema.AdditionalProperties, direction)
+	for i, member := range schema.AllOf {
+		v.schema(pointer+"/allOf/"+strconv.Itoa(i), member, direction)
+	}
+	for i, member := range schema.AnyOf {
+		v.schema(pointer+"/anyOf/"+strconv.Itoa(i), member, direction)
+	}
+	for i, member := range schema.OneOf {
+		v.schema(pointer+"/oneOf/"+strconv.Itoa(i), member, direction)
+	}
+	v.schema(pointer+"/not", schema.Not, direction)
+	v.schema(pointer+"/if", schema.If, direction)
+	v.schema(pointer+"/then", schema.Then, direction)
+	v.schema(pointer+"/else", schema.Else, direction)
+}
+
+func (v *exampleValidator) parameter(pointer string, parameter *Parameter) {
+	if parameter == nil || v.visited[parameter] {
+		return
+	}
+	v.visited[parameter] = true
+	v.validate(pointer+"/example", parameter.Schema, parameter.Example, requestDirection)
+	v.examples(pointer+"/examples", parameter.Schema, parameter.Examples, requestDirection)
+	v.extensions(pointer, parameter.Schema, parameter.ExtensionProps, requestDirection)
+	v.schema(pointer+"/schema", parameter.Schema, requestDirection)
+	v.content(pointer+"/content", parameter.Content, requestDirection)
+}
+
+func (v *exampleValidator) header(pointer string, header *Header) {
+	if header == nil || v.visited[header] {
+		return
+	}
+	v.visited[header] = true
+	v.validate(pointer+"/example", header.Schema, header.Example, responseDirection)
+	v.examples(pointer+"/examples", header.Schema, header.Examples, responseDirection)
+	v.extensions(pointer, header.Schema, header.ExtensionProps, responseDirection)
+	v.schema(pointer+"/schema", header.Schema, responseDirection)
+}
+
+func (v *exampleValidator) content(pointer string, content Content, direction exampleDirection) {
+	for _, mime := range sortedMapKeys(content) {
+		mediaType := content[mime]
+		if mediaType == nil || v.visited[mediaType] {
+			continue
+		}
+		v.visited[mediaType] = true
+		ptr := pointer + "/" + escapeJSONPointerToken(mime)
+		v.validate(ptr+"/example", mediaType.Schema, mediaType.Example, direction)
+		v.examples(ptr+"/examples", mediaType.Schema, mediaType.Examples, direction)
+		v.extensions(ptr, mediaType.Schema, mediaType.ExtensionProps, direction)
+		v.schema(ptr+"/schema", mediaType.Schema, direction)
+	}
+}
+
+func (v *exampleValidator) response(pointer string, response *Response) {
+	if response == nil || v.visited[response] {
+		return
+	}
+	v.visited[response] = true
+	for _, name := range sortedMapKeys(response.Headers) {
+		if ref := response.Headers[name]; ref != nil {
+			v.header(pointer+"/headers/"+escapeJSONPointerToken(name), ref.Value)
+		}
+	}
+	v.content(pointer+"/content", response.Content, responseDirection)
+}
+
+func (v *exampleValidator) pathItem(pointer string, pathItem *PathItem) {
+	if pathItem == nil || v.visited[pathItem] {
+		return
+	}
+	v.visited[pathItem] = true
+	for i, ref := range pathItem.Parameters {
+		if ref != nil {
+			v.parameter(pointer+"/parameters/"+strconv.Itoa(i), ref.Value)
+		}
+	}
+	operations := pathItem.Operations()
+	for _, method := range sortedMapKeys(operations) {
+		operation := operations[method]
+		ptr := pointer + "/" + strings.ToLower(method)
+		for i, ref := range operation.Parameters {
+			if ref != nil {
+				v.parameter(ptr+"/parameters/"+strconv.Itoa(i), ref.Value)
+			}
+		}
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			v.content(ptr+"/requestBody/content", ref.Value.Content, requestDirection)
+		}
+		for _, status := range sortedMapKeys(operation.Responses) {
+			if ref := operation.Responses[status]; ref != nil {
+				v.response(ptr+"/responses/"+escapeJSONPointerToken(status), ref.Value)
+			}
+		}
+		for _, name := range sortedMapKeys(operation.Callbacks) {
+			if ref := operation.Callbacks[name]; ref != nil && ref.Value != nil {
+				for _, expression := range sortedMapKeys(*ref.Value) {
+					v.pathItem(ptr+"/callbacks/"+escapeJSONPointerToken(name)+"/"+escapeJSONPointerToken(expression), (*ref.Value)[expression])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package openapi3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExamples(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("testdata/examples/examples.openapi.yml")
+	require.NoError(t, err)
+	require.NoError(t, swagger.Validate(context.Background()))
+
+	errs := swagger.ValidateExamples(context.Background())
+	pointers := make([]string, 0, len(errs))
+	for _, err := range errs {
+		pointers = append(pointers, err.Pointer)
+	}
+	require.Equal(t, []string{
+		"#/components/schemas/Job/properties/id/example",
+		"#/components/schemas/Job/properties/status/x-examples/1",
+		"#/paths/~1jobs~1{job_id}/get/parameters/0/example",
+		"#/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json/examples/external/externalValue",
+		"#/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json/examples/missing/externalValue",
+		"#/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json/examples/shared/value",
+	}, pointers)
+	require.Contains(t, errs[0].Error(), "invalid example at #/components/schemas/Job/properties/id/example: ")
+	require.Contains(t, errs[4].Error(), `failed to read externalValue "missing.json"`)
+}
+
+func TestValidateExamplesDirection(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Job:
+      type: object
+      properties:
+        id: {type: string, readOnly: true}
+        plan: {type: string}
+        credentials:
+          type: object
+          properties:
+            password: {type: string, writeOnly: true}
+      example: {id: j-1, credentials: {password: secret}}
+paths:
+  /jobs:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Job'}
+            examples:
+              created: {value: {id: j-2, plan: free}}
+              new: {value: {plan: free, credentials: {password: secret}}}
+      responses:
+        '201':
+          description: created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Job'}
+              example: {id: j-3, credentials: {password: secret}}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, err := range swagger.ValidateExamples(context.Background()) {
+		messages = append(messages, err.Error())
+	}
+	require.Equal(t, []string{
+		`invalid example at #/paths/~1jobs/post/requestBody/content/application~1json/examples/created/value: request example has the readOnly property "/id"`,
+		`invalid example at #/components/schemas/Job/example: request example has the readOnly property "/id"`,
+		`invalid example at #/paths/~1jobs/post/responses/201/content/application~1json/example: response example has the writeOnly property "/credentials/password"`,
+		`invalid example at #/components/schemas/Job/example: response example has the writeOnly property "/credentials/password"`,
+	}, messages)
+}
+
+func TestValidateExamplesRequired(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  examples:
+    Untitled:
+      value: {id: j-2, process: {}}
+  schemas:
+    Job:
+      type: object
+      required: [id, title, process]
+      properties:
+        id: {type: string, readOnly: true}
+        title: {type: string}
+        process:
+          type: object
+          required: [process_graph]
+          properties:
+            process_graph: {type: object}
+        token: {type: string, writeOnly: true}
+      allOf:
+        - required: [token]
+paths:
+  /jobs/{job_id}:
+    get:
+      parameters:
+        - {name: job_id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Job'}
+              examples:
+                inline: {value: {id: j-1, title: 1, process: {process_graph: {}}}}
+                shared: {$ref: '#/components/examples/Untitled'}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, err := range swagger.ValidateExamples(context.Background()) {
+		messages = append(messages, err.Error())
+	}
+	require.Len(t, messages, 3)
+	require.Contains(t, messages[0], "invalid example at #/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json/examples/inline/value: ")
+	require.Contains(t, messages[0], "Field must be set to string or not be present")
+	require.Equal(t, []string{
+		`invalid example at #/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json/examples/shared/value: example omits the required property "/title"`,
+		`invalid example at #/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json/examples/shared/value: example omits the required property "/process/process_graph"`,
+	}, messages[1:])
+}
+
+func TestValidateSchemaExamples(t *testing.T) {
+	spec := []byte(`
+openapi: 3.1.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    BoundingBox:
+      type: object
+      required: [west, east]
+      properties:
+        west: {type: number}
+        east: {type: number}
+      example: {west: 16.1, east: 16.6}
+      examples:
+        - {west: 16.1, east: 16.6}
+        - {west: 16.1}
+        - {west: 16.1, east: '16.6'}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	require.Len(t, swagger.Components.Schemas["BoundingBox"].Value.Examples, 3)
+
+	errs := swagger.ValidateExamples(context.Background())
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], `invalid example at #/components/schemas/BoundingBox/examples/1: example omits the required property "/east"`)
+	require.Equal(t, "#/components/schemas/BoundingBox/examples/2", errs[1].Pointer)
+}
+
+func TestValidateBinaryExamples(t *testing.T) {
+	spec := []byte(`
+openapi: 3.1.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Thumbnail: {type: string, format: byte, example: iVBORw0KGgo}
+    Upload:
+      type: object
+      properties:
+        data: {type: string, format: byte}
+        file: {type: string, format: binary}
+        encoded: {type: string, format: binary, contentEncoding: base64}
+paths:
+  /files/{path}:
+    put:
+      parameters:
+        - {name: path, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/octet-stream:
+            schema: {type: string, format: binary}
+            examples:
+              text: {value: "GeoTIFF content"}
+              raw: {value: "II*\u0000\u0008\u0000"}
+          application/json:
+            schema: {$ref: '#/components/schemas/Upload'}
+            examples:
+              valid: {value: {data: aGVsbG8=, file: content, encoded: aGVsbG8=}}
+              invalid: {value: {data: a, file: "\u0089PNG"}}
+              pattern: {value: {data: "not base64"}}
+      responses:
+        '204': {description: uploaded}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, err := range swagger.ValidateExamples(context.Background()) {
+		messages = append(messages, err.Error())
+	}
+	require.Len(t, messages, 4)
+	require.Equal(t, []string{
+		`invalid example at #/paths/~1files~1{path}/put/requestBody/content/application~1json/examples/invalid/value: example value "/data" of format byte isn't base64 encoded: illegal base64 data at input byte 0`,
+		`invalid example at #/paths/~1files~1{path}/put/requestBody/content/application~1json/examples/invalid/value: example value "/file" of format binary is raw binary content, encode it with the contentEncoding base64 or omit it`,
+	}, messages[:2])
+	require.Contains(t, messages[2], "invalid example at #/paths/~1files~1{path}/put/requestBody/content/application~1json/examples/pattern/value: ")
+	require.Equal(t, `invalid example at #/paths/~1files~1{path}/put/requestBody/content/application~1octet-stream/examples/raw/value: example of format binary is raw binary content, encode it with the contentEncoding base64 or omit it`, messages[3])
+}
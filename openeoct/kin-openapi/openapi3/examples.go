@@ -1,6 +1,9 @@
 package openapi3
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
 
@@ -27,3 +30,13 @@ func (example *Example) MarshalJSON() ([]byte, error) {
 func (example *Example) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, example)
 }
+
+// validateExampleKeys rejects blank keys of an examples map.
+func validateExampleKeys(examples map[string]*ExampleRef) error {
+	for key := range examples {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("example key can't be blank")
+		}
+	}
+	return nil
+}
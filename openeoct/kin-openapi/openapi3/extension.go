@@ -1,6 +1,12 @@
 package openapi3
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
 
@@ -36,3 +42,74 @@ func (props *ExtensionProps) DecodeWith(decoder *jsoninfo.ObjectDecoder, value i
 	props.Extensions = result
 	return nil
 }
+
+// ExtensionValidator validates the value of an extension, see ValidationOptions.
+type ExtensionValidator func(c context.Context, value interface{}) error
+
+// SchemaExtensionValidator returns an ExtensionValidator which requires values to match schema.
+func SchemaExtensionValidator(schema *Schema) ExtensionValidator {
+	return func(c context.Context, value interface{}) error {
+		return schema.VisitJSON(value)
+	}
+}
+
+// EnumLabelsExtensionValidator validates an extension labelling the enum values
+// of a schema, e.g. the openEO labels registered as "x-openeo-labels": it must be
+// an array with as many labels as the schema has enum values.
+func EnumLabelsExtensionValidator(c context.Context, value interface{}) error {
+	schema, ok := ExtensionOwner(c).(*Schema)
+	if !ok {
+		return errors.New("labels only apply to schemas")
+	}
+	labels, ok := value.([]interface{})
+	if !ok {
+		return errors.New("labels must be an array")
+	}
+	if len(schema.Enum) == 0 {
+		return errors.New("labels require an enum")
+	}
+	if len(labels) != len(schema.Enum) {
+		return fmt.Errorf("has %d labels for %d enum values", len(labels), len(schema.Enum))
+	}
+	return nil
+}
+
+type extensionOwnerKey struct{}
+
+// ExtensionOwner returns the object, e.g. the *Schema or *Operation, whose
+// extension an ExtensionValidator validates, nil for other contexts.
+func ExtensionOwner(c context.Context) interface{} {
+	if c == nil {
+		return nil
+	}
+	return c.Value(extensionOwnerKey{})
+}
+
+// validateExtensions runs the extension validators of the validation options
+// for the extensions present of owner.
+func (props *ExtensionProps) validateExtensions(c context.Context, owner interface{}) error {
+	options := getValidationOptions(c)
+	if options == nil || len(options.ExtensionValidators) == 0 || len(props.Extensions) == 0 {
+		return nil
+	}
+	c = context.WithValue(c, extensionOwnerKey{}, owner)
+	names := make([]string, 0, len(props.Extensions))
+	for name := range props.Extensions {
+		if _, ok := options.ExtensionValidators[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := props.Extensions[name]
+		if raw, ok := value.(json.RawMessage); ok {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("extension %q is invalid: %v", name, err)
+			}
+		}
+		if err := options.ExtensionValidators[name](c, value); err != nil {
+			return fmt.Errorf("extension %q is invalid: %v", name, err)
+		}
+	}
+	return nil
+}
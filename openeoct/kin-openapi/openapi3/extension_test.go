@@ -1,11 +1,15 @@
 package openapi3_test
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtensionProps_EncodeWith(t *testing.T) {
@@ -99,3 +103,84 @@ func TestExtensionProps_DecodeWith(t *testing.T) {
 		assert.Empty(t, value.Field4)
 	})
 }
+
+func TestExtensionValidators(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Process:
+      type: object
+      x-openeo-category: [math]
+paths:
+  /processes/{process_id}:
+    get:
+      x-openeo-process-id: PROCESS_ID
+      parameters: [{name: process_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200': {description: ok}
+`)
+	validate := func(processID string, categories openapi3.ExtensionValidator) error {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(
+			[]byte(strings.Replace(string(spec), "PROCESS_ID", processID, 1)))
+		require.NoError(t, err)
+		options := &openapi3.ValidationOptions{
+			ExtensionValidators: map[string]openapi3.ExtensionValidator{
+				"x-openeo-process-id": openapi3.SchemaExtensionValidator(
+					openapi3.NewStringSchema().WithMinLength(1).WithPattern("^[a-z]\\w*$")),
+				"x-openeo-category": categories,
+			},
+		}
+		return swagger.Validate(openapi3.WithValidationOptions(context.Background(), options))
+	}
+	valid := func(c context.Context, value interface{}) error { return nil }
+
+	require.NoError(t, validate("ndvi", valid))
+	err := validate("'NDVI'", valid)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `extension "x-openeo-process-id" is invalid`)
+
+	err = validate("ndvi", func(c context.Context, value interface{}) error {
+		require.Equal(t, []interface{}{"math"}, value)
+		return errors.New("unknown category")
+	})
+	require.EqualError(t, err, `invalid components: extension "x-openeo-category" is invalid: unknown category`)
+}
+
+func TestEnumLabelsExtensionValidator(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [created, running, finished]
+            x-openeo-labels: LABELS
+      responses:
+        '200': {description: ok}
+`
+	validate := func(labels string) error {
+		swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(strings.Replace(spec, "LABELS", labels, 1)))
+		require.NoError(t, err)
+		options := &openapi3.ValidationOptions{
+			ExtensionValidators: map[string]openapi3.ExtensionValidator{
+				"x-openeo-labels": openapi3.EnumLabelsExtensionValidator,
+			},
+		}
+		return swagger.Validate(openapi3.WithValidationOptions(context.Background(), options))
+	}
+
+	require.NoError(t, validate("[Created, Running, Finished]"))
+	err := validate("[Created, Running]")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `extension "x-openeo-labels" is invalid: has 2 labels for 3 enum values`)
+	err = validate("Created")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `extension "x-openeo-labels" is invalid: labels must be an array`)
+}
@@ -0,0 +1,193 @@
+package openapi3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Federation is a family of related documents which are validated together,
+// e.g. the core API of openEO and its extensions. Besides the validation of
+// each document, Validate checks that the documents don't conflict.
+type Federation struct {
+	// Names identify the documents in errors, e.g. their file names.
+	Names     []string
+	Documents []*Swagger
+}
+
+// NewFederation returns an empty federation.
+func NewFederation() *Federation {
+	return &Federation{}
+}
+
+// Add adds the document to the federation, identified by name.
+func (federation *Federation) Add(name string, swagger *Swagger) {
+	federation.Names = append(federation.Names, name)
+	federation.Documents = append(federation.Documents, swagger)
+}
+
+// LoadFederation loads the documents at the file paths, which identify them in
+// the federation.
+func (swaggerLoader *SwaggerLoader) LoadFederation(paths ...string) (*Federation, error) {
+	federation := NewFederation()
+	for _, path := range paths {
+		swagger, err := swaggerLoader.LoadSwaggerFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %q: %v", path, err)
+		}
+		federation.Add(path, swagger)
+	}
+	return federation, nil
+}
+
+// FederationError is the validation error of a document of a federation, or a
+// conflict between two of its documents.
+type FederationError struct {
+	// Documents are the names of the invalid or conflicting documents.
+	Documents []string
+	// Pointer locates the conflict in the last document.
+	Pointer string
+	Reason  string
+	// Err is the error of an invalid document.
+	Err error
+}
+
+func (err *FederationError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("document %q: %v", err.Documents[0], err.Err)
+	}
+	quoted := make([]string, 0, len(err.Documents))
+	for _, name := range err.Documents {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+	return fmt.Sprintf("documents %s conflict at %s: %s", strings.Join(quoted, " and "), err.Pointer, err.Reason)
+}
+
+func (err *FederationError) Unwrap() error {
+	return err.Err
+}
+
+// Validate validates each document, and reports conflicts between them:
+//
+//   - operations of different documents with the same operationId
+//   - component schemas of the same name with different definitions
+//   - documents declaring servers without a server URL in common
+//
+// It returns all errors, the errors of the documents first, or nil if the
+// documents are valid and don't conflict.
+func (federation *Federation) Validate(c context.Context) []*FederationError {
+	var errs []*FederationError
+	for i, swagger := range federation.Documents {
+		if err := swagger.Validate(c); err != nil {
+			errs = append(errs, &FederationError{Documents: []string{federation.Names[i]}, Err: err})
+		}
+	}
+	errs = append(errs, federation.operationIDConflicts()...)
+	errs = append(errs, federation.schemaConflicts()...)
+	errs = append(errs, federation.serverConflicts()...)
+	return errs
+}
+
+func (federation *Federation) operationIDConflicts() []*FederationError {
+	type operation struct {
+		document int
+		info     *OperationInfo
+	}
+	var errs []*FederationError
+	operations := make(map[string]operation)
+	for i, swagger := range federation.Documents {
+		for _, info := range swagger.Operations() {
+			id := info.OperationID
+			if id == "" {
+				continue
+			}
+			other, ok := operations[id]
+			if !ok {
+				operations[id] = operation{document: i, info: info}
+				continue
+			}
+			// Duplicates within a document are left to its own validation
+			if other.document != i {
+				errs = append(errs, &FederationError{
+					Documents: []string{federation.Names[other.document], federation.Names[i]},
+					Pointer:   "#/paths/" + escapeJSONPointerToken(info.Path) + "/" + strings.ToLower(info.Method),
+					Reason:    fmt.Sprintf("operationId %q of %s %s is also the operationId of %s %s", id, info.Method, info.Path, other.info.Method, other.info.Path),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+func (federation *Federation) schemaConflicts() []*FederationError {
+	type schema struct {
+		document int
+		data     []byte
+	}
+	var errs []*FederationError
+	schemas := make(map[string]schema)
+	for i, swagger := range federation.Documents {
+		for _, name := range sortedMapKeys(swagger.Components.Schemas) {
+			ref := swagger.Components.Schemas[name]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			// The definitions are compared, so a reference to the schema of
+			// another document is the same schema
+			data, err := canonicalJSON(ref.Value)
+			if err != nil {
+				continue
+			}
+			other, ok := schemas[name]
+			if !ok {
+				schemas[name] = schema{document: i, data: data}
+				continue
+			}
+			if !bytes.Equal(other.data, data) {
+				errs = append(errs, &FederationError{
+					Documents: []string{federation.Names[other.document], federation.Names[i]},
+					Pointer:   "#/components/schemas/" + escapeJSONPointerToken(name),
+					Reason:    fmt.Sprintf("schema %q has different definitions", name),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+func (federation *Federation) serverConflicts() []*FederationError {
+	var errs []*FederationError
+	for i, swagger := range federation.Documents {
+		for j := 0; j < i; j++ {
+			if !serversOverlap(federation.Documents[j].Servers, swagger.Servers) {
+				errs = append(errs, &FederationError{
+					Documents: []string{federation.Names[j], federation.Names[i]},
+					Pointer:   "#/servers",
+					Reason:    "the documents have no server URL in common",
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// serversOverlap returns whether the server lists have a URL in common, or
+// either of them is empty.
+func serversOverlap(servers Servers, others Servers) bool {
+	if len(servers) == 0 || len(others) == 0 {
+		return true
+	}
+	urls := make(map[string]bool, len(servers))
+	for _, server := range servers {
+		if server != nil {
+			urls[strings.TrimSuffix(server.URL, "/")] = true
+		}
+	}
+	for _, server := range others {
+		if server != nil && urls[strings.TrimSuffix(server.URL, "/")] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package openapi3_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederation(t *testing.T) {
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	federation, err := loader.LoadFederation("testdata/federation/core.openapi.yml", "testdata/federation/extension.openapi.yml")
+	require.NoError(t, err)
+	require.Empty(t, federation.Validate(context.Background()))
+
+	extension := `
+openapi: 3.0.0
+info: {title: An extension, version: 1.0.0}
+servers:
+  - url: https://other.example.com
+components:
+  schemas:
+    job_id: {type: string}
+paths:
+  /jobs/{job_id}/estimate:
+    get:
+      operationId: describe-job
+      parameters:
+        - {name: job_id, in: path, required: true, schema: {$ref: '#/components/schemas/job_id'}}
+      responses:
+        '200': {description: The estimate of the job.}
+  /jobs:
+    get:
+      responses: {}
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(extension))
+	require.NoError(t, err)
+	federation.Add("estimates", swagger)
+	errs := federation.Validate(context.Background())
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	require.Len(t, errs, 5, strings.Join(messages, "\n"))
+
+	require.Equal(t, []string{"estimates"}, errs[0].Documents)
+	require.Error(t, errs[0].Err)
+	require.Equal(t, []string{"testdata/federation/core.openapi.yml", "estimates"}, errs[1].Documents)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1estimate/get", errs[1].Pointer)
+	require.Equal(t, `documents "testdata/federation/core.openapi.yml" and "estimates" conflict at #/paths/~1jobs~1{job_id}~1estimate/get: `+
+		`operationId "describe-job" of GET /jobs/{job_id}/estimate is also the operationId of GET /jobs/{job_id}`, messages[1])
+	require.Equal(t, "#/components/schemas/job_id", errs[2].Pointer)
+	require.Equal(t, `schema "job_id" has different definitions`, errs[2].Reason)
+	require.Equal(t, "#/servers", errs[3].Pointer)
+	require.Equal(t, []string{"testdata/federation/extension.openapi.yml", "estimates"}, errs[4].Documents)
+}
@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -24,10 +25,18 @@ func (value *Header) UnmarshalJSON(data []byte) error {
 }
 
 func (value *Header) Validate(c context.Context) error {
+	if (value.Schema == nil) == (value.Content == nil) {
+		return errors.New("header must contain exactly one of content and schema")
+	}
 	if v := value.Schema; v != nil {
 		if err := v.Validate(c); err != nil {
 			return err
 		}
 	}
+	if v := value.Content; v != nil {
+		if err := v.Validate(c); err != nil {
+			return err
+		}
+	}
 	return nil
 }
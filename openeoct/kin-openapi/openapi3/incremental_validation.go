@@ -0,0 +1,167 @@
+package openapi3
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// IncrementalValidator validates a document which is built or edited
+// programmatically. After a change it only validates the changed parts of the
+// document again, along with the parts which reference them. Changes aren't
+// observed and must be reported with MarkDirty. (*Swagger).Validate remains
+// the authoritative check, e.g. after changes which weren't reported.
+type IncrementalValidator struct {
+	swagger *Swagger
+	// results are the errors of the validated parts by pointer, nil before the first validation.
+	results map[string]error
+	dirty   []string
+}
+
+// NewIncrementalValidator returns a validator of swagger. Its first Revalidate
+// validates the whole document.
+func NewIncrementalValidator(swagger *Swagger) *IncrementalValidator {
+	return &IncrementalValidator{swagger: swagger}
+}
+
+// MarkDirty reports a change of the document part at the JSON pointer, e.g.
+// "#/components/schemas/Job" or "#/paths/~1jobs/post", including additions and
+// removals. The next Revalidate validates the part again.
+func (v *IncrementalValidator) MarkDirty(pointer string) {
+	if !strings.HasPrefix(pointer, "#") {
+		pointer = "#" + pointer
+	}
+	v.dirty = append(v.dirty, strings.TrimSuffix(pointer, "/"))
+}
+
+// Revalidate validates the document parts which were marked dirty or reference
+// them, directly or through other parts, and the parts added since the last
+// validation. The results of the other parts are kept. Like (*Swagger).Validate
+// it returns the first error of the document, so the validation options of c
+// should be the same for all calls.
+func (v *IncrementalValidator) Revalidate(c context.Context) error {
+	c = v.swagger.validationContext(c)
+	tasks := v.swagger.validationTasks(c)
+	affected := v.affectedParts(tasks)
+
+	results := make(map[string]error, len(tasks))
+	for _, task := range tasks {
+		err, ok := v.results[task.pointer]
+		if !ok || affected[task.pointer] {
+			err = task.run(c)
+		}
+		results[task.pointer] = err
+	}
+	v.results = results
+	v.dirty = nil
+
+	for _, task := range tasks {
+		if err := results[task.pointer]; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// affectedParts returns the pointers of the parts validated by tasks which
+// are dirty or depend on dirty parts.
+func (v *IncrementalValidator) affectedParts(tasks []validationTask) map[string]bool {
+	affected := make(map[string]bool)
+	if len(v.dirty) == 0 {
+		return affected
+	}
+	for _, pointer := range v.dirty {
+		// Security requirements anywhere in the document depend on the security schemes
+		if pointer == "#" || pointerContains("#/components/securitySchemes", pointer) {
+			for _, task := range tasks {
+				affected[task.pointer] = true
+			}
+			return affected
+		}
+	}
+
+	changed := append([]string(nil), v.dirty...)
+	refs := make(map[string][]string, len(tasks))
+	for _, task := range tasks {
+		for _, pointer := range v.dirty {
+			if pointerContains(task.pointer, pointer) || pointerContains(pointer, task.pointer) {
+				affected[task.pointer] = true
+				changed = append(changed, task.pointer)
+				break
+			}
+		}
+		refs[task.pointer] = collectLocalRefs(task.value)
+	}
+
+	// Parts referencing changed parts are affected too, until no more parts are
+	for found := true; found; {
+		found = false
+		for _, task := range tasks {
+			if affected[task.pointer] {
+				continue
+			}
+		search:
+			for _, ref := range refs[task.pointer] {
+				for _, pointer := range changed {
+					if pointerContains(pointer, ref) || pointerContains(ref, pointer) {
+						affected[task.pointer] = true
+						changed = append(changed, task.pointer)
+						found = true
+						break search
+					}
+				}
+			}
+		}
+	}
+	return affected
+}
+
+// pointerContains returns whether the document part at pointer contains the one at other.
+func pointerContains(pointer string, other string) bool {
+	return other == pointer || strings.HasPrefix(other, pointer+"/")
+}
+
+// collectLocalRefs returns the references of value to parts of the same document,
+// e.g. "#/components/schemas/Job". Referenced values aren't searched further.
+func collectLocalRefs(value interface{}) []string {
+	var refs []string
+	visited := make(map[uintptr]bool)
+	var collect func(v reflect.Value)
+	collect = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() || visited[v.Pointer()] {
+				return
+			}
+			visited[v.Pointer()] = true
+			collect(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				collect(v.Elem())
+			}
+		case reflect.Struct:
+			if ref := v.FieldByName("Ref"); ref.IsValid() && ref.Kind() == reflect.String && v.FieldByName("Value").IsValid() {
+				if s := ref.String(); strings.HasPrefix(s, "#/") {
+					refs = append(refs, s)
+					return
+				}
+			}
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).PkgPath == "" {
+					collect(v.Field(i))
+				}
+			}
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				collect(iter.Value())
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				collect(v.Index(i))
+			}
+		}
+	}
+	collect(reflect.ValueOf(value))
+	return refs
+}
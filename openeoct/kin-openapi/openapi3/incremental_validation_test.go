@@ -0,0 +1,72 @@
+package openapi3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalValidator(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  parameters:
+    path: {name: path, in: path, required: true, schema: {type: string}}
+paths:
+  /files/{+path}:
+    get:
+      x-operation: files
+      parameters: [{$ref: '#/components/parameters/path'}]
+      responses: {'200': {description: ok}}
+  /jobs:
+    get:
+      x-operation: jobs
+      responses: {'200': {description: ok}}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	validated := make(map[string]int)
+	options := &openapi3.ValidationOptions{
+		ExtensionValidators: map[string]openapi3.ExtensionValidator{
+			"x-operation": func(c context.Context, value interface{}) error {
+				validated[value.(string)]++
+				return nil
+			},
+		},
+	}
+	c := openapi3.WithValidationOptions(context.Background(), options)
+	validator := openapi3.NewIncrementalValidator(swagger)
+	require.NoError(t, validator.Revalidate(c))
+	require.Equal(t, map[string]int{"files": 1, "jobs": 1}, validated)
+
+	// Unchanged parts aren't validated again
+	require.NoError(t, validator.Revalidate(c))
+	require.Equal(t, map[string]int{"files": 1, "jobs": 1}, validated)
+
+	// Parts referencing a changed component are validated again
+	swagger.Components.Parameters["path"].Value.Schema = openapi3.NewIntegerSchema().NewRef()
+	validator.MarkDirty("#/components/parameters/path")
+	require.EqualError(t, validator.Revalidate(c),
+		`invalid paths: catch-all parameter "path" of path "/files/{+path}" must have a string schema, not "integer"`)
+	require.Equal(t, map[string]int{"files": 1, "jobs": 1}, validated)
+	require.Error(t, swagger.Validate(c))
+
+	// Errors of unchanged parts are kept
+	swagger.Paths["/jobs"].Get.Responses = openapi3.Responses{}
+	validator.MarkDirty("/paths/~1jobs/get")
+	err = validator.Revalidate(c)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "catch-all parameter")
+	require.Equal(t, map[string]int{"files": 1, "jobs": 2}, validated)
+
+	swagger.Components.Parameters["path"].Value.Schema = openapi3.NewStringSchema().NewRef()
+	validator.MarkDirty("#/components/parameters/path")
+	require.EqualError(t, validator.Revalidate(c),
+		"invalid paths: the responses object of the operation at #/paths/~1jobs/get MUST contain at least one response code")
+	require.Equal(t, map[string]int{"files": 2, "jobs": 2}, validated)
+	require.EqualError(t, swagger.Validate(c), validator.Revalidate(c).Error())
+}
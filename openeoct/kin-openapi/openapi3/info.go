@@ -3,6 +3,8 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/mail"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -67,6 +69,11 @@ func (value *Contact) UnmarshalJSON(data []byte) error {
 }
 
 func (value *Contact) Validate(c context.Context) error {
+	if email := value.Email; email != "" {
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			return fmt.Errorf("value of contact email %q must be an email address", email)
+		}
+	}
 	return nil
 }
 
@@ -75,6 +82,8 @@ type License struct {
 	ExtensionProps
 	Name string `json:"name" yaml:"name"` // Required
 	URL  string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Identifier is an SPDX license expression (OpenAPI 3.1).
+	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
 }
 
 func (value *License) MarshalJSON() ([]byte, error) {
@@ -86,8 +95,8 @@ func (value *License) UnmarshalJSON(data []byte) error {
 }
 
 func (value *License) Validate(c context.Context) error {
-	if value.Name == "" {
-		return errors.New("value of license name must be a non-empty JSON string")
+	if value.Name == "" && value.Identifier == "" {
+		return errors.New("value of license name or identifier must be a non-empty JSON string")
 	}
 	return nil
 }
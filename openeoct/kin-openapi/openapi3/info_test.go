@@ -0,0 +1,64 @@
+package openapi3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoValidation(t *testing.T) {
+	valid := func() *openapi3.Info {
+		return &openapi3.Info{
+			Title:   "openEO API",
+			Version: "1.0.0",
+			Contact: &openapi3.Contact{Email: "openeo.psc@uni-muenster.de"},
+			License: &openapi3.License{Name: "Apache 2.0"},
+		}
+	}
+	require.NoError(t, valid().Validate(context.Background()))
+
+	for name, tc := range map[string]struct {
+		change      func(info *openapi3.Info)
+		expectedErr string
+	}{
+		"no title": {
+			func(info *openapi3.Info) { info.Title = "" },
+			"value of title must be a non-empty JSON string",
+		},
+		"no version": {
+			func(info *openapi3.Info) { info.Version = "" },
+			"value of version must be a non-empty JSON string",
+		},
+		"invalid contact email": {
+			func(info *openapi3.Info) { info.Contact.Email = "openeo.psc at uni-muenster.de" },
+			`value of contact email "openeo.psc at uni-muenster.de" must be an email address`,
+		},
+		"contact email with name": {
+			func(info *openapi3.Info) { info.Contact.Email = "openEO PSC <openeo.psc@uni-muenster.de>" },
+			`value of contact email "openEO PSC <openeo.psc@uni-muenster.de>" must be an email address`,
+		},
+		"license identifier": {
+			func(info *openapi3.Info) { info.License = &openapi3.License{Identifier: "Apache-2.0"} },
+			"",
+		},
+		"no license name or identifier": {
+			func(info *openapi3.Info) {
+				info.License = &openapi3.License{URL: "https://www.apache.org/licenses/LICENSE-2.0"}
+			},
+			"value of license name or identifier must be a non-empty JSON string",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			info := valid()
+			tc.change(info)
+			err := info.Validate(context.Background())
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -36,3 +37,30 @@ func (value *Link) Validate(c context.Context) error {
 	}
 	return nil
 }
+
+// LinkedOperation returns the operation of the document targeted by the link,
+// either by its operationId or by a local operationRef such as
+// "#/paths/~1jobs/get". It returns nil if the target isn't found.
+func (swagger *Swagger) LinkedOperation(link *Link) *OperationInfo {
+	var path, method string
+	if ref := link.OperationRef; ref != "" {
+		const prefix = "#/paths/"
+		if !strings.HasPrefix(ref, prefix) {
+			return nil
+		}
+		i := strings.LastIndex(ref, "/")
+		if i < len(prefix) {
+			return nil
+		}
+		path, method = unescapeRefString(ref[len(prefix):i]), strings.ToUpper(ref[i+1:])
+	}
+	for _, info := range swagger.Operations() {
+		if link.OperationID != "" && info.OperationID == link.OperationID {
+			return info
+		}
+		if link.OperationRef != "" && info.Path == path && info.Method == method {
+			return info
+		}
+	}
+	return nil
+}
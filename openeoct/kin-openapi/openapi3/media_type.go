@@ -70,6 +70,9 @@ func (mediaType *MediaType) Validate(c context.Context) error {
 	if mediaType == nil {
 		return nil
 	}
+	if err := validateExampleKeys(mediaType.Examples); err != nil {
+		return err
+	}
 	if schema := mediaType.Schema; schema != nil {
 		if err := schema.Validate(c); err != nil {
 			return err
@@ -72,3 +72,16 @@ func mediaType() *openapi3.MediaType {
 		},
 	}
 }
+
+func TestBlankExampleKey(t *testing.T) {
+	examples := map[string]*openapi3.ExampleRef{
+		" ": {Value: openapi3.NewExample("blank")},
+	}
+	mediaType := openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema())
+	mediaType.Examples = examples
+	require.EqualError(t, mediaType.Validate(context.Background()), "example key can't be blank")
+
+	parameter := openapi3.NewQueryParameter("q").WithSchema(openapi3.NewStringSchema())
+	parameter.Examples = examples
+	require.EqualError(t, parameter.Validate(context.Background()), `parameter "q" examples are invalid: example key can't be blank`)
+}
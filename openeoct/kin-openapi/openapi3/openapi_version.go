@@ -0,0 +1,82 @@
+package openapi3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OpenAPIVersion is a version of the OpenAPI Specification, e.g. 3.0.3.
+type OpenAPIVersion struct {
+	Major, Minor, Patch int
+}
+
+// LatestTestedOpenAPIVersions are the latest versions of each supported minor
+// version which the package is tested with.
+var LatestTestedOpenAPIVersions = []OpenAPIVersion{{3, 0, 3}, {3, 1, 0}}
+
+// ParseOpenAPIVersion parses the version of the openapi field of a document,
+// e.g. "3.0.3". The patch version defaults to 0.
+func ParseOpenAPIVersion(version string) (OpenAPIVersion, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 2 && len(parts) != 3 {
+		return OpenAPIVersion{}, fmt.Errorf("invalid OpenAPI version %q, expected a version like 3.0.3", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strings.HasPrefix(part, "+") {
+			return OpenAPIVersion{}, fmt.Errorf("invalid OpenAPI version %q, expected a version like 3.0.3", version)
+		}
+		numbers[i] = n
+	}
+	return OpenAPIVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+func (version OpenAPIVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+}
+
+// LatestTested returns the latest tested version of the minor version, false
+// if the minor version isn't supported.
+func (version OpenAPIVersion) LatestTested() (OpenAPIVersion, bool) {
+	for _, tested := range LatestTestedOpenAPIVersions {
+		if tested.Major == version.Major && tested.Minor == version.Minor {
+			return tested, true
+		}
+	}
+	return OpenAPIVersion{}, false
+}
+
+// Supported returns whether the package supports the minor version.
+func (version OpenAPIVersion) Supported() bool {
+	_, ok := version.LatestTested()
+	return ok
+}
+
+// Tested returns whether the package is tested with the version, which is
+// supported and not a newer patch version than the latest tested one.
+func (version OpenAPIVersion) Tested() bool {
+	tested, ok := version.LatestTested()
+	return ok && version.Patch <= tested.Patch
+}
+
+// validateOpenAPIVersion checks that the package supports the version of the
+// openapi field, if any. A missing version is left to the validation.
+func validateOpenAPIVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	parsed, err := ParseOpenAPIVersion(version)
+	if err != nil {
+		return err
+	}
+	if !parsed.Supported() {
+		supported := make([]string, 0, len(LatestTestedOpenAPIVersions))
+		for _, tested := range LatestTestedOpenAPIVersions {
+			supported = append(supported, fmt.Sprintf("%d.%d.x", tested.Major, tested.Minor))
+		}
+		return fmt.Errorf("unsupported OpenAPI version %q, the supported versions are %s", version, strings.Join(supported, " and "))
+	}
+	return nil
+}
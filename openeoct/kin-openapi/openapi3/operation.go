@@ -3,7 +3,9 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -81,6 +83,9 @@ func (operation *Operation) AddResponse(status int, response *Response) {
 }
 
 func (operation *Operation) Validate(c context.Context) error {
+	if err := operation.validateExtensions(c, operation); err != nil {
+		return err
+	}
 	if v := operation.Parameters; v != nil {
 		if err := v.Validate(c); err != nil {
 			return err
@@ -92,11 +97,132 @@ func (operation *Operation) Validate(c context.Context) error {
 		}
 	}
 	if v := operation.Responses; v != nil {
+		if pointer := validationPointer(c); len(v) == 0 && pointer != "#" {
+			return fmt.Errorf("the responses object of the operation at %s MUST contain at least one response code", pointer)
+		}
 		if err := v.Validate(c); err != nil {
 			return err
 		}
 	} else {
 		return errors.New("value of responses must be a JSON object")
 	}
+	if v := operation.Security; v != nil {
+		if err := v.Validate(c); err != nil {
+			return err
+		}
+	}
+	for name, v := range operation.Callbacks {
+		if v == nil || v.Value == nil {
+			// References to callbacks are not resolved by the loader
+			continue
+		}
+		if err := v.Value.Validate(c); err != nil {
+			return fmt.Errorf("invalid callback %q: %v", name, err)
+		}
+	}
 	return nil
 }
+
+// RequestContentTypes returns the media types of the request bodies which the
+// operation accepts, in the order of (Content).MediaTypes, or nil if it declares
+// no request body.
+func (operation *Operation) RequestContentTypes() []string {
+	if ref := operation.RequestBody; ref != nil && ref.Value != nil && len(ref.Value.Content) != 0 {
+		return ref.Value.Content.MediaTypes()
+	}
+	return nil
+}
+
+// ResponseContentTypes returns the media types which the operation responds
+// with for the HTTP status code, in the order of (Content).MediaTypes, taking
+// the response of the code, its range or the default like (Responses).Status.
+// It's nil if the response declares no content.
+func (operation *Operation) ResponseContentTypes(status int) []string {
+	if ref := operation.Responses.Status(status); ref != nil && ref.Value != nil && len(ref.Value.Content) != 0 {
+		return ref.Value.Content.MediaTypes()
+	}
+	return nil
+}
+
+// ReachableSchema is a schema reachable from an operation, see (*Operation).ReachableSchemas.
+type ReachableSchema struct {
+	// Name is the name of the schema in the components of the document, "" for inline schemas.
+	Name   string
+	Schema *Schema
+}
+
+// ReachableSchemas returns every resolved schema which the parameters, request
+// body and responses of the operation reference, including nested schemas, each
+// once and in a stable order. Components of swagger give the names of schemas.
+// Parameters of the path item aren't included, as the operation doesn't know it.
+func (operation *Operation) ReachableSchemas(swagger *Swagger) []*ReachableSchema {
+	names := make(map[*Schema]string)
+	if swagger != nil {
+		for _, name := range sortedMapKeys(swagger.Components.Schemas) {
+			if ref := swagger.Components.Schemas[name]; ref != nil && ref.Value != nil && names[ref.Value] == "" {
+				names[ref.Value] = name
+			}
+		}
+	}
+	var reachable []*ReachableSchema
+	visited := make(map[*Schema]bool)
+	var visit func(ref *SchemaRef)
+	visit = func(ref *SchemaRef) {
+		if ref == nil || ref.Value == nil || visited[ref.Value] {
+			return
+		}
+		schema := ref.Value
+		visited[schema] = true
+		name := names[schema]
+		if name == "" && strings.HasPrefix(ref.Ref, "#/components/schemas/") {
+			name = strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+		}
+		reachable = append(reachable, &ReachableSchema{Name: name, Schema: schema})
+
+		for _, key := range sortedMapKeys(schema.Properties) {
+			visit(schema.Properties[key])
+		}
+		visit(schema.AdditionalProperties)
+		visit(schema.Items)
+		for _, lists := range [][]*SchemaRef{schema.PrefixItems, schema.AllOf, schema.AnyOf, schema.OneOf} {
+			for _, item := range lists {
+				visit(item)
+			}
+		}
+		visit(schema.Not)
+		visit(schema.If)
+		visit(schema.Then)
+		visit(schema.Else)
+	}
+	visitContent := func(content Content) {
+		for _, mediaType := range sortedMapKeys(content) {
+			if v := content[mediaType]; v != nil {
+				visit(v.Schema)
+			}
+		}
+	}
+
+	for _, ref := range operation.Parameters {
+		if ref != nil && ref.Value != nil {
+			visit(ref.Value.Schema)
+			visitContent(ref.Value.Content)
+		}
+	}
+	if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+		visitContent(ref.Value.Content)
+	}
+	for _, status := range sortedMapKeys(operation.Responses) {
+		response := operation.Responses[status]
+		if response == nil || response.Value == nil {
+			continue
+		}
+		for _, name := range sortedMapKeys(response.Value.Headers) {
+			if header := response.Value.Headers[name]; header != nil && header.Value != nil {
+				visit(header.Value.Schema)
+				visitContent(header.Value.Content)
+			}
+		}
+		visitContent(response.Value.Content)
+	}
+	return reachable
+}
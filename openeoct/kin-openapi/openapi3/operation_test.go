@@ -71,3 +71,106 @@ func TestOperationValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestReachableSchemas(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    ProcessGraph:
+      type: object
+      additionalProperties: {$ref: '#/components/schemas/ProcessNode'}
+    ProcessNode:
+      type: object
+      properties:
+        arguments:
+          type: object
+          additionalProperties: {$ref: '#/components/schemas/ProcessGraph'}
+        process_id: {$ref: '#/components/schemas/ProcessId'}
+    ProcessId: {type: string}
+    Error: {type: object, properties: {code: {type: string}}}
+    Unused: {type: string}
+paths:
+  /jobs/{job_id}:
+    patch:
+      parameters:
+        - {name: job_id, in: path, required: true, schema: {$ref: '#/components/schemas/ProcessId'}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                process_graph: {$ref: '#/components/schemas/ProcessGraph'}
+      responses:
+        '204':
+          description: ok
+          headers:
+            OpenEO-Costs: {schema: {type: number}}
+        4XX:
+          description: error
+          content: {application/json: {schema: {$ref: '#/components/schemas/Error'}}}
+`)
+	swagger, err := NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	var names []string
+	for _, reachable := range swagger.Paths["/jobs/{job_id}"].Patch.ReachableSchemas(swagger) {
+		require.NotNil(t, reachable.Schema)
+		names = append(names, reachable.Name)
+	}
+	require.Equal(t, []string{
+		"ProcessId",
+		"", "ProcessGraph", "ProcessNode", "", // the request body and the arguments are inline
+		"", // OpenEO-Costs header
+		"Error", "",
+	}, names)
+}
+
+func TestOperationContentTypes(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  requestBodies:
+    Upload:
+      content:
+        '*/*': {}
+        image/tiff: {}
+        application/octet-stream: {}
+        image/*: {}
+  responses:
+    Error:
+      description: error
+      content: {application/json: {}, text/plain: {}}
+paths:
+  /files/{path}:
+    put:
+      parameters:
+        - {name: path, in: path, required: true, schema: {type: string}}
+      requestBody: {$ref: '#/components/requestBodies/Upload'}
+      responses:
+        '200':
+          description: ok
+          content: {application/json: {}}
+        '204': {description: no content}
+        4XX: {$ref: '#/components/responses/Error'}
+    delete:
+      responses:
+        default: {description: ok}
+`)
+	swagger, err := NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	put := swagger.Paths["/files/{path}"].Put
+	require.Equal(t, []string{"application/octet-stream", "image/tiff", "image/*", "*/*"}, put.RequestContentTypes())
+	require.Equal(t, []string{"application/json"}, put.ResponseContentTypes(200))
+	require.Nil(t, put.ResponseContentTypes(204))
+	require.Equal(t, []string{"application/json", "text/plain"}, put.ResponseContentTypes(404))
+	require.Nil(t, put.ResponseContentTypes(500))
+
+	del := swagger.Paths["/files/{path}"].Delete
+	require.Nil(t, del.RequestContentTypes())
+	require.Nil(t, del.ResponseContentTypes(200))
+}
@@ -17,6 +17,9 @@ func NewParameters() Parameters {
 
 func (parameters Parameters) GetByInAndName(in string, name string) *Parameter {
 	for _, item := range parameters {
+		if item == nil {
+			continue
+		}
 		if v := item.Value; v != nil {
 			if v.Name == name && v.In == in {
 				return v
@@ -220,5 +223,8 @@ func (parameter *Parameter) Validate(c context.Context) error {
 			return fmt.Errorf("parameter %q content is invalid: %v", parameter.Name, err)
 		}
 	}
+	if err := validateExampleKeys(parameter.Examples); err != nil {
+		return fmt.Errorf("parameter %q examples are invalid: %v", parameter.Name, err)
+	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -117,8 +118,17 @@ func (pathItem *PathItem) SetOperation(method string, operation *Operation) {
 }
 
 func (pathItem *PathItem) Validate(c context.Context) error {
-	for _, operation := range pathItem.Operations() {
-		if err := operation.Validate(c); err != nil {
+	operations := pathItem.Operations()
+	for _, method := range sortedMapKeys(operations) {
+		operation := operations[method]
+		pointer := validationPointer(c, strings.ToLower(method))
+		if validationSkipped(c, pointer) {
+			continue
+		}
+		if err := operation.Validate(withValidationPointer(c, pointer)); err != nil {
+			return err
+		}
+		if err := validateCallbackTargets(operation, mergeParameters(pathItem.Parameters, operation.Parameters)); err != nil {
 			return err
 		}
 	}
@@ -3,6 +3,7 @@ package openapi3
 import (
 	"context"
 	"fmt"
+	pathpkg "path"
 	"strings"
 )
 
@@ -10,50 +11,61 @@ import (
 type Paths map[string]*PathItem
 
 func (paths Paths) Validate(c context.Context) error {
-	normalizedPaths := make(map[string]string)
-	for path, pathItem := range paths {
-		if path == "" || path[0] != '/' {
-			return fmt.Errorf("path %q does not start with a forward slash (/)", path)
-		}
+	return runValidationTasks(c, paths.validationTasks(c), 1)
+}
 
-		normalizedPath, _ := normalizeTemplatedPath(path)
-		if oldPath, ok := normalizedPaths[normalizedPath]; ok {
-			return fmt.Errorf("conflicting paths %q and %q", path, oldPath)
+// validationTasks returns tasks validating the paths, in a stable order.
+func (paths Paths) validationTasks(c context.Context) []validationTask {
+	if validationSkipped(c, "#/paths") {
+		return nil
+	}
+	var validated []string
+	for _, path := range sortedMapKeys(paths) {
+		pointer := validationPointer(c, "paths", path)
+		if !validationSkipped(c, pointer) && !validationPathSkipped(c, path, pointer) {
+			validated = append(validated, path)
 		}
-		normalizedPaths[path] = path
+	}
 
-		var globalCount uint
-		for _, parameterRef := range pathItem.Parameters {
-			if parameterRef != nil {
-				if parameter := parameterRef.Value; parameter != nil && parameter.In == ParameterInPath {
-					globalCount++
-				}
+	// The paths themselves are validated first, as a whole
+	tasks := []validationTask{{pointer: validationPointer(c, "paths"), run: func(c context.Context) error {
+		normalizedPaths := make(map[string]string)
+		for _, path := range validated {
+			if path == "" || path[0] != '/' {
+				return fmt.Errorf("path %q does not start with a forward slash (/)", path)
 			}
-		}
-		for _, operation := range pathItem.Operations() {
-			var count uint
-			for _, parameterRef := range operation.Parameters {
-				if parameterRef != nil {
-					if parameter := parameterRef.Value; parameter != nil && parameter.In == ParameterInPath {
-						count++
-					}
+
+			normalizedPath, _ := normalizeTemplatedPath(path)
+			if oldPath, ok := normalizedPaths[normalizedPath]; ok {
+				if method := sharedMethod(paths[oldPath], paths[path]); method != "" {
+					return fmt.Errorf("operations %s %q and %s %q have the same path and method", method, oldPath, method, path)
 				}
+				return fmt.Errorf("conflicting paths %q and %q", path, oldPath)
 			}
-			//if count+globalCount != pathParamsCount {
-			//	return fmt.Errorf("operation %s %s must define exactly all path parameters", method, path)
-			//}
-		}
-
-		if err := pathItem.Validate(c); err != nil {
-			return err
+			normalizedPaths[normalizedPath] = path
 		}
+		return nil
+	}}}
+	for _, path := range validated {
+		path, pathItem := path, paths[path]
+		pointer := validationPointer(c, "paths", path)
+		tasks = append(tasks, validationTask{pointer: pointer, value: pathItem, run: func(c context.Context) error {
+			if err := validateTemplateVariables(path); err != nil {
+				return err
+			}
+			if err := validateCatchAllParameter(path, pathItem); err != nil {
+				return err
+			}
+			return pathItem.Validate(withValidationPointer(c, pointer))
+		}})
 	}
-	return nil
+	return tasks
 }
 
 // Find returns a path that matches the key.
 //
-// The method ignores differences in template variable names (except possible "*" suffix).
+// The method ignores differences in template variable names (except possible "*" suffix
+// or "+" prefix).
 //
 // For example:
 //
@@ -105,13 +117,19 @@ func normalizeTemplatedPath(path string) (string, uint) {
 				isVariable = false
 			} else {
 				// Skip this character
+				cc = c
 				continue
 			}
 		} else if c == '{' {
 			// Begin path variable
-			// The character '{' will be appended
+			// The character '{' will be appended, followed by a possible '+'
 			isVariable = true
 			count++
+			if strings.HasPrefix(path[i+1:], "+") {
+				buf.WriteString("{+")
+				cc = '+'
+				continue
+			}
 		}
 
 		// Append the character
@@ -120,3 +138,112 @@ func normalizeTemplatedPath(path string) (string, uint) {
 	}
 	return buf.String(), count
 }
+
+// MatchPathPattern returns whether the path, e.g. "/collections/{collection_id}",
+// matches the pattern. Patterns are matched by path segment, "**" matches any
+// number of segments and other segments are matched like with path.Match, e.g.
+// "/collections/**" matches all paths starting with "/collections".
+func MatchPathPattern(pattern string, path string) bool {
+	return matchPathSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(path, "/"), "/"))
+}
+
+func matchPathSegments(patterns []string, segments []string) bool {
+	for len(patterns) != 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchPathSegments(patterns[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := pathpkg.Match(patterns[0], segments[0]); !ok {
+			return false
+		}
+		patterns, segments = patterns[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// sharedMethod returns the first method, in alphabetical order, with operations
+// in both path items, or "" if there is none.
+func sharedMethod(a, b *PathItem) string {
+	if a == nil || b == nil {
+		return ""
+	}
+	operations := a.Operations()
+	for _, method := range sortedMapKeys(b.Operations()) {
+		if operations[method] != nil {
+			return method
+		}
+	}
+	return ""
+}
+
+// validateTemplateVariables checks that no template variable name is used twice
+// in the path, e.g. in "/a/{id}/b/{id}", as its value couldn't be bound.
+func validateTemplateVariables(path string) error {
+	names := make(map[string]bool)
+	for rest := path; ; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			return nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(rest[start+1:start+end], "+"), "*")
+		if names[name] {
+			return fmt.Errorf("path %q uses the template variable %q more than once", path, name)
+		}
+		names[name] = true
+		rest = rest[start+end+1:]
+	}
+}
+
+// catchAllParameterName returns the name of the catch-all parameter of a path
+// template, e.g. "path" for "/files/{+path}", or "" if it has none. Like a
+// reserved expansion of a URI template, a catch-all parameter matches the rest
+// of a request path including slashes, while other parameters match a single
+// path segment.
+func catchAllParameterName(path string) string {
+	i := strings.LastIndex(path, "{+")
+	if i < 0 || !strings.HasSuffix(path, "}") {
+		return ""
+	}
+	return strings.TrimSpace(path[i+2 : len(path)-1])
+}
+
+// validateCatchAllParameter checks that a catch-all parameter is the last path
+// segment and, because its value spans segments, has a string schema.
+func validateCatchAllParameter(path string, pathItem *PathItem) error {
+	if i := strings.Index(path, "{+"); i >= 0 && strings.IndexByte(path[i:], '}') != len(path)-i-1 {
+		return fmt.Errorf("path %q has a catch-all parameter which isn't its last segment", path)
+	}
+	name := catchAllParameterName(path)
+	if name == "" || pathItem == nil {
+		return nil
+	}
+	check := func(parameter *Parameter) error {
+		if parameter == nil || parameter.Schema == nil || parameter.Schema.Value == nil {
+			return nil
+		}
+		if typ := parameter.Schema.Value.Type; typ != "" && typ != "string" {
+			return fmt.Errorf("catch-all parameter %q of path %q must have a string schema, not %q", name, path, typ)
+		}
+		return nil
+	}
+	if err := check(pathItem.Parameters.GetByInAndName(ParameterInPath, name)); err != nil {
+		return err
+	}
+	for _, operation := range pathItem.Operations() {
+		if err := check(operation.Parameters.GetByInAndName(ParameterInPath, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
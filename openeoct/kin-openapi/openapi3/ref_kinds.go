@@ -0,0 +1,113 @@
+package openapi3
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// refKinds are the kinds of objects which references resolve to, in the order
+// in which they are matched by singleElementKind.
+var refKinds = []struct {
+	name string
+	site string
+	typ  reflect.Type
+}{
+	{"Parameter", "parameter", reflect.TypeOf(Parameter{})},
+	{"Header", "header", reflect.TypeOf(Header{})},
+	{"RequestBody", "request body", reflect.TypeOf(RequestBody{})},
+	{"Response", "response", reflect.TypeOf(Response{})},
+	{"SecurityScheme", "security scheme", reflect.TypeOf(SecurityScheme{})},
+	{"Example", "example", reflect.TypeOf(Example{})},
+	{"Link", "link", reflect.TypeOf(Link{})},
+	{"Schema", "schema", reflect.TypeOf(Schema{})},
+}
+
+// refKindOf returns the kind of the object v, or of the object referenced by
+// v, e.g. "Schema" for a *Schema or *SchemaRef.
+func refKindOf(v interface{}) (name string, site string) {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil {
+		return "nothing", "nothing"
+	}
+	for _, kind := range refKinds {
+		if typ == kind.typ || typ.Name() == kind.typ.Name()+"Ref" {
+			return kind.name, kind.site
+		}
+	}
+	return typ.Name(), strings.ToLower(typ.Name())
+}
+
+// refKindMismatch is the error of the reference ref of the component, e.g. a
+// *ParameterRef, which resolves to the object resolved of another kind.
+func refKindMismatch(component interface{}, ref string, resolved interface{}) error {
+	name, _ := refKindOf(resolved)
+	return refKindNameMismatch(component, ref, name)
+}
+
+// refKindNameMismatch is like refKindMismatch for an object of the named kind.
+func refKindNameMismatch(component interface{}, ref string, name string) error {
+	_, site := refKindOf(component)
+	article := "a"
+	if strings.ContainsAny(name[:1], "AEIOU") {
+		article = "an"
+	}
+	return fmt.Errorf("%s ref %q resolves to %s %s", strings.ToUpper(site[:1])+site[1:], ref, article, name)
+}
+
+// singleElementKind returns the kind of the JSON or YAML object data if it has
+// fields which the element, e.g. a *Parameter, doesn't have but another kind of
+// object has all of them, or "" otherwise. Schemas aren't checked, as they may
+// have keywords of JSON Schema unknown to the package.
+func singleElementKind(data []byte, element interface{}) string {
+	expected, _ := refKindOf(element)
+	if expected == "Schema" {
+		return ""
+	}
+	var object map[string]interface{}
+	if err := yaml.Unmarshal(data, &object); err != nil {
+		return ""
+	}
+	fits := func(typ reflect.Type) bool {
+		fields := jsonFieldNames(typ)
+		for key := range object {
+			if !fields[key] && !strings.HasPrefix(key, "x-") && key != "$ref" {
+				return false
+			}
+		}
+		return true
+	}
+	for _, kind := range refKinds {
+		if kind.name == expected {
+			if fits(kind.typ) {
+				return ""
+			}
+			break
+		}
+	}
+	for _, kind := range refKinds {
+		if kind.name != expected && fits(kind.typ) {
+			return kind.name
+		}
+	}
+	return ""
+}
+
+// jsonFieldNames returns the names of the JSON fields of the struct type.
+func jsonFieldNames(typ reflect.Type) map[string]bool {
+	names := make(map[string]bool, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		for _, tag := range []string{"json", "multijson"} {
+			if name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]; name != "" && name != "-" {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
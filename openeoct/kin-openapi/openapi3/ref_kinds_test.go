@@ -0,0 +1,50 @@
+package openapi3_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefKindMismatch(t *testing.T) {
+	load := func(parameter string, schema string) error {
+		spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Id: {type: string}
+  parameters:
+    limit: {name: limit, in: query, schema: {type: integer}}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - $ref: '` + parameter + `'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '` + schema + `'}
+`)
+		loader := openapi3.NewSwaggerLoader()
+		loader.IsExternalRefsAllowed = true
+		_, err := loader.LoadSwaggerFromDataWithPath(spec, &url.URL{Path: "testdata/refkinds/openapi.yml"})
+		return err
+	}
+
+	require.NoError(t, load("#/components/parameters/limit", "#/components/schemas/Id"))
+	require.NoError(t, load("limit.parameter.yml", "id.schema.yml"))
+
+	err := load("#/components/schemas/Id", "#/components/schemas/Id")
+	require.EqualError(t, err, `Parameter ref "#/components/schemas/Id" resolves to a Schema`)
+
+	err = load("#/components/parameters/limit", "#/components/parameters/limit")
+	require.EqualError(t, err, `Schema ref "#/components/parameters/limit" resolves to a Parameter`)
+
+	err = load("id.schema.yml", "#/components/schemas/Id")
+	require.EqualError(t, err, `Parameter ref "id.schema.yml" resolves to a Schema`)
+}
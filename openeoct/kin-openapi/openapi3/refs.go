@@ -2,6 +2,8 @@ package openapi3
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -152,6 +154,10 @@ func (value *RequestBodyRef) Validate(c context.Context) error {
 type SchemaRef struct {
 	Ref   string
 	Value *Schema
+	// Siblings are the keywords next to Ref, if any. OpenAPI 3.0 ignores them,
+	// while OpenAPI 3.1 applies them in addition to the referenced schema, so
+	// the loader then makes Value the combination of both.
+	Siblings *Schema
 }
 
 func NewSchemaRef(ref string, value *Schema) *SchemaRef {
@@ -162,11 +168,61 @@ func NewSchemaRef(ref string, value *Schema) *SchemaRef {
 }
 
 func (value *SchemaRef) MarshalJSON() ([]byte, error) {
-	return jsoninfo.MarshalRef(value.Ref, value.Value)
+	if value.Ref == "" || value.Siblings == nil {
+		return jsoninfo.MarshalRef(value.Ref, value.Value)
+	}
+	keywords, err := value.siblingKeywords()
+	if err != nil {
+		return nil, err
+	}
+	if keywords["$ref"], err = json.Marshal(value.Ref); err != nil {
+		return nil, err
+	}
+	return json.Marshal(keywords)
 }
 
 func (value *SchemaRef) UnmarshalJSON(data []byte) error {
-	return jsoninfo.UnmarshalRef(data, &value.Ref, &value.Value)
+	if err := jsoninfo.UnmarshalRef(data, &value.Ref, &value.Value); err != nil || value.Ref == "" {
+		return err
+	}
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	delete(keywords, "$ref")
+	if len(keywords) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(keywords)
+	if err != nil {
+		return err
+	}
+	value.Siblings = &Schema{}
+	return json.Unmarshal(data, value.Siblings)
+}
+
+// SiblingKeywords returns the names of the keywords next to the reference, sorted.
+func (value *SchemaRef) SiblingKeywords() []string {
+	keywords, _ := value.siblingKeywords()
+	names := make([]string, 0, len(keywords))
+	for name := range keywords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (value *SchemaRef) siblingKeywords() (map[string]json.RawMessage, error) {
+	if value.Siblings == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value.Siblings)
+	if err != nil {
+		return nil, err
+	}
+	var keywords map[string]json.RawMessage
+	err = json.Unmarshal(data, &keywords)
+	return keywords, err
 }
 
 func (value *SchemaRef) Validate(c context.Context) error {
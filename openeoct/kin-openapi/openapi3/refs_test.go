@@ -1,6 +1,8 @@
 package openapi3
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -109,3 +111,39 @@ components:
 	_, err := NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
 	require.EqualError(t, err, `invalid response: value MUST be a JSON object`)
 }
+
+func TestSchemaRefSiblings(t *testing.T) {
+	spec := `
+openapi: OPENAPI
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Id:
+      type: string
+    JobId:
+      $ref: '#/components/schemas/Id'
+      description: The id of a job.
+      maxLength: 3
+`
+	for _, tc := range []struct {
+		openapi string
+		valid   bool
+	}{
+		{"3.0.3", true},
+		{"3.1.0", false},
+	} {
+		loader := NewSwaggerLoader()
+		swagger, err := loader.LoadSwaggerFromData([]byte(strings.Replace(spec, "OPENAPI", tc.openapi, 1)))
+		require.NoError(t, err)
+		ref := swagger.Components.Schemas["JobId"]
+		require.Equal(t, "#/components/schemas/Id", ref.Ref)
+		require.Equal(t, []string{"description", "maxLength"}, ref.SiblingKeywords())
+		require.Equal(t, tc.valid, ref.Value.VisitJSON("j-1234") == nil, tc.openapi)
+		require.NoError(t, ref.Value.VisitJSON("j-1"))
+
+		data, err := json.Marshal(ref)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"$ref": "#/components/schemas/Id", "description": "The id of a job.", "maxLength": 3}`, string(data))
+	}
+}
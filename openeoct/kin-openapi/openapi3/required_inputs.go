@@ -0,0 +1,40 @@
+package openapi3
+
+// RequiredInputs are the inputs which every request of an operation must
+// supply, see (*Swagger).RequiredInputs.
+type RequiredInputs struct {
+	Path        string
+	Method      string
+	OperationID string
+	// Parameters are the required parameters, including all path parameters,
+	// from the path item parameters merged with the operation parameters.
+	Parameters []*Parameter
+	// RequestBody is the request body if it is required, nil otherwise.
+	RequestBody *RequestBody
+}
+
+// RequiredInputs lists the required inputs of all operations of the document,
+// sorted by path and method like Operations. References must be resolved, the
+// parameters and request bodies of unresolved references are left out. The
+// document isn't modified.
+func (swagger *Swagger) RequiredInputs() []*RequiredInputs {
+	operations := swagger.Operations()
+	inputs := make([]*RequiredInputs, 0, len(operations))
+	for _, info := range operations {
+		required := &RequiredInputs{
+			Path:        info.Path,
+			Method:      info.Method,
+			OperationID: info.OperationID,
+		}
+		for _, ref := range info.Parameters {
+			if parameter := ref.Value; parameter != nil && (parameter.Required || parameter.In == ParameterInPath) {
+				required.Parameters = append(required.Parameters, parameter)
+			}
+		}
+		if ref := info.Operation.RequestBody; ref != nil && ref.Value != nil && ref.Value.Required {
+			required.RequestBody = ref.Value
+		}
+		inputs = append(inputs, required)
+	}
+	return inputs
+}
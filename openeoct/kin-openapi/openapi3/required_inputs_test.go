@@ -0,0 +1,71 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredInputs(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  parameters:
+    job_id: {name: job_id, in: path, required: true, schema: {type: string}}
+  requestBodies:
+    Job:
+      required: true
+      content:
+        application/json: {schema: {type: object}}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200': {description: ok}
+    post:
+      operationId: create-job
+      requestBody: {$ref: '#/components/requestBodies/Job'}
+      responses:
+        '201': {description: created}
+  /jobs/{job_id}:
+    parameters:
+      - $ref: '#/components/parameters/job_id'
+      - {name: OpenEO-Identifier, in: header, required: true, schema: {type: string}}
+    patch:
+      parameters:
+        - {name: OpenEO-Identifier, in: header, schema: {type: string}}
+        - {name: budget, in: query, required: true, schema: {type: number}}
+      requestBody:
+        content:
+          application/json: {schema: {type: object}}
+      responses:
+        '204': {description: updated}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	type input struct {
+		operation   string
+		parameters  []string
+		requestBody bool
+	}
+	var inputs []input
+	for _, required := range swagger.RequiredInputs() {
+		var parameters []string
+		for _, parameter := range required.Parameters {
+			parameters = append(parameters, parameter.In+" "+parameter.Name)
+		}
+		inputs = append(inputs, input{required.Method + " " + required.Path, parameters, required.RequestBody != nil})
+	}
+	require.Equal(t, []input{
+		{"GET /jobs", nil, false},
+		{"POST /jobs", nil, true},
+		{"PATCH /jobs/{job_id}", []string{"path job_id", "query budget"}, false},
+	}, inputs)
+	require.Equal(t, "create-job", swagger.RequiredInputs()[1].OperationID)
+	require.Same(t, swagger.Components.RequestBodies["Job"].Value, swagger.RequiredInputs()[1].RequestBody)
+}
@@ -3,6 +3,7 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
@@ -29,14 +30,33 @@ func (responses Responses) Validate(c context.Context) error {
 	if len(responses) == 0 {
 		return errors.New("the responses object MUST contain at least one response code")
 	}
-	for _, v := range responses {
+	for status, v := range responses {
 		if err := v.Validate(c); err != nil {
 			return err
 		}
+		if status == "204" || status == "304" {
+			if v.Value != nil && len(v.Value.Content) != 0 {
+				return fmt.Errorf("response %q MUST NOT declare content", status)
+			}
+		}
 	}
 	return nil
 }
 
+// Status returns the response for an HTTP status code, declared either for the
+// exact code, for its range like "2XX" or as default, or nil if there is none.
+func (responses Responses) Status(status int) *ResponseRef {
+	if ref := responses.Get(status); ref != nil {
+		return ref
+	}
+	if status >= 100 && status < 600 {
+		if ref := responses[strconv.Itoa(status/100)+"XX"]; ref != nil {
+			return ref
+		}
+	}
+	return responses.Default()
+}
+
 // Response is specified by OpenAPI/Swagger 3.0 standard.
 type Response struct {
 	ExtensionProps
@@ -83,6 +103,14 @@ func (response *Response) Validate(c context.Context) error {
 		return errors.New("a short description of the response is required")
 	}
 
+	for _, name := range sortedMapKeys(response.Headers) {
+		if v := response.Headers[name]; v != nil {
+			if err := v.Validate(c); err != nil {
+				return fmt.Errorf("response header %q is invalid: %v", name, err)
+			}
+		}
+	}
+
 	if content := response.Content; content != nil {
 		if err := content.Validate(c); err != nil {
 			return err
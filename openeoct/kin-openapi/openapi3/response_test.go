@@ -0,0 +1,66 @@
+package openapi3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoContentResponsesMustNotDeclareContent(t *testing.T) {
+	for _, status := range []string{"204", "304"} {
+		responses := Responses{
+			status: &ResponseRef{Value: NewResponse().WithDescription("no content").WithJSONSchema(NewStringSchema())},
+		}
+		err := responses.Validate(context.Background())
+		require.EqualError(t, err, `response "`+status+`" MUST NOT declare content`)
+
+		responses[status].Value.Content = nil
+		require.NoError(t, responses.Validate(context.Background()))
+	}
+}
+
+func TestResponseHeadersNeedSchemaOrContent(t *testing.T) {
+	response := NewResponse().WithDescription("created")
+	response.Headers = map[string]*HeaderRef{
+		"Location":     {Value: &Header{Required: true, Schema: NewStringSchema().WithFormat("uri").NewRef()}},
+		"OpenEO-Costs": {Value: &Header{Content: NewContentWithJSONSchema(NewFloat64Schema())}},
+	}
+	require.NoError(t, response.Validate(context.Background()))
+
+	response.Headers["OpenEO-Identifier"] = &HeaderRef{Value: &Header{Required: true}}
+	require.EqualError(t, response.Validate(context.Background()),
+		`response header "OpenEO-Identifier" is invalid: header must contain exactly one of content and schema`)
+
+	response.Headers["OpenEO-Identifier"].Value.Schema = NewStringSchema().NewRef()
+	response.Headers["OpenEO-Identifier"].Value.Content = NewContentWithJSONSchema(NewStringSchema())
+	require.Error(t, response.Validate(context.Background()))
+
+	response.Headers["OpenEO-Identifier"].Value.Content = nil
+	response.Headers["OpenEO-Costs"].Value.Content = NewContentWithJSONSchema(NewStringSchema().WithFormat("unknown"))
+	require.Error(t, response.Validate(context.Background()))
+}
+
+func TestRequiredResponseHeaderWithoutSchemaOrContent(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: Created
+          headers:
+            OpenEO-Identifier:
+              required: true
+              description: The id of the job
+`
+	doc, err := NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid paths: response header "OpenEO-Identifier" is invalid: header must contain exactly one of content and schema`)
+
+	doc.Paths["/jobs"].Post.Responses["201"].Value.Headers["OpenEO-Identifier"].Value.Schema = NewStringSchema().NewRef()
+	require.NoError(t, doc.Validate(context.Background()))
+}
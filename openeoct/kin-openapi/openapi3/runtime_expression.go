@@ -0,0 +1,112 @@
+package openapi3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateRuntimeExpression checks the syntax of a runtime expression as used
+// by callbacks and links, such as "$request.body#/url" or "$response.header.Location".
+func ValidateRuntimeExpression(expr string) error {
+	switch expr {
+	case "$url", "$method", "$statusCode":
+		return nil
+	}
+	var source string
+	switch {
+	case strings.HasPrefix(expr, "$request."):
+		source = expr[len("$request."):]
+	case strings.HasPrefix(expr, "$response."):
+		source = expr[len("$response."):]
+	default:
+		return fmt.Errorf("runtime expression %q must be $url, $method, $statusCode or start with $request. or $response.", expr)
+	}
+	switch {
+	case strings.HasPrefix(source, "header."):
+		name := source[len("header."):]
+		if name == "" || strings.IndexFunc(name, func(r rune) bool { return !isTokenChar(r) }) >= 0 {
+			return fmt.Errorf("runtime expression %q has an invalid header name %q", expr, name)
+		}
+	case strings.HasPrefix(source, "query."), strings.HasPrefix(source, "path."):
+		if name := source[strings.IndexByte(source, '.')+1:]; name == "" {
+			return fmt.Errorf("runtime expression %q is missing a parameter name", expr)
+		}
+	case source == "body":
+	case strings.HasPrefix(source, "body#"):
+		if err := validateJSONPointer(source[len("body#"):]); err != nil {
+			return fmt.Errorf("runtime expression %q has an invalid JSON pointer: %v", expr, err)
+		}
+	default:
+		return fmt.Errorf("runtime expression %q has an invalid source, expected header., query., path. or body", expr)
+	}
+	return nil
+}
+
+// validateRuntimeExpressionTemplate checks all runtime expressions embedded in
+// braces in the template, a template without braces starting with '$' is
+// treated as a single runtime expression.
+func validateRuntimeExpressionTemplate(template string) error {
+	exprs, err := RuntimeExpressions(template)
+	if err != nil {
+		return err
+	}
+	for _, expr := range exprs {
+		if err := ValidateRuntimeExpression(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RuntimeExpressions returns the runtime expressions of a template such as
+// "http://example.com?id={$request.body#/id}".
+func RuntimeExpressions(template string) ([]string, error) {
+	if !strings.ContainsAny(template, "{}") {
+		if strings.HasPrefix(template, "$") {
+			return []string{template}, nil
+		}
+		return nil, nil
+	}
+	var exprs []string
+	for rest := template; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if start < 0 && end < 0 {
+			break
+		}
+		if start < 0 || end < start {
+			return nil, fmt.Errorf("expression %q has unbalanced braces", template)
+		}
+		expr := rest[start+1 : end]
+		if strings.IndexByte(expr, '{') >= 0 {
+			return nil, fmt.Errorf("expression %q has unbalanced braces", template)
+		}
+		exprs = append(exprs, expr)
+		rest = rest[end+1:]
+	}
+	return exprs, nil
+}
+
+func validateJSONPointer(pointer string) error {
+	if pointer == "" {
+		return nil
+	}
+	if pointer[0] != '/' {
+		return fmt.Errorf("%q must start with '/'", pointer)
+	}
+	for i := 0; i < len(pointer); i++ {
+		if pointer[i] == '~' && (i+1 == len(pointer) || (pointer[i+1] != '0' && pointer[i+1] != '1')) {
+			return fmt.Errorf("%q has an invalid escape sequence", pointer)
+		}
+	}
+	return nil
+}
+
+// isTokenChar reports whether r is allowed in an HTTP header name (RFC 7230 tchar).
+func isTokenChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
@@ -3,13 +3,17 @@ package openapi3
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"unicode/utf16"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
@@ -49,6 +53,9 @@ func Uint64Ptr(value uint64) *uint64 {
 }
 
 // Schema is specified by OpenAPI/Swagger 3.0 standard.
+//
+// Type is a single type name: the type arrays of OpenAPI 3.1, e.g. [string, "null"],
+// aren't supported and fail to load. Null values are allowed by Nullable instead.
 type Schema struct {
 	ExtensionProps
 
@@ -64,6 +71,13 @@ type Schema struct {
 	Default      interface{}   `json:"default,omitempty" yaml:"default,omitempty"`
 	Example      interface{}   `json:"example,omitempty" yaml:"example,omitempty"`
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	// Examples are example values (OpenAPI 3.1), unlike the examples of parameters and media types
+	Examples []interface{} `json:"examples,omitempty" yaml:"examples,omitempty"`
+
+	// Conditional subschemas (OpenAPI 3.1): values matching If must match Then, others must match Else
+	If   *SchemaRef `json:"if,omitempty" yaml:"if,omitempty"`
+	Then *SchemaRef `json:"then,omitempty" yaml:"then,omitempty"`
+	Else *SchemaRef `json:"else,omitempty" yaml:"else,omitempty"`
 
 	// Object-related, here for struct compactness
 	AdditionalPropertiesAllowed *bool `json:"-" multijson:"additionalProperties,omitempty" yaml:"-"`
@@ -73,10 +87,11 @@ type Schema struct {
 	ExclusiveMin bool `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 	ExclusiveMax bool `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
 	// Properties
-	Nullable  bool        `json:"nullable,omitempty" yaml:"nullable,omitempty"`
-	ReadOnly  bool        `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
-	WriteOnly bool        `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
-	XML       interface{} `json:"xml,omitempty" yaml:"xml,omitempty"`
+	Nullable   bool        `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	ReadOnly   bool        `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	WriteOnly  bool        `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
+	Deprecated bool        `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	XML        interface{} `json:"xml,omitempty" yaml:"xml,omitempty"`
 
 	// Number
 	Min        *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
@@ -84,23 +99,28 @@ type Schema struct {
 	MultipleOf *float64 `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 
 	// String
-	MinLength       uint64  `json:"minLength,omitempty" yaml:"minLength,omitempty"`
-	MaxLength       *uint64 `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
-	Pattern         string  `json:"pattern,omitempty" yaml:"pattern,omitempty"`
-	compiledPattern *compiledPattern
+	MinLength uint64  `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength *uint64 `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Pattern   string  `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// ContentMediaType and ContentEncoding describe string encoded content (OpenAPI 3.1)
+	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+	ContentEncoding  string `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
 
 	// Array
-	MinItems uint64     `json:"minItems,omitempty" yaml:"minItems,omitempty"`
-	MaxItems *uint64    `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	Items    *SchemaRef `json:"items,omitempty" yaml:"items,omitempty"`
+	MinItems    uint64       `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems    *uint64      `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	Items       *SchemaRef   `json:"items,omitempty" yaml:"items,omitempty"`
+	PrefixItems []*SchemaRef `json:"prefixItems,omitempty" yaml:"prefixItems,omitempty"`
 
 	// Object
-	Required             []string              `json:"required,omitempty" yaml:"required,omitempty"`
-	Properties           map[string]*SchemaRef `json:"properties,omitempty" yaml:"properties,omitempty"`
-	MinProps             uint64                `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
-	MaxProps             *uint64               `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
-	AdditionalProperties *SchemaRef            `json:"-" multijson:"additionalProperties,omitempty" yaml:"-"`
-	Discriminator        *Discriminator        `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+	Required   []string              `json:"required,omitempty" yaml:"required,omitempty"`
+	Properties map[string]*SchemaRef `json:"properties,omitempty" yaml:"properties,omitempty"`
+	MinProps   uint64                `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+	MaxProps   *uint64               `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+	// DependentRequired lists for a property the properties required when it is present (OpenAPI 3.1).
+	DependentRequired    map[string][]string `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
+	AdditionalProperties *SchemaRef          `json:"-" multijson:"additionalProperties,omitempty" yaml:"-"`
+	Discriminator        *Discriminator      `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
 }
 
 func NewSchema() *Schema {
@@ -228,6 +248,22 @@ type compiledPattern struct {
 	ErrReason string
 }
 
+// compiledPatterns caches compiled "pattern" regular expressions by their source,
+// it is safe for concurrent use.
+var compiledPatterns sync.Map
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(pattern, re)
+	return re, nil
+}
+
 func (schema *Schema) WithNullable() *Schema {
 	schema.Nullable = true
 	return schema
@@ -318,6 +354,17 @@ func (schema *Schema) WithItems(value *Schema) *Schema {
 	return schema
 }
 
+// WithPrefixItems sets the schemas of the leading array items (tuple validation),
+// as introduced by OpenAPI 3.1.
+func (schema *Schema) WithPrefixItems(values ...*Schema) *Schema {
+	refs := make([]*SchemaRef, 0, len(values))
+	for _, value := range values {
+		refs = append(refs, &SchemaRef{Value: value})
+	}
+	schema.PrefixItems = refs
+	return schema
+}
+
 func (schema *Schema) WithMinItems(i int64) *Schema {
 	n := uint64(i)
 	schema.MinItems = n
@@ -374,6 +421,14 @@ func (schema *Schema) WithMaxProperties(i int64) *Schema {
 	return schema
 }
 
+func (schema *Schema) WithDependentRequired(property string, dependents ...string) *Schema {
+	if schema.DependentRequired == nil {
+		schema.DependentRequired = make(map[string][]string)
+	}
+	schema.DependentRequired[property] = dependents
+	return schema
+}
+
 func (schema *Schema) WithAnyAdditionalProperties() *Schema {
 	schema.AdditionalProperties = nil
 	t := true
@@ -398,14 +453,23 @@ func (schema *Schema) IsEmpty() bool {
 		!schema.Nullable ||
 		schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil ||
 		schema.MinLength != 0 || schema.MaxLength != nil || schema.Pattern != "" ||
+		schema.ContentMediaType != "" || schema.ContentEncoding != "" ||
 		schema.MinItems != 0 || schema.MaxItems != nil ||
-		len(schema.Required) != 0 ||
+		len(schema.Required) != 0 || len(schema.DependentRequired) != 0 ||
 		schema.MinProps != 0 || schema.MaxProps != nil {
 		return false
 	}
 	if n := schema.Not; n != nil && !n.Value.IsEmpty() {
 		return false
 	}
+	if schema.If != nil {
+		if t := schema.Then; t != nil && !t.Value.IsEmpty() {
+			return false
+		}
+		if e := schema.Else; e != nil && !e.Value.IsEmpty() {
+			return false
+		}
+	}
 	if ap := schema.AdditionalProperties; ap != nil && !ap.Value.IsEmpty() {
 		return false
 	}
@@ -415,6 +479,11 @@ func (schema *Schema) IsEmpty() bool {
 	if items := schema.Items; items != nil && !items.Value.IsEmpty() {
 		return false
 	}
+	for _, s := range schema.PrefixItems {
+		if !s.Value.IsEmpty() {
+			return false
+		}
+	}
 	for _, s := range schema.Properties {
 		if !s.Value.IsEmpty() {
 			return false
@@ -450,6 +519,14 @@ func (schema *Schema) validate(c context.Context, stack []*Schema) (err error) {
 	}
 	stack = append(stack, schema)
 
+	if err = schema.validateExtensions(c, schema); err != nil {
+		return
+	}
+
+	if key, property := schema.unknownDiscriminatorMappingKey(); key != "" {
+		return fmt.Errorf("Schema 'discriminator' mapping key '%s' isn't a member of the enum of property '%s'", key, property)
+	}
+
 	for _, item := range schema.OneOf {
 		v := item.Value
 		if v == nil {
@@ -490,7 +567,37 @@ func (schema *Schema) validate(c context.Context, stack []*Schema) (err error) {
 		}
 	}
 
+	for _, ref := range []*SchemaRef{schema.If, schema.Then, schema.Else} {
+		if ref == nil {
+			continue
+		}
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		if err = v.validate(c, stack); err != nil {
+			return
+		}
+	}
+
+	for property, dependents := range schema.DependentRequired {
+		seen := make(map[string]struct{}, len(dependents))
+		for _, dependent := range dependents {
+			if _, ok := seen[dependent]; ok {
+				return fmt.Errorf("Schema 'dependentRequired' of property '%s' lists '%s' more than once", property, dependent)
+			}
+			seen[dependent] = struct{}{}
+		}
+	}
+
+	if first, second := duplicateEnumMembers(schema.Enum); second >= 0 {
+		return fmt.Errorf("Schema 'enum' values at index %d and %d are equal: %s", first, second, uniqueItemKey(schema.Enum[first]))
+	}
+
 	schemaType := schema.Type
+	if (schema.ContentMediaType != "" || schema.ContentEncoding != "") && schemaType != "" && schemaType != "string" {
+		return fmt.Errorf("Schema 'contentMediaType' and 'contentEncoding' only apply to strings, not to type '%s'", schemaType)
+	}
 	switch schemaType {
 	case "":
 	case "boolean":
@@ -529,14 +636,23 @@ func (schema *Schema) validate(c context.Context, stack []*Schema) (err error) {
 			case "json-pointer", "relative-json-pointer":
 			default:
 				// Try to check for custom defined formats
-				if _, ok := SchemaStringFormats[format]; !ok && !SchemaFormatValidationDisabled {
+				_, ok := SchemaStringFormats[format]
+				if _, defined := SchemaStringFormatValidators[format]; !ok && !defined && !SchemaFormatValidationDisabled {
 					return unsupportedFormat(format)
 				}
 			}
+			// Enum members must have the format themselves, unless it's lenient
+			if !SchemaFormatValidationDisabled && SchemaFormatSeverities[format] == FormatSeverityError {
+				for _, item := range schema.Enum {
+					if value, ok := item.(string); ok && validateStringFormat(format, value) != nil {
+						return fmt.Errorf("Schema 'enum' value '%s' doesn't match the format '%s'", value, format)
+					}
+				}
+			}
 		}
 	case "array":
-		if schema.Items == nil {
-			return errors.New("When schema type is 'array', schema 'items' must be non-null")
+		if schema.Items == nil && len(schema.PrefixItems) == 0 {
+			return errors.New("When schema type is 'array', schema 'items' or 'prefixItems' must be non-null")
 		}
 	case "object":
 	default:
@@ -553,6 +669,16 @@ func (schema *Schema) validate(c context.Context, stack []*Schema) (err error) {
 		}
 	}
 
+	for _, ref := range schema.PrefixItems {
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		if err = v.validate(c, stack); err != nil {
+			return
+		}
+	}
+
 	for _, ref := range schema.Properties {
 		v := ref.Value
 		if v == nil {
@@ -577,37 +703,43 @@ func (schema *Schema) validate(c context.Context, stack []*Schema) (err error) {
 }
 
 func (schema *Schema) IsMatching(value interface{}) bool {
-	return schema.visitJSON(value, true) == nil
+	return schema.visitJSON(newSchemaValidationSettings(FailFast()), value) == nil
 }
 
 func (schema *Schema) IsMatchingJSONBoolean(value bool) bool {
-	return schema.visitJSON(value, true) == nil
+	return schema.visitJSON(newSchemaValidationSettings(FailFast()), value) == nil
 }
 
 func (schema *Schema) IsMatchingJSONNumber(value float64) bool {
-	return schema.visitJSON(value, true) == nil
+	return schema.visitJSON(newSchemaValidationSettings(FailFast()), value) == nil
 }
 
 func (schema *Schema) IsMatchingJSONString(value string) bool {
-	return schema.visitJSON(value, true) == nil
+	return schema.visitJSON(newSchemaValidationSettings(FailFast()), value) == nil
 }
 
 func (schema *Schema) IsMatchingJSONArray(value []interface{}) bool {
-	return schema.visitJSON(value, true) == nil
+	return schema.visitJSON(newSchemaValidationSettings(FailFast()), value) == nil
 }
 
 func (schema *Schema) IsMatchingJSONObject(value map[string]interface{}) bool {
-	return schema.visitJSON(value, true) == nil
+	return schema.visitJSON(newSchemaValidationSettings(FailFast()), value) == nil
 }
 
-func (schema *Schema) VisitJSON(value interface{}) error {
-	return schema.visitJSON(value, false)
+func (schema *Schema) VisitJSON(value interface{}, opts ...SchemaValidationOption) error {
+	settings := newSchemaValidationSettings(opts...)
+	return settings.result(schema.visitJSON(settings, value))
 }
 
-func (schema *Schema) visitJSON(value interface{}, fast bool) (err error) {
+func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interface{}) (err error) {
+	if err = settings.enter(); err != nil {
+		return
+	}
+	defer settings.leave()
+
 	switch value := value.(type) {
 	case nil:
-		return schema.visitJSONNull(fast)
+		return schema.visitJSONNull(settings)
 	case float64:
 		if math.IsNaN(value) {
 			return ErrSchemaInputNaN
@@ -620,23 +752,23 @@ func (schema *Schema) visitJSON(value interface{}, fast bool) (err error) {
 	if schema.IsEmpty() {
 		return
 	}
-	if err = schema.visitSetOperations(value, fast); err != nil {
+	if err = schema.visitSetOperations(settings, value); err != nil {
 		return
 	}
 
 	switch value := value.(type) {
 	case nil:
-		return schema.visitJSONNull(fast)
+		return schema.visitJSONNull(settings)
 	case bool:
-		return schema.visitJSONBoolean(value, fast)
+		return schema.visitJSONBoolean(settings, value)
 	case float64:
-		return schema.visitJSONNumber(value, fast)
+		return schema.visitJSONNumber(settings, value)
 	case string:
-		return schema.visitJSONString(value, fast)
+		return schema.visitJSONString(settings, value)
 	case []interface{}:
-		return schema.visitJSONArray(value, fast)
+		return schema.visitJSONArray(settings, value)
 	case map[string]interface{}:
-		return schema.visitJSONObject(value, fast)
+		return schema.visitJSONObject(settings, value)
 	default:
 		return &SchemaError{
 			Value:       value,
@@ -647,14 +779,17 @@ func (schema *Schema) visitJSON(value interface{}, fast bool) (err error) {
 	}
 }
 
-func (schema *Schema) visitSetOperations(value interface{}, fast bool) (err error) {
+func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, value interface{}) (err error) {
 	if enum := schema.Enum; len(enum) != 0 {
-		for _, v := range enum {
+		for i, v := range enum {
 			if value == v {
+				if settings.coverage != nil {
+					settings.coverage.mark(schema, "enum/"+strconv.Itoa(i))
+				}
 				return
 			}
 		}
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -670,8 +805,8 @@ func (schema *Schema) visitSetOperations(value interface{}, fast bool) (err erro
 		if v == nil {
 			return foundUnresolvedRef(ref.Ref)
 		}
-		if err := v.visitJSON(value, true); err == nil {
-			if fast {
+		if err := v.visitJSON(settings.failFast(), value); err == nil {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -682,19 +817,30 @@ func (schema *Schema) visitSetOperations(value interface{}, fast bool) (err erro
 		}
 	}
 
-	if v := schema.OneOf; len(v) > 0 {
+	discriminated := false
+	if object, ok := value.(map[string]interface{}); ok {
+		if discriminated, err = schema.visitDiscriminator(settings, object); err != nil {
+			return
+		}
+	}
+
+	if v := schema.OneOf; len(v) > 0 && !discriminated {
 		ok := 0
-		for _, item := range v {
+		for i, item := range v {
 			v := item.Value
 			if v == nil {
 				return foundUnresolvedRef(item.Ref)
 			}
-			if err := v.visitJSON(value, true); err == nil {
+			if err := v.visitJSON(settings.failFast(), value); err == nil {
 				ok++
+				if settings.coverage != nil {
+					settings.coverage.mark(schema, "oneOf/"+strconv.Itoa(i))
+					v.visitJSON(settings, value)
+				}
 			}
 		}
 		if ok != 1 {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -705,20 +851,24 @@ func (schema *Schema) visitSetOperations(value interface{}, fast bool) (err erro
 		}
 	}
 
-	if v := schema.AnyOf; len(v) > 0 {
+	if v := schema.AnyOf; len(v) > 0 && (!discriminated || len(schema.OneOf) > 0) {
 		ok := false
-		for _, item := range v {
+		for i, item := range v {
 			v := item.Value
 			if v == nil {
 				return foundUnresolvedRef(item.Ref)
 			}
-			if err := v.visitJSON(value, true); err == nil {
+			if err := v.visitJSON(settings.failFast(), value); err == nil {
 				ok = true
+				if settings.coverage != nil {
+					settings.coverage.mark(schema, "anyOf/"+strconv.Itoa(i))
+					v.visitJSON(settings, value)
+				}
 				break
 			}
 		}
 		if !ok {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -734,8 +884,8 @@ func (schema *Schema) visitSetOperations(value interface{}, fast bool) (err erro
 		if v == nil {
 			return foundUnresolvedRef(item.Ref)
 		}
-		if err := v.visitJSON(value, false); err != nil {
-			if fast {
+		if err := v.visitJSON(settings, value); err != nil {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -746,14 +896,46 @@ func (schema *Schema) visitSetOperations(value interface{}, fast bool) (err erro
 			}
 		}
 	}
+
+	if ref := schema.If; ref != nil {
+		v := ref.Value
+		if v == nil {
+			return foundUnresolvedRef(ref.Ref)
+		}
+		branch, field, reason := schema.Then, "then", "Value matches the 'if' schema but doesn't match the 'then' schema"
+		if err := v.visitJSON(settings.failFast(), value); err != nil {
+			branch, field, reason = schema.Else, "else", "Value doesn't match the 'if' schema nor the 'else' schema"
+		}
+		if branch != nil {
+			v := branch.Value
+			if v == nil {
+				return foundUnresolvedRef(branch.Ref)
+			}
+			if settings.coverage != nil {
+				settings.coverage.mark(schema, field)
+			}
+			if err := v.visitJSON(settings, value); err != nil {
+				if settings.failfast {
+					return errSchema
+				}
+				return &SchemaError{
+					Value:       value,
+					Schema:      schema,
+					SchemaField: field,
+					Reason:      reason,
+					Origin:      err,
+				}
+			}
+		}
+	}
 	return
 }
 
-func (schema *Schema) visitJSONNull(fast bool) (err error) {
+func (schema *Schema) visitJSONNull(settings *schemaValidationSettings) (err error) {
 	if schema.Nullable {
 		return
 	}
-	if fast {
+	if settings.failfast {
 		return errSchema
 	}
 	return &SchemaError{
@@ -765,25 +947,25 @@ func (schema *Schema) visitJSONNull(fast bool) (err error) {
 }
 
 func (schema *Schema) VisitJSONBoolean(value bool) error {
-	return schema.visitJSONBoolean(value, false)
+	return schema.visitJSONBoolean(newSchemaValidationSettings(), value)
 }
 
-func (schema *Schema) visitJSONBoolean(value bool, fast bool) (err error) {
+func (schema *Schema) visitJSONBoolean(settings *schemaValidationSettings, value bool) (err error) {
 	if schemaType := schema.Type; schemaType != "" && schemaType != "boolean" {
-		return schema.expectedType("boolean", fast)
+		return schema.expectedType(settings, "boolean")
 	}
 	return
 }
 
 func (schema *Schema) VisitJSONNumber(value float64) error {
-	return schema.visitJSONNumber(value, false)
+	return schema.visitJSONNumber(newSchemaValidationSettings(), value)
 }
 
-func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
+func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value float64) (err error) {
 	schemaType := schema.Type
 	if schemaType == "integer" {
 		if bigFloat := big.NewFloat(value); !bigFloat.IsInt() {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -794,12 +976,31 @@ func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
 			}
 		}
 	} else if schemaType != "" && schemaType != "number" {
-		return schema.expectedType("number, integer", fast)
+		return schema.expectedType(settings, "number, integer")
+	}
+
+	// "format" of integers implies their range
+	if format := schema.Format; (format == "int32" || format == "int64") && !settings.formatValidationDisabled {
+		exceeds := value < math.MinInt32 || value > math.MaxInt32
+		if format == "int64" {
+			// float64(math.MaxInt64) rounds up to 2^63, which exceeds the range itself
+			exceeds = value < math.MinInt64 || value >= math.MaxInt64
+		}
+		if exceeds {
+			if err := settings.formatMismatch(&SchemaError{
+				Value:       value,
+				Schema:      schema,
+				SchemaField: "format",
+				Reason:      fmt.Sprintf("value exceeds %s range", format),
+			}); err != nil {
+				return err
+			}
+		}
 	}
 
 	// "exclusiveMinimum"
 	if v := schema.ExclusiveMin; v && !(*schema.Min < value) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -812,7 +1013,7 @@ func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
 
 	// "exclusiveMaximum"
 	if v := schema.ExclusiveMax; v && !(*schema.Max > value) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -825,7 +1026,7 @@ func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
 
 	// "minimum"
 	if v := schema.Min; v != nil && !(*v <= value) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -838,7 +1039,7 @@ func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
 
 	// "maximum"
 	if v := schema.Max; v != nil && !(*v >= value) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -854,7 +1055,7 @@ func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
 		// "A numeric instance is valid only if division by this keyword's
 		//    value results in an integer."
 		if bigFloat := big.NewFloat(value / *v); !bigFloat.IsInt() {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -868,12 +1069,12 @@ func (schema *Schema) visitJSONNumber(value float64, fast bool) (err error) {
 }
 
 func (schema *Schema) VisitJSONString(value string) error {
-	return schema.visitJSONString(value, false)
+	return schema.visitJSONString(newSchemaValidationSettings(), value)
 }
 
-func (schema *Schema) visitJSONString(value string, fast bool) (err error) {
+func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value string) (err error) {
 	if schemaType := schema.Type; schemaType != "" && schemaType != "string" {
-		return schema.expectedType("string", fast)
+		return schema.expectedType(settings, "string")
 	}
 
 	// "minLength" and "maxLength"
@@ -890,7 +1091,7 @@ func (schema *Schema) visitJSONString(value string, fast bool) (err error) {
 			}
 		}
 		if minLength != 0 && length < int64(minLength) {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -901,7 +1102,7 @@ func (schema *Schema) visitJSONString(value string, fast bool) (err error) {
 			}
 		}
 		if maxLength != nil && length > int64(*maxLength) {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return &SchemaError{
@@ -914,63 +1115,129 @@ func (schema *Schema) visitJSONString(value string, fast bool) (err error) {
 	}
 
 	// "format" and "pattern"
-	cp := schema.compiledPattern
-	if cp == nil {
-		pattern := schema.Pattern
-		if v := schema.Pattern; len(v) > 0 {
-			// Pattern
-			re, err := regexp.Compile(v)
-			if err != nil {
-				return fmt.Errorf("Error while compiling regular expression '%s': %v", pattern, err)
-			}
+	var cp *compiledPattern
+	if v := schema.Pattern; len(v) > 0 {
+		// Pattern
+		re, err := compilePattern(v)
+		if err != nil {
+			return fmt.Errorf("Error while compiling regular expression '%s': %v", v, err)
+		}
+		cp = &compiledPattern{
+			Regexp:    re,
+			ErrReason: "JSON string doesn't match the regular expression '" + v + "'",
+		}
+	} else if v := schema.Format; len(v) > 0 {
+		// No pattern, but does have a format
+		re := SchemaStringFormats[v]
+		if re != nil {
 			cp = &compiledPattern{
 				Regexp:    re,
-				ErrReason: "JSON string doesn't match the regular expression '" + v + "'",
-			}
-			schema.compiledPattern = cp
-		} else if v := schema.Format; len(v) > 0 {
-			// No pattern, but does have a format
-			re := SchemaStringFormats[v]
-			if re != nil {
-				cp = &compiledPattern{
-					Regexp:    re,
-					ErrReason: "JSON string doesn't match the format '" + v + " (regular expression `" + re.String() + "`)'",
-				}
-				schema.compiledPattern = cp
+				ErrReason: "JSON string doesn't match the format '" + v + " (regular expression `" + re.String() + "`)'",
 			}
 		}
 	}
-	if cp != nil {
+	if cp != nil && (schema.Pattern != "" || !settings.formatValidationDisabled) {
 		if !cp.Regexp.MatchString(value) {
-			field := "format"
+			err := &SchemaError{
+				Value:       value,
+				Schema:      schema,
+				SchemaField: "format",
+				Reason:      cp.ErrReason,
+			}
 			if schema.Pattern != "" {
-				field = "pattern"
+				err.SchemaField = "pattern"
+				return err
 			}
-			return &SchemaError{
+			if err := settings.formatMismatch(err); err != nil {
+				return err
+			}
+		}
+	}
+	// Like formats defined by a pattern, only without a pattern
+	if validate := SchemaStringFormatValidators[schema.Format]; validate != nil && schema.Pattern == "" && !settings.formatValidationDisabled {
+		if err := validate(value); err != nil {
+			if err := settings.formatMismatch(&SchemaError{
 				Value:       value,
 				Schema:      schema,
-				SchemaField: field,
-				Reason:      cp.ErrReason,
+				SchemaField: "format",
+				Reason:      fmt.Sprintf("JSON string doesn't match the format '%s': %v", schema.Format, err),
+			}); err != nil {
+				return err
 			}
 		}
 	}
+
+	// "contentEncoding" and "contentMediaType"
+	if schema.ContentEncoding != "" || schema.ContentMediaType != "" {
+		return schema.visitJSONStringContent(settings, value)
+	}
 	return
 }
 
+// visitJSONStringContent checks that the string decodes according to
+// "contentEncoding", and that the decoded content is well-formed if
+// "contentMediaType" is a JSON media type. Other media types are not checked.
+func (schema *Schema) visitJSONStringContent(settings *schemaValidationSettings, value string) error {
+	content := []byte(value)
+	var encoding *base64.Encoding
+	switch schema.ContentEncoding {
+	case "base64":
+		encoding = base64.StdEncoding
+	case "base64url":
+		encoding = base64.URLEncoding
+	}
+	if encoding != nil {
+		decoded, err := encoding.DecodeString(value)
+		if err != nil {
+			if settings.failfast {
+				return errSchema
+			}
+			return &SchemaError{
+				Value:       value,
+				Schema:      schema,
+				SchemaField: "contentEncoding",
+				Reason:      fmt.Sprintf("JSON string is not valid %s: %v", schema.ContentEncoding, err),
+			}
+		}
+		content = decoded
+	}
+	if isJSONMediaType(schema.ContentMediaType) && !json.Valid(content) {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "contentMediaType",
+			Reason:      fmt.Sprintf("Content is not valid '%s'", schema.ContentMediaType),
+		}
+	}
+	return nil
+}
+
+func isJSONMediaType(mediaType string) bool {
+	if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+		mediaType = mediaType[:i]
+	}
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
 func (schema *Schema) VisitJSONArray(value []interface{}) error {
-	return schema.visitJSONArray(value, false)
+	settings := newSchemaValidationSettings()
+	return settings.result(schema.visitJSONArray(settings, value))
 }
 
-func (schema *Schema) visitJSONArray(value []interface{}, fast bool) (err error) {
+func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value []interface{}) (err error) {
 	if schemaType := schema.Type; schemaType != "" && schemaType != "array" {
-		return schema.expectedType("array", fast)
+		return schema.expectedType(settings, "array")
 	}
 
 	lenValue := int64(len(value))
 
 	// "minItems"
 	if v := schema.MinItems; v != 0 && lenValue < int64(v) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -983,7 +1250,7 @@ func (schema *Schema) visitJSONArray(value []interface{}, fast bool) (err error)
 
 	// "maxItems"
 	if v := schema.MaxItems; v != nil && lenValue > int64(*v) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -994,27 +1261,37 @@ func (schema *Schema) visitJSONArray(value []interface{}, fast bool) (err error)
 		}
 	}
 
-	// "uniqueItems"
-	if v := schema.UniqueItems; v && !sliceUniqueItemsChecker(value) {
-		if fast {
-			return errSchema
+	if err = schema.visitJSONArrayUniqueItems(settings, value); err != nil {
+		return
+	}
+
+	// "prefixItems"
+	prefixLen := 0
+	for i, itemSchemaRef := range schema.PrefixItems {
+		if i >= len(value) {
+			break
 		}
-		return &SchemaError{
-			Value:       value,
-			Schema:      schema,
-			SchemaField: "uniqueItems",
-			Reason:      fmt.Sprintf("Duplicate items found"),
+		itemSchema := itemSchemaRef.Value
+		if itemSchema == nil {
+			return foundUnresolvedRef(itemSchemaRef.Ref)
 		}
+		if err := itemSchema.visitJSON(settings, value[i]); err != nil {
+			if settings.failfast {
+				return errSchema
+			}
+			return markSchemaErrorIndex(err, i)
+		}
+		prefixLen = i + 1
 	}
 
-	// "items"
+	// "items", applied to the items not covered by "prefixItems"
 	if itemSchemaRef := schema.Items; itemSchemaRef != nil {
 		itemSchema := itemSchemaRef.Value
 		if itemSchema == nil {
 			return foundUnresolvedRef(itemSchemaRef.Ref)
 		}
-		for i, item := range value {
-			if err := itemSchema.VisitJSON(item); err != nil {
+		for i := prefixLen; i < len(value); i++ {
+			if err := itemSchema.visitJSON(settings, value[i]); err != nil {
 				return markSchemaErrorIndex(err, i)
 			}
 		}
@@ -1022,13 +1299,30 @@ func (schema *Schema) visitJSONArray(value []interface{}, fast bool) (err error)
 	return
 }
 
+func (schema *Schema) visitJSONArrayUniqueItems(settings *schemaValidationSettings, value []interface{}) error {
+	// "uniqueItems"
+	if v := schema.UniqueItems; v && !sliceUniqueItemsChecker(value) {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "uniqueItems",
+			Reason:      fmt.Sprintf("Duplicate items found"),
+		}
+	}
+	return nil
+}
+
 func (schema *Schema) VisitJSONObject(value map[string]interface{}) error {
-	return schema.visitJSONObject(value, false)
+	settings := newSchemaValidationSettings()
+	return settings.result(schema.visitJSONObject(settings, value))
 }
 
-func (schema *Schema) visitJSONObject(value map[string]interface{}, fast bool) (err error) {
+func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value map[string]interface{}) (err error) {
 	if schemaType := schema.Type; schemaType != "" && schemaType != "object" {
-		return schema.expectedType("object", fast)
+		return schema.expectedType(settings, "object")
 	}
 
 	// "properties"
@@ -1037,27 +1331,27 @@ func (schema *Schema) visitJSONObject(value map[string]interface{}, fast bool) (
 
 	// "minProperties"
 	if v := schema.MinProps; v != 0 && lenValue < int64(v) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
 			Value:       value,
 			Schema:      schema,
 			SchemaField: "minProperties",
-			Reason:      fmt.Sprintf("There must be at least %d properties", v),
+			Reason:      fmt.Sprintf("object has %s, minProperties is %d", countProperties(lenValue), v),
 		}
 	}
 
 	// "maxProperties"
 	if v := schema.MaxProps; v != nil && lenValue > int64(*v) {
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
 			Value:       value,
 			Schema:      schema,
 			SchemaField: "maxProperties",
-			Reason:      fmt.Sprintf("There must be at most %d properties", *v),
+			Reason:      fmt.Sprintf("object has %s, maxProperties is %d", countProperties(lenValue), *v),
 		}
 	}
 
@@ -1074,20 +1368,23 @@ func (schema *Schema) visitJSONObject(value map[string]interface{}, fast bool) (
 				if p == nil {
 					return foundUnresolvedRef(propertyRef.Ref)
 				}
-				if err := p.VisitJSON(v); err != nil {
-					if fast {
+				if err := p.visitJSON(settings, v); err != nil {
+					if settings.failfast {
 						return errSchema
 					}
 					return markSchemaErrorKey(err, k)
 				}
+				if settings.coverage != nil {
+					settings.coverage.mark(schema, "properties/"+escapeJSONPointerToken(k))
+				}
 				continue
 			}
 		}
 		allowed := schema.AdditionalPropertiesAllowed
 		if additionalProperties != nil || allowed == nil || (allowed != nil && *allowed) {
 			if additionalProperties != nil {
-				if err := additionalProperties.VisitJSON(v); err != nil {
-					if fast {
+				if err := additionalProperties.visitJSON(settings, v); err != nil {
+					if settings.failfast {
 						return errSchema
 					}
 					return markSchemaErrorKey(err, k)
@@ -1095,7 +1392,7 @@ func (schema *Schema) visitJSONObject(value map[string]interface{}, fast bool) (
 			}
 			continue
 		}
-		if fast {
+		if settings.failfast {
 			return errSchema
 		}
 		return &SchemaError{
@@ -1103,11 +1400,15 @@ func (schema *Schema) visitJSONObject(value map[string]interface{}, fast bool) (
 			Schema:      schema,
 			SchemaField: "properties",
 			Reason:      fmt.Sprintf("Property '%s' is unsupported", k),
+			params:      map[string]interface{}{"property": k},
 		}
 	}
 	for _, k := range schema.Required {
+		if settings.requiredDisabled {
+			break
+		}
 		if _, ok := value[k]; !ok {
-			if fast {
+			if settings.failfast {
 				return errSchema
 			}
 			return markSchemaErrorKey(&SchemaError{
@@ -1115,14 +1416,49 @@ func (schema *Schema) visitJSONObject(value map[string]interface{}, fast bool) (
 				Schema:      schema,
 				SchemaField: "required",
 				Reason:      fmt.Sprintf("Property '%s' is missing", k),
+				params:      map[string]interface{}{"property": k},
 			}, k)
 		}
 	}
+	if len(schema.DependentRequired) != 0 {
+		properties := make([]string, 0, len(schema.DependentRequired))
+		for property := range schema.DependentRequired {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		for _, property := range properties {
+			if _, ok := value[property]; !ok {
+				continue
+			}
+			for _, k := range schema.DependentRequired[property] {
+				if _, ok := value[k]; !ok {
+					if settings.failfast {
+						return errSchema
+					}
+					return markSchemaErrorKey(&SchemaError{
+						Value:       value,
+						Schema:      schema,
+						SchemaField: "dependentRequired",
+						Reason:      fmt.Sprintf("Property '%s' is missing, it is required when '%s' is present", k, property),
+						params:      map[string]interface{}{"property": k, "dependent": property},
+					}, k)
+				}
+			}
+		}
+	}
 	return
 }
 
-func (schema *Schema) expectedType(typ string, fast bool) error {
-	if fast {
+// countProperties formats a number of object properties, e.g. "1 property".
+func countProperties(n int64) string {
+	if n == 1 {
+		return "1 property"
+	}
+	return fmt.Sprintf("%d properties", n)
+}
+
+func (schema *Schema) expectedType(settings *schemaValidationSettings, typ string) error {
+	if settings.failfast {
 		return errSchema
 	}
 	return &SchemaError{
@@ -1140,6 +1476,8 @@ type SchemaError struct {
 	SchemaField string
 	Reason      string
 	Origin      error
+	// params are the message parameters which aren't derived from the schema, see Params.
+	params map[string]interface{}
 }
 
 func markSchemaErrorKey(err error, key string) error {
@@ -1194,7 +1532,7 @@ func (err *SchemaError) Error() string {
 		buf.WriteString("\nSchema:\n  ")
 		encoder := json.NewEncoder(buf)
 		encoder.SetIndent("  ", "  ")
-		if err := encoder.Encode(err.Schema); err != nil {
+		if err := encoder.Encode(acyclicSchema(err.Schema, "#", map[*Schema]string{})); err != nil {
 			panic(err)
 		}
 		buf.WriteString("\nValue:\n  ")
@@ -1205,18 +1543,95 @@ func (err *SchemaError) Error() string {
 	return buf.String()
 }
 
+// acyclicSchema returns a copy of the schema at the pointer which can be
+// encoded, even if it contains itself, like recursive schemas built in code.
+// An inline sub-schema which is one of the enclosing schemas, the ancestors by
+// their pointers, is replaced by a reference to it, relative to the copy, e.g.
+// "#/anyOf/1/properties/node".
+func acyclicSchema(schema *Schema, pointer string, ancestors map[*Schema]string) *Schema {
+	if schema == nil {
+		return nil
+	}
+	ancestors[schema] = pointer
+	defer delete(ancestors, schema)
+
+	sub := func(ref *SchemaRef, tokens ...string) *SchemaRef {
+		if ref == nil || ref.Ref != "" || ref.Value == nil {
+			return ref
+		}
+		if ancestor, ok := ancestors[ref.Value]; ok {
+			return &SchemaRef{Ref: ancestor}
+		}
+		path := pointer
+		for _, token := range tokens {
+			path += "/" + escapeJSONPointerToken(token)
+		}
+		copied := *ref
+		copied.Value = acyclicSchema(ref.Value, path, ancestors)
+		return &copied
+	}
+	subs := func(refs []*SchemaRef, field string) []*SchemaRef {
+		if refs == nil {
+			return nil
+		}
+		copied := make([]*SchemaRef, len(refs))
+		for i, ref := range refs {
+			copied[i] = sub(ref, field, strconv.Itoa(i))
+		}
+		return copied
+	}
+
+	copied := *schema
+	copied.OneOf = subs(schema.OneOf, "oneOf")
+	copied.AnyOf = subs(schema.AnyOf, "anyOf")
+	copied.AllOf = subs(schema.AllOf, "allOf")
+	copied.Not = sub(schema.Not, "not")
+	copied.If = sub(schema.If, "if")
+	copied.Then = sub(schema.Then, "then")
+	copied.Else = sub(schema.Else, "else")
+	copied.Items = sub(schema.Items, "items")
+	copied.PrefixItems = subs(schema.PrefixItems, "prefixItems")
+	if schema.Properties != nil {
+		copied.Properties = make(map[string]*SchemaRef, len(schema.Properties))
+		for name, ref := range schema.Properties {
+			copied.Properties[name] = sub(ref, "properties", name)
+		}
+	}
+	copied.AdditionalProperties = sub(schema.AdditionalProperties, "additionalProperties")
+	return &copied
+}
+
 func isSliceOfUniqueItems(xs []interface{}) bool {
 	s := len(xs)
 	m := make(map[string]struct{}, s)
 	for _, x := range xs {
-		// The input slice is coverted from a JSON string, there shall
-		// have no error when covert it back.
-		key, _ := json.Marshal(&x)
-		m[string(key)] = struct{}{}
+		m[uniqueItemKey(x)] = struct{}{}
 	}
 	return s == len(m)
 }
 
+// uniqueItemKey returns the JSON encoding of x, which is equal for deeply equal values.
+func uniqueItemKey(x interface{}) string {
+	// The input slice is coverted from a JSON string, there shall
+	// have no error when covert it back.
+	key, _ := json.Marshal(&x)
+	return string(key)
+}
+
+// duplicateEnumMembers returns the indices of the first two equal enum members,
+// compared like unique items, or -1, -1 if all members are distinct.
+func duplicateEnumMembers(enum []interface{}) (int, int) {
+	seen := make(map[string]int, len(enum))
+	for i, member := range enum {
+		key := uniqueItemKey(member)
+		if first, ok := seen[key]; ok {
+			return first, i
+		}
+		seen[key] = i
+	}
+	return -1, -1
+}
+
 // SliceUniqueItemsChecker is an function used to check if an given slice
 // have unique items.
 type SliceUniqueItemsChecker func(items []interface{}) bool
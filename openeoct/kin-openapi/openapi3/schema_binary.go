@@ -0,0 +1,42 @@
+package openapi3
+
+import "fmt"
+
+// VisitBinary validates binary data, e.g. an application/octet-stream body,
+// against the string schema. As binary data has no JSON structure, only the
+// type of the schema and its minLength and maxLength, as the size of the data
+// in bytes, are validated.
+func (schema *Schema) VisitBinary(data []byte, opts ...SchemaValidationOption) error {
+	settings := newSchemaValidationSettings(opts...)
+	return settings.result(schema.visitBinary(settings, data))
+}
+
+func (schema *Schema) visitBinary(settings *schemaValidationSettings, data []byte) error {
+	if schemaType := schema.Type; schemaType != "" && schemaType != "string" {
+		return schema.expectedType(settings, "string")
+	}
+	size := len(data)
+	if v := schema.MinLength; v != 0 && uint64(size) < v {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:       size,
+			Schema:      schema,
+			SchemaField: "minLength",
+			Reason:      fmt.Sprintf("Minimum size is %d bytes, data has %d bytes", v, size),
+		}
+	}
+	if v := schema.MaxLength; v != nil && uint64(size) > *v {
+		if settings.failfast {
+			return errSchema
+		}
+		return &SchemaError{
+			Value:       size,
+			Schema:      schema,
+			SchemaField: "maxLength",
+			Reason:      fmt.Sprintf("Maximum size is %d bytes, data has %d bytes", *v, size),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package openapi3
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaCoverage records which parts of schemas were exercised by validated values:
+// enum values, oneOf and anyOf options and optional properties.
+// It is safe for concurrent use.
+type SchemaCoverage struct {
+	mu        sync.Mutex
+	exercised map[*Schema]map[string]struct{}
+}
+
+// NewSchemaCoverage returns an empty SchemaCoverage, to be passed to
+// WithSchemaCoverage when validating values.
+func NewSchemaCoverage() *SchemaCoverage {
+	return &SchemaCoverage{
+		exercised: make(map[*Schema]map[string]struct{}),
+	}
+}
+
+// WithSchemaCoverage records the coverage of schemas by validated values.
+// Without this option, values are validated without instrumentation.
+func WithSchemaCoverage(coverage *SchemaCoverage) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.coverage = coverage }
+}
+
+func (coverage *SchemaCoverage) mark(schema *Schema, part string) {
+	coverage.mu.Lock()
+	defer coverage.mu.Unlock()
+	parts := coverage.exercised[schema]
+	if parts == nil {
+		parts = make(map[string]struct{})
+		coverage.exercised[schema] = parts
+	}
+	parts[part] = struct{}{}
+}
+
+func (coverage *SchemaCoverage) isExercised(schema *Schema, part string) bool {
+	_, ok := coverage.exercised[schema][part]
+	return ok
+}
+
+// Unexercised returns JSON pointers, relative to schema, of the enum values,
+// oneOf and anyOf options and optional properties no validated value exercised.
+// For example "/properties/options/oneOf/1".
+func (coverage *SchemaCoverage) Unexercised(schema *Schema) []string {
+	coverage.mu.Lock()
+	defer coverage.mu.Unlock()
+	var unexercised []string
+	coverage.unexercised(schema, "", make(map[*Schema]struct{}), &unexercised)
+	return unexercised
+}
+
+func (coverage *SchemaCoverage) unexercised(schema *Schema, ptr string, visited map[*Schema]struct{}, unexercised *[]string) {
+	if schema == nil {
+		return
+	}
+	if _, ok := visited[schema]; ok {
+		return
+	}
+	visited[schema] = struct{}{}
+
+	for i := range schema.Enum {
+		if part := "enum/" + strconv.Itoa(i); !coverage.isExercised(schema, part) {
+			*unexercised = append(*unexercised, ptr+"/"+part)
+		}
+	}
+	for i, ref := range schema.OneOf {
+		part := "oneOf/" + strconv.Itoa(i)
+		if !coverage.isExercised(schema, part) {
+			*unexercised = append(*unexercised, ptr+"/"+part)
+		}
+		coverage.unexercised(ref.Value, ptr+"/"+part, visited, unexercised)
+	}
+	for i, ref := range schema.AnyOf {
+		part := "anyOf/" + strconv.Itoa(i)
+		if !coverage.isExercised(schema, part) {
+			*unexercised = append(*unexercised, ptr+"/"+part)
+		}
+		coverage.unexercised(ref.Value, ptr+"/"+part, visited, unexercised)
+	}
+	for i, ref := range schema.AllOf {
+		coverage.unexercised(ref.Value, ptr+"/allOf/"+strconv.Itoa(i), visited, unexercised)
+	}
+	if schema.If != nil {
+		for i, ref := range []*SchemaRef{schema.Then, schema.Else} {
+			part := []string{"then", "else"}[i]
+			if ref == nil {
+				continue
+			}
+			if !coverage.isExercised(schema, part) {
+				*unexercised = append(*unexercised, ptr+"/"+part)
+			}
+			coverage.unexercised(ref.Value, ptr+"/"+part, visited, unexercised)
+		}
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		part := "properties/" + escapeJSONPointerToken(name)
+		if !required[name] && !coverage.isExercised(schema, part) {
+			*unexercised = append(*unexercised, ptr+"/"+part)
+		}
+		coverage.unexercised(schema.Properties[name].Value, ptr+"/"+part, visited, unexercised)
+	}
+	if ref := schema.AdditionalProperties; ref != nil {
+		coverage.unexercised(ref.Value, ptr+"/additionalProperties", visited, unexercised)
+	}
+	if ref := schema.Items; ref != nil {
+		coverage.unexercised(ref.Value, ptr+"/items", visited, unexercised)
+	}
+	for i, ref := range schema.PrefixItems {
+		coverage.unexercised(ref.Value, ptr+"/prefixItems/"+strconv.Itoa(i), visited, unexercised)
+	}
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
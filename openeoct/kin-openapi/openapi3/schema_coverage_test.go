@@ -0,0 +1,36 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCoverage(t *testing.T) {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("process_id", openapi3.NewStringSchema()).
+		WithProperty("description", openapi3.NewStringSchema()).
+		WithProperty("format", openapi3.NewStringSchema().WithEnum("GTiff", "netCDF", "PNG")).
+		WithProperty("value", openapi3.NewOneOfSchema(openapi3.NewFloat64Schema(), openapi3.NewStringSchema(), openapi3.NewBoolSchema()))
+	schema.Required = []string{"process_id"}
+
+	coverage := openapi3.NewSchemaCoverage()
+	for _, value := range []map[string]interface{}{
+		{"process_id": "ndvi", "format": "GTiff", "value": 1.0},
+		{"process_id": "ndvi", "format": "PNG", "value": "red"},
+		{"process_id": "ndvi", "format": "JPEG"},
+	} {
+		schema.VisitJSON(value, openapi3.WithSchemaCoverage(coverage))
+	}
+
+	require.Equal(t, []string{
+		"/properties/description",
+		"/properties/format/enum/1",
+		"/properties/value/oneOf/2",
+	}, coverage.Unexercised(schema))
+
+	// Without the option nothing is recorded
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"process_id": "ndvi", "description": "x"}))
+	require.Contains(t, coverage.Unexercised(schema), "/properties/description")
+}
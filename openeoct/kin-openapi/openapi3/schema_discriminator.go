@@ -0,0 +1,166 @@
+package openapi3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// visitDiscriminator validates the object against the oneOf or anyOf member
+// selected by the value of the discriminator property, instead of trying all of
+// them, so the errors are those of the selected schema. The value is mapped to
+// a schema by the mapping of the discriminator, or else by the name of the
+// component schema. It returns false if the schema has no such discriminator.
+func (schema *Schema) visitDiscriminator(settings *schemaValidationSettings, value map[string]interface{}) (bool, error) {
+	discriminator := schema.Discriminator
+	if discriminator == nil || discriminator.PropertyName == "" {
+		return false, nil
+	}
+	field, candidates := "oneOf", schema.OneOf
+	if len(candidates) == 0 {
+		field, candidates = "anyOf", schema.AnyOf
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+	name := discriminator.PropertyName
+	params := map[string]interface{}{"property": name}
+
+	property, ok := value[name]
+	if !ok {
+		if settings.failfast {
+			return true, errSchema
+		}
+		return true, markSchemaErrorKey(&SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "discriminator",
+			Reason:      fmt.Sprintf("Property '%s' is missing", name),
+			params:      params,
+		}, name)
+	}
+	discriminatorValue, ok := property.(string)
+	if !ok {
+		if settings.failfast {
+			return true, errSchema
+		}
+		return true, markSchemaErrorKey(&SchemaError{
+			Value:       property,
+			Schema:      schema,
+			SchemaField: "discriminator",
+			Reason:      fmt.Sprintf("Discriminator property '%s' must be a string", name),
+			params:      params,
+		}, name)
+	}
+
+	i, item := discriminatorCandidate(discriminator, discriminatorValue, candidates)
+	if item == nil {
+		if settings.failfast {
+			return true, errSchema
+		}
+		return true, markSchemaErrorKey(&SchemaError{
+			Value:       discriminatorValue,
+			Schema:      schema,
+			SchemaField: "discriminator",
+			Reason:      fmt.Sprintf("Discriminator value %q doesn't select any of the %s schemas", discriminatorValue, field),
+			params:      params,
+		}, name)
+	}
+	v := item.Value
+	if v == nil {
+		return true, foundUnresolvedRef(item.Ref)
+	}
+	if settings.coverage != nil {
+		settings.coverage.mark(schema, field+"/"+strconv.Itoa(i))
+	}
+	if err := v.visitJSON(settings, value); err != nil {
+		if settings.failfast {
+			return true, errSchema
+		}
+		return true, &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "discriminator",
+			Reason:      fmt.Sprintf("Value doesn't match the schema selected by the discriminator value %q", discriminatorValue),
+			Origin:      err,
+			params:      params,
+		}
+	}
+	return true, nil
+}
+
+// discriminatorCandidate returns the schema among candidates which the
+// discriminator value selects, or nil.
+func discriminatorCandidate(discriminator *Discriminator, value string, candidates []*SchemaRef) (int, *SchemaRef) {
+	ref, mapped := discriminator.Mapping[value]
+	if !mapped {
+		ref = value
+	}
+	if !strings.Contains(ref, "#") && !strings.Contains(ref, "/") {
+		// A schema name instead of a reference
+		ref = "#/components/schemas/" + ref
+	}
+	for i, candidate := range candidates {
+		if candidate == nil {
+			continue
+		}
+		if candidate.Ref == ref || (strings.HasPrefix(ref, "#") && strings.HasSuffix(candidate.Ref, ref)) {
+			return i, candidate
+		}
+	}
+	return -1, nil
+}
+
+// unknownDiscriminatorMappingKey returns the first mapping key of the
+// discriminator which isn't a member of the enum of the discriminator
+// property, and the name of the property. The key is empty if all keys are
+// members, or the property has no enum.
+func (schema *Schema) unknownDiscriminatorMappingKey() (string, string) {
+	discriminator := schema.Discriminator
+	if discriminator == nil || discriminator.PropertyName == "" || len(discriminator.Mapping) == 0 {
+		return "", ""
+	}
+	name := discriminator.PropertyName
+	property := discriminatorProperty(schema, name, nil)
+	if property == nil || len(property.Enum) == 0 {
+		return "", ""
+	}
+	for _, key := range sortedMapKeys(discriminator.Mapping) {
+		if !isEnumMember(property.Enum, key) {
+			return key, name
+		}
+	}
+	return "", ""
+}
+
+// discriminatorProperty returns the schema of the property declared by the
+// schema, or else by the members of its allOf, or nil.
+func discriminatorProperty(schema *Schema, name string, stack []*Schema) *Schema {
+	for _, existing := range stack {
+		if existing == schema {
+			return nil
+		}
+	}
+	stack = append(stack, schema)
+	if ref := schema.Properties[name]; ref != nil {
+		return ref.Value
+	}
+	for _, item := range schema.AllOf {
+		if item == nil || item.Value == nil {
+			continue
+		}
+		if property := discriminatorProperty(item.Value, name, stack); property != nil {
+			return property
+		}
+	}
+	return nil
+}
+
+func isEnumMember(enum []interface{}, value string) bool {
+	for _, item := range enum {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -12,6 +12,10 @@ const (
 
 var SchemaStringFormats = make(map[string]*regexp.Regexp, 8)
 
+// SchemaStringFormatValidators are the formats defined by a function, see
+// DefineStringFormatValidator.
+var SchemaStringFormatValidators = make(map[string]func(value string) error, 4)
+
 func DefineStringFormat(name string, pattern string) {
 	re, err := regexp.Compile(pattern)
 	if err != nil {
@@ -19,6 +23,72 @@ func DefineStringFormat(name string, pattern string) {
 		panic(err)
 	}
 	SchemaStringFormats[name] = re
+	delete(SchemaStringFormatValidators, name)
+}
+
+// DefineStringFormatValidator defines a format by a function returning an error
+// describing why a string doesn't have the format, for formats which a pattern
+// can't describe. It replaces a pattern defined for the format. Like formats
+// defined by a pattern, it isn't applied to schemas which have a pattern.
+func DefineStringFormatValidator(name string, validate func(value string) error) {
+	SchemaStringFormatValidators[name] = validate
+	delete(SchemaStringFormats, name)
+}
+
+// FormatSeverity is how values which don't have their format are treated, see
+// DefineFormatSeverity.
+type FormatSeverity int
+
+const (
+	// FormatSeverityError makes values which don't have the format invalid,
+	// the default of all formats.
+	FormatSeverityError FormatSeverity = iota
+	// FormatSeverityWarning reports values which don't have the format to the
+	// FormatWarnings option, the values are valid.
+	FormatSeverityWarning
+	// FormatSeverityIgnore doesn't check the format of values.
+	FormatSeverityIgnore
+)
+
+// SchemaFormatSeverities are the severities of the formats by name, formats
+// which aren't listed are errors.
+var SchemaFormatSeverities = make(map[string]FormatSeverity, 4)
+
+// DefineFormatSeverity sets how values which don't have the format are treated,
+// e.g. to only warn about malformed URIs while keeping date-time strict. It
+// applies to all formats, built-in or defined with DefineStringFormat, also
+// to the ranges of the int32 and int64 formats.
+func DefineFormatSeverity(name string, severity FormatSeverity) {
+	if severity == FormatSeverityError {
+		delete(SchemaFormatSeverities, name)
+		return
+	}
+	SchemaFormatSeverities[name] = severity
+}
+
+// ParseFormatSeverity parses "error", "warning" (or "warn") and "ignore".
+func ParseFormatSeverity(severity string) (FormatSeverity, error) {
+	switch severity {
+	case "error":
+		return FormatSeverityError, nil
+	case "warning", "warn":
+		return FormatSeverityWarning, nil
+	case "ignore":
+		return FormatSeverityIgnore, nil
+	}
+	return FormatSeverityError, fmt.Errorf("unknown format severity %q, expected error, warning or ignore", severity)
+}
+
+// validateStringFormat returns an error if the string doesn't have the format
+// defined with DefineStringFormat or DefineStringFormatValidator.
+func validateStringFormat(format string, value string) error {
+	if re := SchemaStringFormats[format]; re != nil && !re.MatchString(value) {
+		return fmt.Errorf("doesn't match the regular expression `%s`", re.String())
+	}
+	if validate := SchemaStringFormatValidators[format]; validate != nil {
+		return validate(value)
+	}
+	return nil
 }
 
 func init() {
@@ -30,9 +100,9 @@ func init() {
 	// The pattern supports base64 and b./ase64url. Padding ('=') is supported.
 	DefineStringFormat("byte", `(^$|^[a-zA-Z0-9+/\-_]*=*$)`)
 
-	// date
-	DefineStringFormat("date", `^[0-9]{4}-(0[0-9]|10|11|12)-([0-2][0-9]|30|31)$`)
-
-	// date-time
-	DefineStringFormat("date-time", `^[0-9]{4}-(0[0-9]|10|11|12)-([0-2][0-9]|30|31)T[0-9]{2}:[0-9]{2}:[0-9]{2}(.[0-9]+)?(Z|(\+|-)[0-9]{2}:[0-9]{2})?$`)
+	// Temporal formats of RFC 3339, with the days of months and leap seconds
+	DefineStringFormatValidator("date", validateDate)
+	DefineStringFormatValidator("time", validateTime)
+	DefineStringFormatValidator("date-time", validateDateTime)
+	DefineStringFormatValidator("duration", validateDuration)
 }
@@ -0,0 +1,96 @@
+package openapi3
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	fullDatePattern = regexp.MustCompile(`^([0-9]{4})-([0-9]{2})-([0-9]{2})$`)
+	timePattern     = regexp.MustCompile(`^([0-9]{2}):([0-9]{2}):([0-9]{2})(\.[0-9]+)?([Zz]|([+-])([0-9]{2}):([0-9]{2}))?$`)
+	durationPattern = regexp.MustCompile(`^P(?:([0-9]+Y)?([0-9]+M)?([0-9]+D)?(?:T([0-9]+H)?([0-9]+M)?([0-9]+(?:\.[0-9]+)?S)?)?|[0-9]+W)$`)
+)
+
+// validateDate validates a full-date of RFC 3339, e.g. "2020-02-29".
+func validateDate(value string) error {
+	m := fullDatePattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Errorf("%q is not a date like YYYY-MM-DD", value)
+	}
+	year, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	if month < 1 || month > 12 {
+		return fmt.Errorf("month %d is out of range", month)
+	}
+	// The day before the first day of the next month is the last day of the month
+	if days := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day(); day < 1 || day > days {
+		return fmt.Errorf("day %d is out of range for %s %d", day, time.Month(month), year)
+	}
+	return nil
+}
+
+// validateTime validates a full-time of RFC 3339, e.g. "12:00:00+01:00". The
+// time zone offset may be omitted, as for a partial-time. Leap seconds are
+// only valid at 23:59:60 UTC.
+func validateTime(value string) error {
+	m := timePattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Errorf("%q is not a time like HH:MM:SS with an optional fraction and time zone offset", value)
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	second, _ := strconv.Atoi(m[3])
+	if hour > 23 {
+		return fmt.Errorf("hour %d is out of range", hour)
+	}
+	if minute > 59 {
+		return fmt.Errorf("minute %d is out of range", minute)
+	}
+	if second > 60 {
+		return fmt.Errorf("second %d is out of range", second)
+	}
+	offset := 0
+	if m[6] != "" {
+		offsetHour, _ := strconv.Atoi(m[7])
+		offsetMinute, _ := strconv.Atoi(m[8])
+		if offsetHour > 23 || offsetMinute > 59 {
+			return fmt.Errorf("time zone offset %s is out of range", m[5])
+		}
+		offset = offsetHour*60 + offsetMinute
+		if m[6] == "-" {
+			offset = -offset
+		}
+	}
+	if second == 60 {
+		// Leap seconds are inserted at the end of the day in UTC
+		if utc := ((hour*60+minute-offset)%(24*60) + 24*60) % (24 * 60); utc != 23*60+59 {
+			return fmt.Errorf("leap second %s isn't at 23:59:60 UTC", value)
+		}
+	}
+	return nil
+}
+
+// validateDateTime validates a date-time of RFC 3339, e.g. "2020-02-29T12:00:00Z",
+// see validateDate and validateTime.
+func validateDateTime(value string) error {
+	if len(value) < 11 || (value[10] != 'T' && value[10] != 't') {
+		return fmt.Errorf("%q is not a date-time like YYYY-MM-DDTHH:MM:SSZ", value)
+	}
+	if err := validateDate(value[:10]); err != nil {
+		return err
+	}
+	return validateTime(value[11:])
+}
+
+// validateDuration validates a duration of ISO 8601 as defined by RFC 3339,
+// e.g. "P1DT12H" or "P2W".
+func validateDuration(value string) error {
+	// The pattern can't require a component after P and after T
+	if !durationPattern.MatchString(value) || value == "P" || value[len(value)-1] == 'T' {
+		return fmt.Errorf("%q is not an ISO 8601 duration like P1DT12H", value)
+	}
+	return nil
+}
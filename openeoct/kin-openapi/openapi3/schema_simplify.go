@@ -0,0 +1,202 @@
+package openapi3
+
+// Simplify returns a schema accepting the same values as the schema, without
+// its redundant constraints:
+//
+//   - constraints of another type than the schema's type, e.g. minLength of an
+//     integer schema, and exclusiveMinimum or exclusiveMaximum without a bound
+//   - duplicate required properties
+//   - allOf members without constraints, and the type of allOf members which
+//     is the type of the schema anyway
+//   - allOf with a single member, which is merged into the schema if the schema
+//     has no other constraints
+//
+// Inline subschemas are simplified too, while referenced schemas are kept as
+// they are. The schema isn't modified, but the result shares the parts which
+// weren't simplified with it.
+func (schema *Schema) Simplify() *Schema {
+	return simplifySchema(schema, make(map[*Schema]*Schema))
+}
+
+func simplifySchema(schema *Schema, simplified map[*Schema]*Schema) *Schema {
+	if result, ok := simplified[schema]; ok {
+		return result
+	}
+	result := &Schema{}
+	*result = *schema
+	simplified[schema] = result
+
+	result.Not = simplifySchemaRef(schema.Not, simplified)
+	result.If = simplifySchemaRef(schema.If, simplified)
+	result.Then = simplifySchemaRef(schema.Then, simplified)
+	result.Else = simplifySchemaRef(schema.Else, simplified)
+	result.Items = simplifySchemaRef(schema.Items, simplified)
+	result.AdditionalProperties = simplifySchemaRef(schema.AdditionalProperties, simplified)
+	result.OneOf = simplifySchemaRefs(schema.OneOf, simplified)
+	result.AnyOf = simplifySchemaRefs(schema.AnyOf, simplified)
+	result.PrefixItems = simplifySchemaRefs(schema.PrefixItems, simplified)
+	if schema.Properties != nil {
+		result.Properties = make(map[string]*SchemaRef, len(schema.Properties))
+		for name, ref := range schema.Properties {
+			result.Properties[name] = simplifySchemaRef(ref, simplified)
+		}
+	}
+
+	if result.Min == nil {
+		result.ExclusiveMin = false
+	}
+	if result.Max == nil {
+		result.ExclusiveMax = false
+	}
+	if len(result.Required) != 0 {
+		required := make([]string, 0, len(result.Required))
+		seen := make(map[string]bool, len(result.Required))
+		for _, name := range result.Required {
+			if !seen[name] {
+				seen[name] = true
+				required = append(required, name)
+			}
+		}
+		result.Required = required
+	}
+
+	result.AllOf = nil
+	for _, ref := range schema.AllOf {
+		ref = simplifySchemaRef(ref, simplified)
+		if ref.Ref == "" && ref.Value != nil {
+			if member := ref.Value; member.Type != "" && member.Type == result.Type {
+				member = copySchema(member, simplified)
+				member.Type = ""
+				ref = &SchemaRef{Value: member}
+			}
+			if !ref.Value.constrains() {
+				continue
+			}
+		}
+		result.AllOf = append(result.AllOf, ref)
+	}
+	if len(result.AllOf) == 1 && result.AllOf[0].Ref == "" && result.AllOf[0].Value != nil {
+		member := result.AllOf[0].Value
+		result.AllOf = nil
+		if result.constrains() {
+			result.AllOf = []*SchemaRef{{Value: member}}
+		} else {
+			result = mergeSchemaAnnotations(result, member)
+			simplified[schema] = result
+		}
+	}
+
+	result.removeConstraintsOfOtherTypes()
+	return result
+}
+
+func simplifySchemaRef(ref *SchemaRef, simplified map[*Schema]*Schema) *SchemaRef {
+	if ref == nil || ref.Ref != "" || ref.Value == nil {
+		return ref
+	}
+	return &SchemaRef{Value: simplifySchema(ref.Value, simplified)}
+}
+
+func simplifySchemaRefs(refs []*SchemaRef, simplified map[*Schema]*Schema) []*SchemaRef {
+	if refs == nil {
+		return nil
+	}
+	result := make([]*SchemaRef, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, simplifySchemaRef(ref, simplified))
+	}
+	return result
+}
+
+// copySchema returns a copy of the simplified schema, to be modified further.
+func copySchema(schema *Schema, simplified map[*Schema]*Schema) *Schema {
+	result := &Schema{}
+	*result = *schema
+	simplified[result] = result
+	return result
+}
+
+// mergeSchemaAnnotations returns the single allOf member of schema, which has no
+// other constraints, with the annotations of schema. Nullability is the schema's,
+// as null values aren't validated against allOf members.
+func mergeSchemaAnnotations(schema *Schema, member *Schema) *Schema {
+	result := &Schema{}
+	*result = *member
+	result.Nullable = schema.Nullable
+	result.ReadOnly = schema.ReadOnly || member.ReadOnly
+	result.WriteOnly = schema.WriteOnly || member.WriteOnly
+	result.Deprecated = schema.Deprecated || member.Deprecated
+	if schema.Title != "" {
+		result.Title = schema.Title
+	}
+	if schema.Description != "" {
+		result.Description = schema.Description
+	}
+	if schema.Default != nil {
+		result.Default = schema.Default
+	}
+	if schema.Example != nil {
+		result.Example = schema.Example
+	}
+	if len(schema.Examples) != 0 {
+		result.Examples = schema.Examples
+	}
+	if schema.ExternalDocs != nil {
+		result.ExternalDocs = schema.ExternalDocs
+	}
+	if schema.XML != nil {
+		result.XML = schema.XML
+	}
+	if len(schema.Extensions) != 0 {
+		extensions := make(map[string]interface{}, len(member.Extensions)+len(schema.Extensions))
+		for name, value := range member.Extensions {
+			extensions[name] = value
+		}
+		for name, value := range schema.Extensions {
+			extensions[name] = value
+		}
+		result.Extensions = extensions
+	}
+	return result
+}
+
+// constrains returns whether the schema constrains the non-null values it accepts,
+// unlike a schema with annotations like a description only.
+func (schema *Schema) constrains() bool {
+	return schema.Type != "" || schema.Format != "" || len(schema.Enum) != 0 ||
+		schema.Not != nil || len(schema.OneOf) != 0 || len(schema.AnyOf) != 0 || len(schema.AllOf) != 0 ||
+		schema.If != nil || schema.Discriminator != nil ||
+		schema.Min != nil || schema.Max != nil || schema.MultipleOf != nil ||
+		schema.MinLength != 0 || schema.MaxLength != nil || schema.Pattern != "" ||
+		schema.ContentMediaType != "" || schema.ContentEncoding != "" ||
+		schema.MinItems != 0 || schema.MaxItems != nil || schema.UniqueItems ||
+		schema.Items != nil || len(schema.PrefixItems) != 0 ||
+		len(schema.Required) != 0 || len(schema.Properties) != 0 ||
+		schema.MinProps != 0 || schema.MaxProps != nil || len(schema.DependentRequired) != 0 ||
+		schema.AdditionalProperties != nil || schema.AdditionalPropertiesAllowed != nil
+}
+
+// removeConstraintsOfOtherTypes removes the constraints which don't apply to
+// values of the schema's type, as values of other types are rejected anyway. The
+// content keywords are kept, as Validate rejects them for other types.
+func (schema *Schema) removeConstraintsOfOtherTypes() {
+	if schema.Type == "" {
+		return
+	}
+	if schema.Type != "number" && schema.Type != "integer" {
+		schema.Min, schema.Max, schema.MultipleOf = nil, nil, nil
+		schema.ExclusiveMin, schema.ExclusiveMax = false, false
+	}
+	if schema.Type != "string" {
+		schema.MinLength, schema.MaxLength, schema.Pattern = 0, nil, ""
+	}
+	if schema.Type != "array" {
+		schema.MinItems, schema.MaxItems, schema.UniqueItems = 0, nil, false
+		schema.Items, schema.PrefixItems = nil, nil
+	}
+	if schema.Type != "object" {
+		schema.Required, schema.Properties, schema.DependentRequired = nil, nil, nil
+		schema.MinProps, schema.MaxProps = 0, nil
+		schema.AdditionalProperties, schema.AdditionalPropertiesAllowed = nil, nil
+	}
+}
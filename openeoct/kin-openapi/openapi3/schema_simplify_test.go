@@ -0,0 +1,84 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaSimplify(t *testing.T) {
+	values := []interface{}{
+		nil, true, 0.0, 1.5, 3.0, -2.0, "", "a", "abcdef", "2020-01-01",
+		[]interface{}{}, []interface{}{1.0, 1.0}, []interface{}{"a"},
+		map[string]interface{}{}, map[string]interface{}{"id": "j-1"}, map[string]interface{}{"id": 1.0, "title": "t"},
+	}
+	tests := []struct {
+		name       string
+		schema     string
+		simplified string
+	}{
+		{
+			"single allOf member",
+			`{"description": "A job", "nullable": true, "allOf": [{"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}}]}`,
+			`{"description": "A job", "nullable": true, "type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}}`,
+		},
+		{
+			"allOf member type",
+			`{"type": "object", "allOf": [{"type": "object", "required": ["id"]}, {"type": "object"}, {"description": "none"}]}`,
+			`{"type": "object", "allOf": [{"required": ["id"]}]}`,
+		},
+		{
+			"nested single allOf members",
+			`{"allOf": [{"allOf": [{"type": "string", "minLength": 2}]}]}`,
+			`{"type": "string", "minLength": 2}`,
+		},
+		{
+			"constraints of other types",
+			`{"type": "integer", "minimum": 0, "minLength": 3, "pattern": "^a", "maxItems": 1, "required": ["id"]}`,
+			`{"type": "integer", "minimum": 0}`,
+		},
+		{
+			"bounds and required",
+			`{"type": "object", "required": ["id", "id"], "properties": {"id": {"type": "string", "exclusiveMaximum": true}}}`,
+			`{"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}}`,
+		},
+		{
+			"kept constraints",
+			`{"type": "array", "items": {"type": "number"}, "uniqueItems": true, "allOf": [{"maxItems": 1}, {"minItems": 1}]}`,
+			`{"type": "array", "items": {"type": "number"}, "uniqueItems": true, "allOf": [{"maxItems": 1}, {"minItems": 1}]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema := NewSchema()
+			require.NoError(t, json.Unmarshal([]byte(test.schema), schema))
+			original, err := json.Marshal(schema)
+			require.NoError(t, err)
+
+			simplified := schema.Simplify()
+			data, err := json.Marshal(simplified)
+			require.NoError(t, err)
+			require.JSONEq(t, test.simplified, string(data))
+			for _, value := range values {
+				require.Equal(t, schema.VisitJSON(value) == nil, simplified.VisitJSON(value) == nil, "%#v", value)
+			}
+
+			data, err = json.Marshal(schema)
+			require.NoError(t, err)
+			require.JSONEq(t, string(original), string(data))
+		})
+	}
+}
+
+func TestSchemaSimplifyKeepsReferences(t *testing.T) {
+	shared := &Schema{AllOf: []*SchemaRef{{Value: NewStringSchema()}}}
+	schema := &Schema{
+		Description: "ref",
+		AllOf:       []*SchemaRef{{Ref: "#/components/schemas/Shared", Value: shared}},
+	}
+	simplified := schema.Simplify()
+	require.Len(t, simplified.AllOf, 1)
+	require.Equal(t, "#/components/schemas/Shared", simplified.AllOf[0].Ref)
+	require.Same(t, shared, simplified.AllOf[0].Value)
+}
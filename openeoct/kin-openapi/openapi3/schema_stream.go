@@ -0,0 +1,411 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// VisitJSONArrayStream validates the JSON array read from r against the array
+// schema, decoding and validating one item at a time instead of the whole array.
+// Reading stops at the first invalid item and, if the schema declares maxItems,
+// as soon as the array has more items, so huge arrays aren't read completely.
+// minItems is validated at the end of the array. Keywords constraining the array
+// as a whole, e.g. uniqueItems or oneOf, are validated against all items at the
+// end, the items aren't validated against items and prefixItems again.
+func (schema *Schema) VisitJSONArrayStream(r io.Reader, opts ...SchemaValidationOption) error {
+	settings := newSchemaValidationSettings(opts...)
+	items, err := schema.visitJSONArrayStream(settings, json.NewDecoder(r))
+	if err == nil && items != nil {
+		err = schema.visitJSONArrayWhole(settings, items)
+	}
+	return settings.result(err)
+}
+
+// visitJSONArrayWhole validates the keywords of the schema constraining the
+// streamed array as a whole.
+func (schema *Schema) visitJSONArrayWhole(settings *schemaValidationSettings, items []interface{}) error {
+	if err := settings.enter(); err != nil {
+		return err
+	}
+	defer settings.leave()
+
+	if err := schema.visitSetOperations(settings, items); err != nil {
+		return err
+	}
+	return schema.visitJSONArrayUniqueItems(settings, items)
+}
+
+// visitJSONArrayStream validates the items of the array decoded by dec, returning
+// them if the array must also be validated as a whole.
+func (schema *Schema) visitJSONArrayStream(settings *schemaValidationSettings, dec *json.Decoder) (items []interface{}, err error) {
+	if err = settings.enter(); err != nil {
+		return
+	}
+	defer settings.leave()
+
+	if schemaType := schema.Type; schemaType != "" && schemaType != "array" {
+		return nil, schema.expectedType(settings, "array")
+	}
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('[') {
+		if settings.failfast {
+			return nil, errSchema
+		}
+		return nil, &SchemaError{
+			Value:       token,
+			Schema:      schema,
+			SchemaField: "type",
+			Reason:      "Streamed JSON value must be an array",
+		}
+	}
+
+	whole := schema.UniqueItems || len(schema.Enum) != 0 || len(schema.AllOf) != 0 || len(schema.AnyOf) != 0 ||
+		len(schema.OneOf) != 0 || schema.Not != nil || schema.If != nil
+	if whole {
+		items = make([]interface{}, 0)
+	}
+	count := 0
+	for dec.More() {
+		// "maxItems", before reading further items
+		if v := schema.MaxItems; v != nil && int64(count) >= int64(*v) {
+			if settings.failfast {
+				return nil, errSchema
+			}
+			return nil, &SchemaError{
+				Value:       count + 1,
+				Schema:      schema,
+				SchemaField: "maxItems",
+				Reason:      fmt.Sprintf("Maximum number of items is %d, exceeded while streaming the array", *v),
+			}
+		}
+		var item interface{}
+		if err = dec.Decode(&item); err != nil {
+			return nil, err
+		}
+
+		// "prefixItems" and "items"
+		itemSchemaRef := schema.Items
+		if count < len(schema.PrefixItems) {
+			itemSchemaRef = schema.PrefixItems[count]
+		}
+		if itemSchemaRef != nil {
+			itemSchema := itemSchemaRef.Value
+			if itemSchema == nil {
+				return nil, foundUnresolvedRef(itemSchemaRef.Ref)
+			}
+			if err = itemSchema.visitJSON(settings, item); err != nil {
+				return nil, markSchemaErrorIndex(err, count)
+			}
+		}
+		if whole {
+			items = append(items, item)
+		}
+		count++
+	}
+	if _, err = dec.Token(); err != nil {
+		return nil, err
+	}
+
+	// "minItems", once the whole array is read
+	if v := schema.MinItems; v != 0 && int64(count) < int64(v) {
+		if settings.failfast {
+			return nil, errSchema
+		}
+		return nil, &SchemaError{
+			Value:       count,
+			Schema:      schema,
+			SchemaField: "minItems",
+			Reason:      fmt.Sprintf("Minimum number of items is %d", v),
+		}
+	}
+	return items, nil
+}
+
+// StreamError is the first error of a JSON value validated by VisitJSONStream,
+// with the approximate byte offset in the stream and the JSON pointer of the
+// value which has it.
+type StreamError struct {
+	Offset  int64
+	Pointer string
+	Err     error
+}
+
+func (err *StreamError) Error() string {
+	return fmt.Sprintf("Error at byte %d (%q): %v", err.Offset, err.Pointer, err.Err)
+}
+
+// Unwrap returns the underlying error, e.g. a *SchemaError or *json.SyntaxError.
+func (err *StreamError) Unwrap() error {
+	return err.Err
+}
+
+// VisitJSONStream validates the JSON value read from r against the schema
+// while decoding it, without reading the whole value into memory. Arrays are
+// validated item by item and objects property by property, only the property
+// names of an object are kept to validate required, minProperties and
+// maxProperties once the object is read. Subtrees whose schema constrains them
+// as a whole, e.g. with enum, oneOf or uniqueItems, are decoded and then
+// validated. Reading stops at the first error, which is a *StreamError.
+func (schema *Schema) VisitJSONStream(r io.Reader, opts ...SchemaValidationOption) error {
+	settings := newSchemaValidationSettings(opts...)
+	stream := &jsonStream{settings: settings, dec: json.NewDecoder(r)}
+	err := stream.value(schema)
+	if err, ok := err.(*StreamError); ok {
+		err.Err = settings.result(err.Err)
+		return err
+	}
+	return settings.result(err)
+}
+
+// jsonStream validates the values of a JSON stream, tracking the path to the
+// current value.
+type jsonStream struct {
+	settings *schemaValidationSettings
+	dec      *json.Decoder
+	path     []string
+}
+
+// streamable returns whether values of the schema can be validated while
+// they're decoded, i.e. the schema has no keywords constraining them as a whole.
+func (schema *Schema) streamable() bool {
+	return len(schema.Enum) == 0 && len(schema.AllOf) == 0 && len(schema.AnyOf) == 0 && len(schema.OneOf) == 0 &&
+		schema.Not == nil && schema.If == nil && schema.Discriminator == nil && !schema.UniqueItems &&
+		len(schema.DependentRequired) == 0
+}
+
+// fail returns err as *StreamError of the current value at offset, adding the
+// path of the value to schema errors.
+func (stream *jsonStream) fail(offset int64, err error) error {
+	if err == errSchema {
+		return err
+	}
+	if _, ok := err.(*StreamError); ok {
+		return err
+	}
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		offset = syntaxErr.Offset
+	}
+	tokens := append([]string(nil), stream.path...)
+	if schemaErr, ok := err.(*SchemaError); ok {
+		tokens = append(tokens, schemaErr.JSONPointer()...)
+		for i := len(stream.path) - 1; i >= 0; i-- {
+			markSchemaErrorKey(schemaErr, stream.path[i])
+		}
+	}
+	pointer := ""
+	for _, token := range tokens {
+		pointer += "/" + escapeJSONPointerToken(token)
+	}
+	return &StreamError{Offset: offset, Pointer: pointer, Err: err}
+}
+
+// value validates the next value of the stream against the schema, nil
+// schemas allow any value.
+func (stream *jsonStream) value(schema *Schema) (err error) {
+	settings := stream.settings
+	if err = settings.enter(); err != nil {
+		return stream.fail(stream.dec.InputOffset(), err)
+	}
+	defer settings.leave()
+
+	offset := stream.dec.InputOffset()
+	if schema != nil && schema.IsEmpty() {
+		schema = nil
+	}
+	if schema != nil && !schema.streamable() {
+		var value interface{}
+		if err = stream.dec.Decode(&value); err != nil {
+			return stream.fail(offset, err)
+		}
+		if err = schema.visitJSON(settings, value); err != nil {
+			return stream.fail(offset, err)
+		}
+		return nil
+	}
+
+	token, err := stream.dec.Token()
+	if err != nil {
+		return stream.fail(offset, err)
+	}
+	switch token {
+	case json.Delim('{'):
+		return stream.object(schema, offset)
+	case json.Delim('['):
+		return stream.array(schema, offset)
+	}
+	if schema != nil {
+		if err = schema.visitJSON(settings, token); err != nil {
+			return stream.fail(offset, err)
+		}
+	}
+	return nil
+}
+
+// object validates the object of the stream, after its opening delimiter.
+func (stream *jsonStream) object(schema *Schema, offset int64) error {
+	settings := stream.settings
+	if schema != nil && schema.Type != "" && schema.Type != "object" {
+		return stream.fail(offset, schema.expectedType(settings, "object"))
+	}
+	names := make(map[string]bool)
+	for stream.dec.More() {
+		keyOffset := stream.dec.InputOffset()
+		token, err := stream.dec.Token()
+		if err != nil {
+			return stream.fail(keyOffset, err)
+		}
+		key, _ := token.(string)
+		names[key] = true
+
+		var property *Schema
+		if schema != nil {
+			// "maxProperties", before reading further properties
+			if v := schema.MaxProps; v != nil && int64(len(names)) > int64(*v) {
+				if settings.failfast {
+					return errSchema
+				}
+				return stream.fail(keyOffset, &SchemaError{
+					Value:       key,
+					Schema:      schema,
+					SchemaField: "maxProperties",
+					Reason:      fmt.Sprintf("object has more than %s, maxProperties is %d", countProperties(int64(*v)), *v),
+				})
+			}
+			if propertyRef := schema.Properties[key]; propertyRef != nil {
+				if property = propertyRef.Value; property == nil {
+					return stream.fail(keyOffset, foundUnresolvedRef(propertyRef.Ref))
+				}
+				if settings.coverage != nil {
+					settings.coverage.mark(schema, "properties/"+escapeJSONPointerToken(key))
+				}
+			} else if ref := schema.AdditionalProperties; ref != nil {
+				property = ref.Value
+			} else if allowed := schema.AdditionalPropertiesAllowed; allowed != nil && !*allowed {
+				if settings.failfast {
+					return errSchema
+				}
+				// The offset is the one of the property, so is the pointer
+				return stream.fail(keyOffset, markSchemaErrorKey(&SchemaError{
+					Value:       key,
+					Schema:      schema,
+					SchemaField: "properties",
+					Reason:      fmt.Sprintf("Property '%s' is unsupported", key),
+					params:      map[string]interface{}{"property": key},
+				}, key))
+			}
+		}
+		stream.path = append(stream.path, key)
+		err = stream.value(property)
+		stream.path = stream.path[:len(stream.path)-1]
+		if err != nil {
+			return err
+		}
+	}
+	endOffset := stream.dec.InputOffset()
+	if _, err := stream.dec.Token(); err != nil {
+		return stream.fail(endOffset, err)
+	}
+	if schema == nil {
+		return nil
+	}
+
+	// "required" and "minProperties", once the whole object is read
+	if !settings.requiredDisabled {
+		for _, k := range schema.Required {
+			if names[k] {
+				continue
+			}
+			if settings.failfast {
+				return errSchema
+			}
+			return stream.fail(endOffset, markSchemaErrorKey(&SchemaError{
+				Value:       sortedMapKeys(names),
+				Schema:      schema,
+				SchemaField: "required",
+				Reason:      fmt.Sprintf("Property '%s' is missing", k),
+				params:      map[string]interface{}{"property": k},
+			}, k))
+		}
+	}
+	if v := schema.MinProps; v != 0 && int64(len(names)) < int64(v) {
+		if settings.failfast {
+			return errSchema
+		}
+		return stream.fail(endOffset, &SchemaError{
+			Value:       sortedMapKeys(names),
+			Schema:      schema,
+			SchemaField: "minProperties",
+			Reason:      fmt.Sprintf("object has %s, minProperties is %d", countProperties(int64(len(names))), v),
+		})
+	}
+	return nil
+}
+
+// array validates the array of the stream, after its opening delimiter.
+func (stream *jsonStream) array(schema *Schema, offset int64) error {
+	settings := stream.settings
+	if schema != nil && schema.Type != "" && schema.Type != "array" {
+		return stream.fail(offset, schema.expectedType(settings, "array"))
+	}
+	count := 0
+	for stream.dec.More() {
+		itemOffset := stream.dec.InputOffset()
+		var item *Schema
+		if schema != nil {
+			// "maxItems", before reading further items
+			if v := schema.MaxItems; v != nil && int64(count) >= int64(*v) {
+				if settings.failfast {
+					return errSchema
+				}
+				return stream.fail(itemOffset, &SchemaError{
+					Value:       count + 1,
+					Schema:      schema,
+					SchemaField: "maxItems",
+					Reason:      fmt.Sprintf("Maximum number of items is %d, exceeded while streaming the array", *v),
+				})
+			}
+			// "prefixItems" and "items"
+			itemSchemaRef := schema.Items
+			if count < len(schema.PrefixItems) {
+				itemSchemaRef = schema.PrefixItems[count]
+			}
+			if itemSchemaRef != nil {
+				if item = itemSchemaRef.Value; item == nil {
+					return stream.fail(itemOffset, foundUnresolvedRef(itemSchemaRef.Ref))
+				}
+			}
+		}
+		stream.path = append(stream.path, strconv.Itoa(count))
+		err := stream.value(item)
+		stream.path = stream.path[:len(stream.path)-1]
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	endOffset := stream.dec.InputOffset()
+	if _, err := stream.dec.Token(); err != nil {
+		return stream.fail(endOffset, err)
+	}
+
+	// "minItems", once the whole array is read
+	if schema != nil {
+		if v := schema.MinItems; v != 0 && int64(count) < int64(v) {
+			if settings.failfast {
+				return errSchema
+			}
+			return stream.fail(endOffset, &SchemaError{
+				Value:       count,
+				Schema:      schema,
+				SchemaField: "minItems",
+				Reason:      fmt.Sprintf("Minimum number of items is %d", v),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// endlessArray is an endless JSON array of numbers.
+type endlessArray struct {
+	started bool
+	read    int
+}
+
+func (r *endlessArray) Read(p []byte) (int, error) {
+	n := 0
+	if !r.started {
+		p[0] = '['
+		r.started = true
+		n = 1
+	}
+	for ; n+1 < len(p); n += 2 {
+		p[n], p[n+1] = '1', ','
+	}
+	r.read += n
+	return n, nil
+}
+
+func TestVisitJSONArrayStream(t *testing.T) {
+	schema := NewArraySchema().WithItems(NewIntegerSchema()).WithMinItems(2).WithMaxItems(3)
+
+	require.NoError(t, schema.VisitJSONArrayStream(strings.NewReader(`[1, 2]`)))
+	require.NoError(t, schema.VisitJSONArrayStream(strings.NewReader(`[1, 2, 3]`)))
+
+	err := schema.VisitJSONArrayStream(strings.NewReader(`[1]`))
+	require.IsType(t, &SchemaError{}, err)
+	require.Equal(t, "minItems", err.(*SchemaError).SchemaField)
+
+	err = schema.VisitJSONArrayStream(strings.NewReader(`[1, "2", 3]`))
+	require.IsType(t, &SchemaError{}, err)
+	require.Equal(t, []string{"1"}, err.(*SchemaError).JSONPointer())
+
+	err = schema.VisitJSONArrayStream(strings.NewReader(`{}`))
+	require.IsType(t, &SchemaError{}, err)
+	require.Equal(t, "type", err.(*SchemaError).SchemaField)
+
+	err = schema.VisitJSONArrayStream(strings.NewReader(`[1, 2`))
+	require.IsType(t, &json.SyntaxError{}, err)
+}
+
+func TestVisitJSONArrayStreamMaxItems(t *testing.T) {
+	schema := NewArraySchema().WithItems(NewIntegerSchema()).WithMaxItems(3)
+
+	// The stream is only read until the limit is exceeded
+	r := &endlessArray{}
+	err := schema.VisitJSONArrayStream(r)
+	require.IsType(t, &SchemaError{}, err)
+	require.Equal(t, "maxItems", err.(*SchemaError).SchemaField)
+	require.Equal(t, "Maximum number of items is 3, exceeded while streaming the array", err.(*SchemaError).Reason)
+	require.Less(t, r.read, 10000)
+
+	require.Equal(t, errSchema, schema.VisitJSONArrayStream(&endlessArray{}, FailFast()))
+}
+
+func TestVisitJSONArrayStreamWholeArray(t *testing.T) {
+	schema := NewArraySchema().WithItems(NewIntegerSchema()).WithUniqueItems(true)
+	require.NoError(t, schema.VisitJSONArrayStream(strings.NewReader(`[1, 2, 3]`)))
+
+	err := schema.VisitJSONArrayStream(strings.NewReader(`[1, 2, 1]`))
+	require.IsType(t, &SchemaError{}, err)
+	require.Equal(t, "uniqueItems", err.(*SchemaError).SchemaField)
+}
+
+func TestVisitJSONArrayStreamWholeArrayFormatWarnings(t *testing.T) {
+	DefineFormatSeverity("email", FormatSeverityWarning)
+	defer DefineFormatSeverity("email", FormatSeverityError)
+
+	// The items are validated once, while streaming
+	schema := NewArraySchema().WithItems(NewStringSchema().WithFormat("email")).WithUniqueItems(true)
+	warnings := 0
+	countWarnings := FormatWarnings(func(err *SchemaError) { warnings++ })
+	require.NoError(t, schema.VisitJSONArrayStream(strings.NewReader(`["nobody"]`), countWarnings))
+	require.Equal(t, 1, warnings)
+
+	warnings = 0
+	require.NoError(t, schema.VisitJSONStream(strings.NewReader(`["nobody"]`), countWarnings))
+	require.Equal(t, 1, warnings)
+}
+
+func TestVisitJSONStream(t *testing.T) {
+	node := NewObjectSchema().
+		WithProperty("process_id", NewStringSchema()).
+		WithPropertyRef("arguments", NewObjectSchema().WithAnyAdditionalProperties().NewRef()).
+		WithProperty("result", NewBoolSchema())
+	node.Required = []string{"process_id", "arguments"}
+	schema := NewObjectSchema().
+		WithProperty("title", NewStringSchema().WithMaxLength(10)).
+		WithProperty("process", NewObjectSchema().WithProperty("process_graph", NewObjectSchema().WithAdditionalProperties(node))).
+		WithProperty("tags", NewArraySchema().WithItems(NewStringSchema().WithEnum("eo", "sar")).WithMaxItems(2))
+	schema.AdditionalPropertiesAllowed = BoolPtr(false)
+
+	valid := `{"title": "NDVI", "process": {"process_graph": {
+  "load": {"process_id": "load_collection", "arguments": {"id": "S2"}},
+  "save": {"process_id": "save_result", "arguments": {"data": {"from_node": "load"}}, "result": true}
+}}, "tags": ["eo"]}`
+	require.NoError(t, schema.VisitJSONStream(strings.NewReader(valid)))
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(valid), &value))
+	require.NoError(t, schema.VisitJSON(value))
+
+	streamError := func(document string) *StreamError {
+		err := schema.VisitJSONStream(strings.NewReader(document))
+		require.IsType(t, &StreamError{}, err, document)
+		return err.(*StreamError)
+	}
+
+	document := `{"title": "NDVI", "process": {"process_graph": {"load": {"process_id": 1, "arguments": {}}}}}`
+	err := streamError(document)
+	require.Equal(t, "/process/process_graph/load/process_id", err.Pointer)
+	// The offset is the end of the preceding token
+	require.Equal(t, int64(strings.Index(document, ": 1,")), err.Offset)
+	require.IsType(t, &SchemaError{}, err.Err)
+	require.Equal(t, []string{"process", "process_graph", "load", "process_id"}, err.Err.(*SchemaError).JSONPointer())
+	require.Contains(t, err.Error(), `Error at byte `)
+
+	// Required properties are known once the object is read
+	document = `{"process": {"process_graph": {"load": {"process_id": "load_collection"}, "save": {}}}}`
+	err = streamError(document)
+	require.Equal(t, "/process/process_graph/load/arguments", err.Pointer)
+	require.Equal(t, int64(strings.Index(document, `}, "save"`)), err.Offset)
+	require.Equal(t, "required", err.Err.(*SchemaError).SchemaField)
+
+	err = streamError(`{"title": "NDVI", "plan": "free"}`)
+	require.Equal(t, "/plan", err.Pointer)
+	require.Equal(t, "Property 'plan' is unsupported", err.Err.(*SchemaError).Reason)
+
+	// Subtrees with enum are validated as a whole
+	err = streamError(`{"tags": ["eo", "radar"]}`)
+	require.Equal(t, "/tags/1", err.Pointer)
+	require.Equal(t, "enum", err.Err.(*SchemaError).SchemaField)
+
+	err = streamError(`{"tags": ["eo", "sar", "eo"]}`)
+	require.Equal(t, "/tags", err.Pointer)
+	require.Equal(t, "maxItems", err.Err.(*SchemaError).SchemaField)
+
+	err = streamError(`{"title": "NDVI", "process": {"process_graph": {"load" 1}}}`)
+	require.IsType(t, &json.SyntaxError{}, err.Err)
+	require.Equal(t, "/process/process_graph/load", err.Pointer)
+
+	// Reading stops at the first error
+	reader := &endlessArray{}
+	endless := NewObjectSchema().WithProperty("values", NewArraySchema().WithItems(NewIntegerSchema().WithMax(0)))
+	endlessErr := endless.VisitJSONStream(io.MultiReader(strings.NewReader(`{"values": `), reader))
+	require.IsType(t, &StreamError{}, endlessErr)
+	require.Equal(t, "/values/0", endlessErr.(*StreamError).Pointer)
+	require.Less(t, reader.read, 1<<20)
+}
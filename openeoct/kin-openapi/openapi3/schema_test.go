@@ -341,6 +341,29 @@ var schemaExamples = []schemaExample{
 		},
 	},
 
+	{
+		Title: "STRING: base64 encoded JSON content",
+		Schema: &openapi3.Schema{
+			Type:             "string",
+			ContentEncoding:  "base64",
+			ContentMediaType: "application/geo+json",
+		},
+		Serialization: map[string]interface{}{
+			"type":             "string",
+			"contentEncoding":  "base64",
+			"contentMediaType": "application/geo+json",
+		},
+		AllValid: []interface{}{
+			"e30=",                     // {}
+			"eyJ0eXBlIjogIlBvaW50In0=", // {"type": "Point"}
+		},
+		AllInvalid: []interface{}{
+			"e30",      // bad padding
+			"e30=!",    // not base64
+			"bm90IGpz", // "not js"
+		},
+	},
+
 	{
 		Title: "ARRAY",
 		Schema: &openapi3.Schema{
@@ -381,6 +404,35 @@ var schemaExamples = []schemaExample{
 			},
 		},
 	},
+	{
+		Title: "ARRAY : prefixItems",
+		Schema: &openapi3.Schema{
+			Type:        "array",
+			PrefixItems: []*openapi3.SchemaRef{openapi3.NewFloat64Schema().NewRef(), openapi3.NewStringSchema().NewRef()},
+			Items:       openapi3.NewBoolSchema().NewRef(),
+		},
+		Serialization: map[string]interface{}{
+			"type": "array",
+			"prefixItems": []interface{}{
+				map[string]interface{}{"type": "number"},
+				map[string]interface{}{"type": "string"},
+			},
+			"items": map[string]interface{}{
+				"type": "boolean",
+			},
+		},
+		AllValid: []interface{}{
+			[]interface{}{},
+			[]interface{}{4.5},
+			[]interface{}{4.5, "EPSG:4326"},
+			[]interface{}{4.5, "EPSG:4326", true, false},
+		},
+		AllInvalid: []interface{}{
+			[]interface{}{"EPSG:4326"},
+			[]interface{}{4.5, 51.2},
+			[]interface{}{4.5, "EPSG:4326", "extra"},
+		},
+	},
 	{
 		Title: "ARRAY : items format 'object'",
 		Schema: &openapi3.Schema{
@@ -1071,3 +1123,298 @@ func TestRegisterArrayUniqueItemsChecker(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.HasPrefix(err.Error(), "Duplicate items found"))
 }
+
+func TestPrefixItemsErrorIndex(t *testing.T) {
+	schema := openapi3.NewArraySchema().WithPrefixItems(openapi3.NewFloat64Schema(), openapi3.NewFloat64Schema())
+	err := schema.VisitJSON([]interface{}{4.5, "51.2"})
+	require.Error(t, err)
+	schemaErr, ok := err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, []string{"1"}, schemaErr.JSONPointer())
+}
+
+func TestDisableFormatValidation(t *testing.T) {
+	schema := openapi3.NewStringSchema().WithFormat("date").WithMaxLength(10)
+	require.Error(t, schema.VisitJSON("2020-W01"))
+	require.NoError(t, schema.VisitJSON("2020-W01", openapi3.DisableFormatValidation()))
+	require.Error(t, schema.VisitJSON("2020-W01-01-01", openapi3.DisableFormatValidation()))
+
+	// An explicit pattern is still enforced
+	schema.WithPattern("^[0-9-]+$")
+	require.Error(t, schema.VisitJSON("2020-W01", openapi3.DisableFormatValidation()))
+}
+
+func TestObjectPropertiesCountErrors(t *testing.T) {
+	schema := openapi3.NewObjectSchema().WithMinProperties(2).WithMaxProperties(3)
+	schema.AdditionalPropertiesAllowed = openapi3.BoolPtr(true)
+
+	err := schema.VisitJSON(map[string]interface{}{"a": 1.0})
+	require.IsType(t, &openapi3.SchemaError{}, err)
+	require.Equal(t, "object has 1 property, minProperties is 2", err.(*openapi3.SchemaError).Reason)
+	err = schema.VisitJSON(map[string]interface{}{"a": 1.0, "b": 2.0, "c": 3.0, "d": 4.0})
+	require.IsType(t, &openapi3.SchemaError{}, err)
+	require.Equal(t, "object has 4 properties, maxProperties is 3", err.(*openapi3.SchemaError).Reason)
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"a": 1.0, "b": 2.0}))
+}
+
+func TestMaxValidationDepth(t *testing.T) {
+	// A process graph like schema: a node is null or an object with a nested node
+	node := &openapi3.Schema{Nullable: true}
+	object := openapi3.NewObjectSchema().WithProperty("node", node)
+	node.AnyOf = []*openapi3.SchemaRef{{Value: object}}
+	nested := func(depth int) interface{} {
+		var value interface{}
+		for i := 0; i < depth; i++ {
+			value = map[string]interface{}{"node": value}
+		}
+		return value
+	}
+
+	require.NoError(t, node.VisitJSON(nested(100)))
+	require.NoError(t, node.VisitJSON(nested(100), openapi3.MaxValidationDepth(0)))
+
+	err := node.VisitJSON(nested(100), openapi3.MaxValidationDepth(50))
+	require.EqualError(t, err, "value validation exceeded the maximum depth of 50 nested schemas")
+	require.IsType(t, &openapi3.MaxValidationDepthError{}, err)
+
+	err = node.VisitJSON(nested(1000))
+	require.IsType(t, &openapi3.MaxValidationDepthError{}, err)
+
+	// The error of a cyclic schema refers to the enclosing schema instead
+	err = node.VisitJSON(map[string]interface{}{"node": map[string]interface{}{"node": 1.0}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"$ref": "#"`)
+}
+
+func TestDependentRequired(t *testing.T) {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("west", openapi3.NewFloat64Schema()).
+		WithProperty("east", openapi3.NewFloat64Schema()).
+		WithProperty("crs", openapi3.NewStringSchema()).
+		WithDependentRequired("west", "east", "crs")
+	require.NoError(t, schema.Validate(context.Background()))
+
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{}))
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"east": 1.0}))
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"west": 0.0, "east": 1.0, "crs": "EPSG:4326"}))
+
+	err := schema.VisitJSON(map[string]interface{}{"west": 0.0, "east": 1.0})
+	require.Error(t, err)
+	schemaErr, ok := err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "dependentRequired", schemaErr.SchemaField)
+	require.Equal(t, "Property 'crs' is missing, it is required when 'west' is present", schemaErr.Reason)
+	require.Equal(t, []string{"crs"}, schemaErr.JSONPointer())
+
+	schema.WithDependentRequired("west", "east", "east")
+	require.EqualError(t, schema.Validate(context.Background()),
+		"Schema 'dependentRequired' of property 'west' lists 'east' more than once")
+}
+
+func TestDisableRequiredValidation(t *testing.T) {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("process", openapi3.NewObjectSchema().
+			WithProperty("process_graph", openapi3.NewObjectSchema()).
+			WithProperty("summary", openapi3.NewStringSchema()))
+	schema.Required = []string{"process"}
+	schema.Properties["process"].Value.Required = []string{"process_graph"}
+
+	partial := map[string]interface{}{"process": map[string]interface{}{"summary": "NDVI"}}
+	require.Error(t, schema.VisitJSON(partial))
+	require.NoError(t, schema.VisitJSON(partial, openapi3.DisableRequiredValidation()))
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{}, openapi3.DisableRequiredValidation()))
+	require.Error(t, schema.VisitJSON(map[string]interface{}{"title": 1.0}, openapi3.DisableRequiredValidation()))
+}
+
+func TestEnumValuesMatchFormat(t *testing.T) {
+	schema := openapi3.NewStringSchema().WithFormat("date").WithEnum("2020-01-01", "2021-01-01")
+	require.NoError(t, schema.Validate(context.Background()))
+
+	schema.WithEnum("2020-01-01", "yesterday")
+	require.EqualError(t, schema.Validate(context.Background()), "Schema 'enum' value 'yesterday' doesn't match the format 'date'")
+
+	// Formats without a pattern aren't checked
+	schema = openapi3.NewStringSchema().WithFormat("uri").WithEnum("not a uri")
+	require.NoError(t, schema.Validate(context.Background()))
+}
+
+func TestTemporalFormats(t *testing.T) {
+	valid := map[string][]string{
+		"date":      {"2020-02-29", "2021-12-31"},
+		"time":      {"12:00:00", "12:00:00.5Z", "23:59:60Z", "00:59:60+01:00", "18:29:60-05:30"},
+		"date-time": {"2016-12-31T23:59:60Z", "2020-02-29t12:00:00+14:00", "2020-01-01T00:00:00"},
+		"duration":  {"P1Y2M3D", "PT12H", "P1DT0.5S", "P2W", "PT1M"},
+	}
+	invalid := map[string][]string{
+		"date":      {"2021-02-29", "2020-13-01", "2020-04-31", "2020-00-10", "20-01-01"},
+		"time":      {"24:00:00", "12:60:00", "12:00:60Z", "12:00:00+24:00", "12:00:00+01:60", "12:00"},
+		"date-time": {"2020-02-30T12:00:00Z", "2020-01-01 12:00:00Z", "2020-01-01T23:59:60+01:00"},
+		"duration":  {"P", "PT", "P1DT", "P1W2D", "1D", "P1.5D", "PT1H2S3M"},
+	}
+	for format, values := range valid {
+		schema := openapi3.NewStringSchema().WithFormat(format)
+		for _, value := range values {
+			require.NoError(t, schema.VisitJSON(value), "%s %q", format, value)
+		}
+	}
+	for format, values := range invalid {
+		schema := openapi3.NewStringSchema().WithFormat(format)
+		for _, value := range values {
+			require.Error(t, schema.VisitJSON(value), "%s %q", format, value)
+		}
+	}
+
+	err := openapi3.NewStringSchema().WithFormat("date").VisitJSON("2021-02-29")
+	require.IsType(t, &openapi3.SchemaError{}, err)
+	require.Equal(t, "format", err.(*openapi3.SchemaError).SchemaField)
+	require.Equal(t, "JSON string doesn't match the format 'date': day 29 is out of range for February 2021", err.(*openapi3.SchemaError).Reason)
+	err = openapi3.NewStringSchema().WithFormat("time").VisitJSON("12:00:60Z")
+	require.Contains(t, err.Error(), `leap second 12:00:60Z isn't at 23:59:60 UTC`)
+	require.Error(t, openapi3.NewStringSchema().WithFormat("duration").VisitJSON("P", openapi3.FailFast()))
+
+	// Like formats defined by a pattern, the pattern of the schema replaces the format
+	schema := openapi3.NewStringSchema().WithFormat("date-time").WithPattern(`^\d{4}-\d{2}-\d{2}`)
+	require.NoError(t, schema.VisitJSON("2020-02-30"))
+	require.Error(t, schema.VisitJSON("yesterday"))
+	require.NoError(t, openapi3.NewStringSchema().WithFormat("email").WithPattern("^nobody$").VisitJSON("nobody"))
+
+	schema = openapi3.NewStringSchema().WithFormat("duration").WithEnum("P1D", "P1X")
+	require.EqualError(t, schema.Validate(context.Background()), "Schema 'enum' value 'P1X' doesn't match the format 'duration'")
+}
+
+func TestFormatSeverities(t *testing.T) {
+	openapi3.DefineStringFormat("uri", `^[a-z]+://`)
+	openapi3.DefineFormatSeverity("uri", openapi3.FormatSeverityWarning)
+	openapi3.DefineFormatSeverity("email", openapi3.FormatSeverityIgnore)
+	defer func() {
+		delete(openapi3.SchemaStringFormats, "uri")
+		openapi3.DefineFormatSeverity("uri", openapi3.FormatSeverityError)
+		openapi3.DefineFormatSeverity("email", openapi3.FormatSeverityError)
+	}()
+
+	schema := openapi3.NewObjectSchema().
+		WithProperty("href", openapi3.NewStringSchema().WithFormat("uri")).
+		WithProperty("email", openapi3.NewStringSchema().WithFormat("email")).
+		WithProperty("created", openapi3.NewStringSchema().WithFormat("date-time")).
+		WithProperty("alternate", openapi3.NewOneOfSchema(
+			openapi3.NewStringSchema().WithFormat("uri"),
+			openapi3.NewIntegerSchema(),
+		))
+	var warnings []string
+	warn := openapi3.FormatWarnings(func(err *openapi3.SchemaError) {
+		warnings = append(warnings, err.Reason)
+	})
+
+	value := map[string]interface{}{"href": "/jobs", "email": "nobody", "created": "2020-01-01T00:00:00Z", "alternate": "/jobs"}
+	require.NoError(t, schema.VisitJSON(value, warn))
+	require.NoError(t, schema.VisitJSON(value, openapi3.FailFast()))
+	require.Equal(t, []string{"JSON string doesn't match the format 'uri (regular expression `^[a-z]+://`)'"}, warnings)
+
+	value["created"] = "2020-01-01"
+	err := schema.VisitJSON(value, warn)
+	require.Error(t, err)
+	require.Equal(t, "format", err.(*openapi3.SchemaError).SchemaField)
+
+	openapi3.DefineFormatSeverity("int32", openapi3.FormatSeverityIgnore)
+	defer openapi3.DefineFormatSeverity("int32", openapi3.FormatSeverityError)
+	require.NoError(t, openapi3.NewInt32Schema().VisitJSON(float64(math.MaxInt64)))
+	require.Error(t, openapi3.NewInt64Schema().VisitJSON(math.Pow(2, 64)))
+
+	schema = openapi3.NewStringSchema().WithFormat("email").WithEnum("nobody")
+	require.NoError(t, schema.Validate(context.Background()))
+
+	_, err = openapi3.ParseFormatSeverity("fatal")
+	require.EqualError(t, err, `unknown format severity "fatal", expected error, warning or ignore`)
+	severity, err := openapi3.ParseFormatSeverity("warn")
+	require.NoError(t, err)
+	require.Equal(t, openapi3.FormatSeverityWarning, severity)
+}
+
+func TestDuplicateEnumMembers(t *testing.T) {
+	schema := openapi3.NewStringSchema().WithEnum("GTiff", "netCDF", "PNG")
+	require.NoError(t, schema.Validate(context.Background()))
+
+	schema.WithEnum("GTiff", "netCDF", "PNG", "netCDF")
+	require.EqualError(t, schema.Validate(context.Background()), `Schema 'enum' values at index 1 and 3 are equal: "netCDF"`)
+
+	schema = openapi3.NewObjectSchema().WithEnum(
+		map[string]interface{}{"method": "near", "resolution": 10.0},
+		map[string]interface{}{"method": "bilinear", "resolution": 10.0},
+		map[string]interface{}{"resolution": 10.0, "method": "near"},
+	)
+	require.EqualError(t, schema.Validate(context.Background()),
+		`Schema 'enum' values at index 0 and 2 are equal: {"method":"near","resolution":10}`)
+}
+
+func TestIfThenElse(t *testing.T) {
+	spec := `{
+  "type": "object",
+  "properties": {"format": {"type": "string"}, "options": {"type": "object"}, "tiled": {"type": "boolean"}},
+  "if": {"properties": {"format": {"enum": ["GTiff"]}}},
+  "then": {"required": ["options"]},
+  "else": {"not": {"required": ["tiled"]}}
+}`
+	var schema openapi3.Schema
+	require.NoError(t, json.Unmarshal([]byte(spec), &schema))
+	require.NoError(t, schema.Validate(context.Background()))
+
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"format": "GTiff", "options": map[string]interface{}{}}))
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"format": "netCDF"}))
+
+	err := schema.VisitJSON(map[string]interface{}{"format": "GTiff"})
+	require.Error(t, err)
+	schemaErr, ok := err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "then", schemaErr.SchemaField)
+	require.Equal(t, "Value matches the 'if' schema but doesn't match the 'then' schema", schemaErr.Reason)
+
+	err = schema.VisitJSON(map[string]interface{}{"format": "netCDF", "tiled": true})
+	require.Error(t, err)
+	schemaErr, ok = err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "else", schemaErr.SchemaField)
+
+	schema.Else = nil
+	require.NoError(t, schema.VisitJSON(map[string]interface{}{"format": "netCDF", "tiled": true}))
+}
+
+func TestIfThenElseRefs(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    gtiff: {properties: {format: {enum: [GTiff]}}}
+    options: {required: [options]}
+    output:
+      if: {$ref: '#/components/schemas/gtiff'}
+      then: {$ref: '#/components/schemas/options'}
+`
+	doc, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+	output := doc.Components.Schemas["output"].Value
+	require.Error(t, output.VisitJSON(map[string]interface{}{"format": "GTiff"}))
+}
+
+func TestIntegerFormatRanges(t *testing.T) {
+	schema := openapi3.NewInt32Schema()
+	require.NoError(t, schema.VisitJSON(float64(math.MaxInt32)))
+	require.NoError(t, schema.VisitJSON(float64(math.MinInt32)))
+	err := schema.VisitJSON(float64(math.MaxInt32 + 1))
+	require.IsType(t, &openapi3.SchemaError{}, err)
+	require.Equal(t, "format", err.(*openapi3.SchemaError).SchemaField)
+	require.Equal(t, "value exceeds int32 range", err.(*openapi3.SchemaError).Reason)
+	require.Error(t, schema.VisitJSON(float64(math.MinInt32-1)))
+	require.NoError(t, schema.VisitJSON(float64(math.MaxInt32+1), openapi3.DisableFormatValidation()))
+
+	schema = openapi3.NewInt64Schema()
+	require.NoError(t, schema.VisitJSON(float64(math.MaxInt32+1)))
+	require.NoError(t, schema.VisitJSON(float64(math.MinInt64)))
+	err = schema.VisitJSON(math.Pow(2, 63))
+	require.IsType(t, &openapi3.SchemaError{}, err)
+	require.Equal(t, "value exceeds int64 range", err.(*openapi3.SchemaError).Reason)
+	require.Error(t, schema.VisitJSON(-1e19))
+}
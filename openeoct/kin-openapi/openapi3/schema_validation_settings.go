@@ -0,0 +1,135 @@
+package openapi3
+
+import "fmt"
+
+// DefaultMaxValidationDepth is the maximum number of nested schema applications
+// allowed while validating a single value, unless set with MaxValidationDepth.
+const DefaultMaxValidationDepth = 1000
+
+// SchemaValidationOption describes options a user has when validating values against a schema.
+type SchemaValidationOption func(*schemaValidationSettings)
+
+type schemaValidationSettings struct {
+	failfast                 bool
+	formatValidationDisabled bool
+	requiredDisabled         bool
+	coverage                 *SchemaCoverage
+	formatWarnings           func(err *SchemaError)
+	maxDepth                 int
+	depth                    *validationDepth
+}
+
+// validationDepth tracks the nesting of a single value validation. It is shared
+// by the fail fast copies of the settings.
+type validationDepth struct {
+	current  int
+	exceeded bool
+}
+
+// MaxValidationDepthError is returned when validating a value applies schemas
+// nested deeper than the maximum validation depth, e.g. for pathologically deep
+// values of recursive schemas.
+type MaxValidationDepthError struct {
+	MaxDepth int
+}
+
+func (err *MaxValidationDepthError) Error() string {
+	return fmt.Sprintf("value validation exceeded the maximum depth of %d nested schemas", err.MaxDepth)
+}
+
+// FailFast returns schema validation errors quicker, without details.
+func FailFast() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.failfast = true }
+}
+
+// DisableFormatValidation skips all "format" checks of string values,
+// while still enforcing types and other constraints.
+func DisableFormatValidation() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.formatValidationDisabled = true }
+}
+
+// DisableRequiredValidation skips all "required" checks of object values, while
+// still validating present properties, e.g. for partial updates with PATCH semantics.
+func DisableRequiredValidation() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.requiredDisabled = true }
+}
+
+// FormatWarnings calls fn with the errors of values which don't have a format of
+// FormatSeverityWarning, see DefineFormatSeverity. Values of subschemas which
+// are only tried, e.g. of oneOf members, aren't reported.
+func FormatWarnings(fn func(err *SchemaError)) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.formatWarnings = fn }
+}
+
+// MaxValidationDepth limits the nesting of schemas applied while validating a value,
+// DefaultMaxValidationDepth by default. A depth of 0 disables the limit.
+func MaxValidationDepth(depth int) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.maxDepth = depth }
+}
+
+func newSchemaValidationSettings(opts ...SchemaValidationOption) *schemaValidationSettings {
+	settings := &schemaValidationSettings{
+		maxDepth: DefaultMaxValidationDepth,
+		depth:    &validationDepth{},
+	}
+	for _, opt := range opts {
+		opt(settings)
+	}
+	return settings
+}
+
+// failFast returns a copy of the settings which fails fast, used to try values
+// against subschemas. Coverage and format warnings aren't recorded for such trials.
+func (settings *schemaValidationSettings) failFast() *schemaValidationSettings {
+	if settings.failfast && settings.coverage == nil && settings.formatWarnings == nil {
+		return settings
+	}
+	failFastSettings := *settings
+	failFastSettings.failfast = true
+	failFastSettings.coverage = nil
+	failFastSettings.formatWarnings = nil
+	return &failFastSettings
+}
+
+// enter records applying a nested schema, failing once the maximum depth is exceeded.
+// Each successful call must be paired with a call to leave.
+func (settings *schemaValidationSettings) enter() error {
+	depth := settings.depth
+	if settings.maxDepth > 0 && depth.current >= settings.maxDepth {
+		depth.exceeded = true
+		return &MaxValidationDepthError{MaxDepth: settings.maxDepth}
+	}
+	depth.current++
+	return nil
+}
+
+func (settings *schemaValidationSettings) leave() {
+	settings.depth.current--
+}
+
+// result returns the error of a value validation, reporting an exceeded maximum
+// depth even if the validation replaced it by a mismatch.
+func (settings *schemaValidationSettings) result(err error) error {
+	if err != nil && settings.depth.exceeded {
+		return &MaxValidationDepthError{MaxDepth: settings.maxDepth}
+	}
+	return err
+}
+
+// formatMismatch applies the severity of the format to the error of a value
+// which doesn't have the format, returning nil unless it's an error.
+func (settings *schemaValidationSettings) formatMismatch(err *SchemaError) error {
+	switch SchemaFormatSeverities[err.Schema.Format] {
+	case FormatSeverityIgnore:
+		return nil
+	case FormatSeverityWarning:
+		if settings.formatWarnings != nil {
+			settings.formatWarnings(err)
+		}
+		return nil
+	}
+	if settings.failfast {
+		return errSchema
+	}
+	return err
+}
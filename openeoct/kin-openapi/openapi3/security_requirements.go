@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"context"
+	"fmt"
 )
 
 type SecurityRequirements []SecurityRequirement
@@ -38,6 +39,37 @@ func (security SecurityRequirement) Authenticate(provider string, scopes ...stri
 	return security
 }
 
+// Validate checks that only OAuth2 and OpenID Connect security schemes are
+// required with scopes, if the security schemes of the document are known.
 func (security SecurityRequirement) Validate(c context.Context) error {
+	schemes := getSecuritySchemes(c)
+	for _, name := range sortedMapKeys(security) {
+		ref := schemes[name]
+		if ref == nil || ref.Value == nil || len(security[name]) == 0 {
+			continue
+		}
+		if typ := ref.Value.Type; typ != "oauth2" && typ != "openIdConnect" {
+			return fmt.Errorf("security scheme %q of type %q can't be required with scopes", name, typ)
+		}
+	}
 	return nil
 }
+
+type securitySchemesKey struct{}
+
+// withSecuritySchemes returns a context providing the security schemes of the
+// validated document to the validation of security requirements.
+func withSecuritySchemes(c context.Context, schemes map[string]*SecuritySchemeRef) context.Context {
+	if c == nil {
+		c = context.Background()
+	}
+	return context.WithValue(c, securitySchemesKey{}, schemes)
+}
+
+func getSecuritySchemes(c context.Context) map[string]*SecuritySchemeRef {
+	if c == nil {
+		return nil
+	}
+	schemes, _ := c.Value(securitySchemesKey{}).(map[string]*SecuritySchemeRef)
+	return schemes
+}
@@ -1,7 +1,9 @@
 package openapi3
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -62,3 +64,39 @@ func TestSecurityRequirementEncoding(t *testing.T) {
 		require.Equal(t, test.json, string(b), "incorrect requirements encoding")
 	}
 }
+
+func TestSecurityRequirementScopes(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+security: [{Bearer: ROOT_SCOPES}]
+components:
+  securitySchemes:
+    Bearer: {type: http, scheme: bearer}
+    OAuth2:
+      type: oauth2
+      flows: {implicit: {authorizationUrl: 'https://openeo.org/authorize', scopes: {openid: OpenID}}}
+paths:
+  /jobs:
+    get:
+      security: [{OAuth2: [openid]}, {Bearer: OPERATION_SCOPES}]
+      responses: {'200': {description: ok}}
+`
+	for _, tc := range []struct {
+		rootScopes, operationScopes, expectedErr string
+	}{
+		{"[]", "[]", ""},
+		{"[jobs]", "[]", `invalid security: security scheme "Bearer" of type "http" can't be required with scopes`},
+		{"[]", "[jobs]", `invalid paths: security scheme "Bearer" of type "http" can't be required with scopes`},
+	} {
+		data := strings.NewReplacer("ROOT_SCOPES", tc.rootScopes, "OPERATION_SCOPES", tc.operationScopes).Replace(spec)
+		doc, err := NewSwaggerLoader().LoadSwaggerFromData([]byte(data))
+		require.NoError(t, err)
+		err = doc.Validate(context.Background())
+		if tc.expectedErr == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, tc.expectedErr)
+		}
+	}
+}
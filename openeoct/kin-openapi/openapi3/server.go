@@ -3,8 +3,10 @@ package openapi3
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"net/url"
+	"sort"
 	"strings"
 )
 
@@ -116,9 +118,14 @@ func (server *Server) Validate(c context.Context) (err error) {
 	if server.URL == "" {
 		return errors.New("value of url must be a non-empty JSON string")
 	}
-	for _, v := range server.Variables {
-		if err = v.Validate(c); err != nil {
-			return
+	names := make([]string, 0, len(server.Variables))
+	for name := range server.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err = server.Variables[name].Validate(c); err != nil {
+			return fmt.Errorf("server variable %q is invalid: %v", name, err)
 		}
 	}
 	return
@@ -144,5 +151,17 @@ func (serverVariable *ServerVariable) Validate(c context.Context) error {
 			return errors.New("Every variable 'enum' item must be number of string")
 		}
 	}
+	if len(serverVariable.Enum) != 0 {
+		found := false
+		for _, item := range serverVariable.Enum {
+			if item == serverVariable.Default {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("value of default %v must be one of the variable 'enum' items", serverVariable.Default)
+		}
+	}
 	return nil
 }
@@ -77,6 +77,27 @@ func TestServerValidation(t *testing.T) {
 			validServer(),
 			nil,
 		},
+		{
+			"when a variable default is one of its enum items",
+			&openapi3.Server{
+				URL: "https://{host}/openeo/{version}",
+				Variables: map[string]*openapi3.ServerVariable{
+					"host":    {Default: "example.com"},
+					"version": {Enum: []interface{}{"1.0", "1.1"}, Default: "1.1"},
+				},
+			},
+			nil,
+		},
+		{
+			"when a variable default is not one of its enum items",
+			&openapi3.Server{
+				URL: "https://example.com/openeo/{version}",
+				Variables: map[string]*openapi3.ServerVariable{
+					"version": {Enum: []interface{}{"1.0", "1.1"}, Default: "0.4"},
+				},
+			},
+			errors.New(`server variable "version" is invalid: value of default 0.4 must be one of the variable 'enum' items`),
+		},
 	}
 
 	for _, test := range tests {
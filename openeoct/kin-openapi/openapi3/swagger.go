@@ -3,7 +3,8 @@ package openapi3
 import (
 	"context"
 	"errors"
-	"fmt"
+	"net/url"
+	"sort"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/jsoninfo"
 )
@@ -18,6 +19,14 @@ type Swagger struct {
 	Servers      Servers              `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Tags         Tags                 `json:"tags,omitempty" yaml:"tags,omitempty"`
 	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	baseURI *url.URL
+}
+
+// BaseURI returns the location relative references of the document are resolved
+// against, or nil if it was loaded without one.
+func (swagger *Swagger) BaseURI() *url.URL {
+	return swagger.baseURI
 }
 
 func (swagger *Swagger) MarshalJSON() ([]byte, error) {
@@ -42,63 +51,120 @@ func (swagger *Swagger) AddOperation(path string, method string, operation *Oper
 	pathItem.SetOperation(method, operation)
 }
 
+// OperationInfo describes an operation of the document with its effective parameters.
+type OperationInfo struct {
+	Path        string
+	Method      string
+	OperationID string
+	PathItem    *PathItem
+	Operation   *Operation
+	// Parameters contains the path item parameters merged with the operation
+	// parameters, the latter overriding the former by location and name.
+	Parameters Parameters
+}
+
+// Operations lists all operations of the document, sorted by path and method.
+func (swagger *Swagger) Operations() []*OperationInfo {
+	paths := make([]string, 0, len(swagger.Paths))
+	for path := range swagger.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var operations []*OperationInfo
+	for _, path := range paths {
+		pathItem := swagger.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		pathOperations := pathItem.Operations()
+		methods := make([]string, 0, len(pathOperations))
+		for method := range pathOperations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := pathOperations[method]
+			operations = append(operations, &OperationInfo{
+				Path:        path,
+				Method:      method,
+				OperationID: operation.OperationID,
+				PathItem:    pathItem,
+				Operation:   operation,
+				Parameters:  mergeParameters(pathItem.Parameters, operation.Parameters),
+			})
+		}
+	}
+	return operations
+}
+
+func mergeParameters(pathItemParameters Parameters, operationParameters Parameters) Parameters {
+	parameters := make(Parameters, 0, len(pathItemParameters)+len(operationParameters))
+	for _, ref := range pathItemParameters {
+		if ref == nil {
+			continue
+		}
+		if v := ref.Value; v != nil && operationParameters.GetByInAndName(v.In, v.Name) != nil {
+			continue
+		}
+		parameters = append(parameters, ref)
+	}
+	return append(parameters, operationParameters...)
+}
+
 func (swagger *Swagger) AddServer(server *Server) {
 	swagger.Servers = append(swagger.Servers, server)
 }
 
 func (swagger *Swagger) Validate(c context.Context) error {
-	if swagger.OpenAPI == "" {
-		return errors.New("value of openapi must be a non-empty JSON string")
+	c = swagger.validationContext(c)
+	workers := 1
+	if options := getValidationOptions(c); options != nil {
+		workers = options.Concurrency
 	}
+	return runValidationTasks(c, swagger.validationTasks(c), workers)
+}
 
-	// NOTE: only mention info/components/paths/... key in this func's errors.
+// validationContext returns the context for validating the parts of the document.
+func (swagger *Swagger) validationContext(c context.Context) context.Context {
+	if len(swagger.Components.SecuritySchemes) != 0 {
+		c = withSecuritySchemes(c, swagger.Components.SecuritySchemes)
+	}
+	return c
+}
 
-	{
-		wrap := func(e error) error { return fmt.Errorf("invalid components: %v", e) }
-		if err := swagger.Components.Validate(c); err != nil {
-			return wrap(err)
-		}
+// validationTasks returns the tasks validating the document sections, in order.
+func (swagger *Swagger) validationTasks(c context.Context) []validationTask {
+	// NOTE: only mention info/components/paths/... key in this func's errors.
+	var tasks []validationTask
+	fail := func(pointer string, err error) validationTask {
+		return validationTask{pointer: pointer, run: func(context.Context) error { return err }}
 	}
 
-	{
-		wrap := func(e error) error { return fmt.Errorf("invalid info: %v", e) }
-		if v := swagger.Info; v != nil {
-			if err := v.Validate(c); err != nil {
-				return wrap(err)
-			}
-		} else {
-			return wrap(errors.New("must be a JSON object"))
-		}
+	if swagger.OpenAPI == "" {
+		tasks = append(tasks, fail("#/openapi", errors.New("value of openapi must be a non-empty JSON string")))
 	}
 
-	{
-		wrap := func(e error) error { return fmt.Errorf("invalid paths: %v", e) }
-		if v := swagger.Paths; v != nil {
-			if err := v.Validate(c); err != nil {
-				return wrap(err)
-			}
-		} else {
-			return wrap(errors.New("must be a JSON object"))
-		}
+	tasks = append(tasks, wrapValidationTasks("components", swagger.Components.validationTasks(c))...)
+
+	if v := swagger.Info; v == nil {
+		tasks = append(tasks, fail("#/info", errors.New("invalid info: must be a JSON object")))
+	} else if !validationSkipped(c, "#/info") {
+		tasks = append(tasks, wrapValidationTasks("info", []validationTask{{pointer: "#/info", value: v, run: v.Validate}})...)
 	}
 
-	{
-		wrap := func(e error) error { return fmt.Errorf("invalid security: %v", e) }
-		if v := swagger.Security; v != nil {
-			if err := v.Validate(c); err != nil {
-				return wrap(err)
-			}
-		}
+	if v := swagger.Paths; v != nil {
+		tasks = append(tasks, wrapValidationTasks("paths", v.validationTasks(c))...)
+	} else {
+		tasks = append(tasks, fail("#/paths", errors.New("invalid paths: must be a JSON object")))
 	}
 
-	{
-		wrap := func(e error) error { return fmt.Errorf("invalid servers: %v", e) }
-		if v := swagger.Servers; v != nil {
-			if err := v.Validate(c); err != nil {
-				return wrap(err)
-			}
-		}
+	if v := swagger.Security; v != nil && !validationSkipped(c, "#/security") {
+		tasks = append(tasks, wrapValidationTasks("security", []validationTask{{pointer: "#/security", value: v, run: v.Validate}})...)
 	}
 
-	return nil
+	if v := swagger.Servers; v != nil && !validationSkipped(c, "#/servers") {
+		tasks = append(tasks, wrapValidationTasks("servers", []validationTask{{pointer: "#/servers", value: v, run: v.Validate}})...)
+	}
+	return tasks
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -20,20 +21,63 @@ func foundUnresolvedRef(ref string) error {
 	return fmt.Errorf("Found unresolved ref: '%s'", ref)
 }
 
-func failedToResolveRefFragment(value string) error {
-	return fmt.Errorf("Failed to resolve fragment in URI: '%s'", value)
-}
-
 func failedToResolveRefFragmentPart(value string, what string) error {
 	return fmt.Errorf("Failed to resolve '%s' in fragment in URI: '%s'", what, value)
 }
 
+func refSegmentNotEscaped(value string, segment string) error {
+	return fmt.Errorf("ref '%s' segment '%s' is not escaped, use '~0' for '~' and '~1' for '/'", value, segment)
+}
+
+// validateRefSyntax reports malformed references, which would otherwise fail
+// to resolve with a less helpful error.
+func validateRefSyntax(ref string) error {
+	if strings.Contains(ref, `\`) {
+		return fmt.Errorf("ref '%s' contains a backslash, use '/' as path separator", ref)
+	}
+	i := strings.IndexByte(ref, '#')
+	if i < 0 {
+		return nil
+	}
+	for _, segment := range strings.Split(ref[i+1:], "/") {
+		for j := 0; j < len(segment); j++ {
+			if segment[j] == '~' && (j+1 == len(segment) || (segment[j+1] != '0' && segment[j+1] != '1')) {
+				return refSegmentNotEscaped(ref, segment)
+			}
+		}
+	}
+	return nil
+}
+
+// ErrUnsupportedRef is returned by a RefResolver for references which it doesn't
+// resolve, the loader reads them from files or over HTTP itself.
+var ErrUnsupportedRef = errors.New("unsupported reference")
+
+// RefResolver reads the documents of external references, e.g. from a registry
+// accessed with a custom protocol.
+type RefResolver interface {
+	// Resolve returns the data of the document referenced by refURI, without
+	// fragment, from the document at baseURI, which is nil for references in
+	// documents loaded from data without path. It returns ErrUnsupportedRef for
+	// references which the loader should resolve itself.
+	Resolve(refURI *url.URL, baseURI *url.URL) ([]byte, error)
+}
+
 type SwaggerLoader struct {
 	IsExternalRefsAllowed  bool
 	Context                context.Context
 	LoadSwaggerFromURIFunc func(loader *SwaggerLoader, url *url.URL) (*Swagger, error)
-	visited                map[interface{}]struct{}
-	visitedFiles           map[string]struct{}
+	// RefResolver, if set, reads the documents of external references, before
+	// the loader falls back to read them from files or over HTTP.
+	RefResolver RefResolver
+	// ExternalRefLoaded, if set, is called once for every document which external
+	// references resolved to, with the first of the references and the URI the
+	// document was read from, after the redirects of HTTP requests.
+	ExternalRefLoaded func(ref string, uri *url.URL)
+	visited           map[interface{}]struct{}
+	visitedFiles      map[string]struct{}
+	loadedRefs        map[string]struct{}
+	partial           *partialLoad
 }
 
 func NewSwaggerLoader() *SwaggerLoader {
@@ -42,6 +86,23 @@ func NewSwaggerLoader() *SwaggerLoader {
 
 func (swaggerLoader *SwaggerLoader) reset() {
 	swaggerLoader.visitedFiles = make(map[string]struct{})
+	swaggerLoader.loadedRefs = make(map[string]struct{})
+}
+
+// externalRefLoaded reports the document which the external reference resolved
+// to, unless it's reported already.
+func (swaggerLoader *SwaggerLoader) externalRefLoaded(ref string, uri *url.URL) {
+	if swaggerLoader.ExternalRefLoaded == nil {
+		return
+	}
+	if swaggerLoader.loadedRefs == nil {
+		swaggerLoader.loadedRefs = make(map[string]struct{})
+	}
+	if _, loaded := swaggerLoader.loadedRefs[uri.String()]; loaded {
+		return
+	}
+	swaggerLoader.loadedRefs[uri.String()] = struct{}{}
+	swaggerLoader.ExternalRefLoaded(ref, uri)
 }
 
 func (swaggerLoader *SwaggerLoader) LoadSwaggerFromURI(location *url.URL) (*Swagger, error) {
@@ -67,6 +128,9 @@ func (swaggerLoader *SwaggerLoader) loadSingleElementFromURI(ref string, rootPat
 	if !swaggerLoader.IsExternalRefsAllowed {
 		return fmt.Errorf("encountered non-allowed external reference: '%s'", ref)
 	}
+	if err := validateRefSyntax(ref); err != nil {
+		return err
+	}
 
 	parsedURL, err := url.Parse(ref)
 	if err != nil {
@@ -81,11 +145,17 @@ func (swaggerLoader *SwaggerLoader) loadSingleElementFromURI(ref string, rootPat
 	if err != nil {
 		return fmt.Errorf("could not resolve path: %v", err)
 	}
-
-	data, err := readURL(resolvedPath)
+	data, err := swaggerLoader.resolveRef(parsedURL, rootPath)
+	if err == ErrUnsupportedRef {
+		data, resolvedPath, err = readFinalURL(resolvedPath)
+	}
 	if err != nil {
 		return err
 	}
+	swaggerLoader.externalRefLoaded(ref, resolvedPath)
+	if kind := singleElementKind(data, element); kind != "" {
+		return refKindNameMismatch(element, ref, kind)
+	}
 	if err := yaml.Unmarshal(data, element); err != nil {
 		return err
 	}
@@ -93,27 +163,61 @@ func (swaggerLoader *SwaggerLoader) loadSingleElementFromURI(ref string, rootPat
 	return nil
 }
 
+// resolveRef returns the data of the document referenced by refURI from the
+// document at baseURI with the RefResolver, ErrUnsupportedRef without one.
+func (swaggerLoader *SwaggerLoader) resolveRef(refURI *url.URL, baseURI *url.URL) ([]byte, error) {
+	if swaggerLoader.RefResolver == nil {
+		return nil, ErrUnsupportedRef
+	}
+	return swaggerLoader.RefResolver.Resolve(refURI, baseURI)
+}
+
+// unmarshalSwagger unmarshals the JSON or YAML document data.
+func unmarshalSwagger(data []byte) (*Swagger, error) {
+	if err := validateTextFields(data); err != nil {
+		return nil, err
+	}
+	swagger := &Swagger{}
+	if err := yaml.Unmarshal(data, swagger); err != nil {
+		return nil, err
+	}
+	if err := validateOpenAPIVersion(swagger.OpenAPI); err != nil {
+		return nil, err
+	}
+	return swagger, nil
+}
+
 func readURL(location *url.URL) ([]byte, error) {
+	data, _, err := readFinalURL(location)
+	return data, err
+}
+
+// readFinalURL reads the document at location like readURL, also returning the
+// URL which it was read from after the redirects of HTTP requests.
+func readFinalURL(location *url.URL) ([]byte, *url.URL, error) {
 	if location.Scheme != "" && location.Host != "" {
 		resp, err := http.Get(location.String())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		data, err := ioutil.ReadAll(resp.Body)
 		defer resp.Body.Close()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return data, nil
+		if resp.Request != nil && resp.Request.URL != nil {
+			location = resp.Request.URL
+		}
+		return data, location, nil
 	}
 	if location.Scheme != "" || location.Host != "" || location.RawQuery != "" {
-		return nil, fmt.Errorf("Unsupported URI: '%s'", location.String())
+		return nil, nil, fmt.Errorf("Unsupported URI: '%s'", location.String())
 	}
 	data, err := ioutil.ReadFile(location.Path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return data, nil
+	return data, location, nil
 }
 
 func (swaggerLoader *SwaggerLoader) LoadSwaggerFromFile(path string) (*Swagger, error) {
@@ -143,8 +247,8 @@ func (swaggerLoader *SwaggerLoader) LoadSwaggerFromData(data []byte) (*Swagger,
 }
 
 func (swaggerLoader *SwaggerLoader) loadSwaggerFromDataInternal(data []byte) (*Swagger, error) {
-	swagger := &Swagger{}
-	if err := yaml.Unmarshal(data, swagger); err != nil {
+	swagger, err := unmarshalSwagger(data)
+	if err != nil {
 		return nil, err
 	}
 	return swagger, swaggerLoader.ResolveRefsIn(swagger, nil)
@@ -158,63 +262,125 @@ func (swaggerLoader *SwaggerLoader) LoadSwaggerFromDataWithPath(data []byte, pat
 }
 
 func (swaggerLoader *SwaggerLoader) loadSwaggerFromDataWithPathInternal(data []byte, path *url.URL) (*Swagger, error) {
-	swagger := &Swagger{}
-	if err := yaml.Unmarshal(data, swagger); err != nil {
+	swagger, err := unmarshalSwagger(data)
+	if err != nil {
 		return nil, err
 	}
+	if path != nil {
+		baseURI, err := copyURL(path)
+		if err != nil {
+			return nil, err
+		}
+		swagger.baseURI = baseURI
+	}
 	return swagger, swaggerLoader.ResolveRefsIn(swagger, path)
 }
 
+// LoadSwaggerFromReader reads the OpenApi spec from r and resolves relative references against baseURI,
+// e.g. when the spec is streamed over HTTP or read from an embedded file system.
+// The returned *Swagger carries baseURI, see (*Swagger).BaseURI.
+func (swaggerLoader *SwaggerLoader) LoadSwaggerFromReader(r io.Reader, baseURI *url.URL) (*Swagger, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	swaggerLoader.reset()
+	return swaggerLoader.loadSwaggerFromDataWithPathInternal(data, baseURI)
+}
+
+// ResolveRefsIn resolves the references in swagger relative to path.
+// A nil path defaults to the base URI the document was loaded from, if any.
 func (swaggerLoader *SwaggerLoader) ResolveRefsIn(swagger *Swagger, path *url.URL) (err error) {
+	if path == nil {
+		path = swagger.baseURI
+	}
 	swaggerLoader.visited = make(map[interface{}]struct{})
 	if swaggerLoader.visitedFiles == nil {
 		swaggerLoader.visitedFiles = make(map[string]struct{})
 	}
 
+	resolve := func(pointer string, resolveRefs func() error) error {
+		partial := swaggerLoader.partial
+		if partial == nil || partial.swagger != swagger {
+			return resolveRefs()
+		}
+		outer := partial.pointer
+		partial.pointer = pointer
+		defer func() { partial.pointer = outer }()
+		if err := resolveRefs(); err != nil {
+			partial.errs = append(partial.errs, &LoadError{Pointer: pointer, Err: err})
+		}
+		return nil
+	}
+
 	// Visit all components
 	components := swagger.Components
-	for _, component := range components.Headers {
-		if err = swaggerLoader.resolveHeaderRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.Headers) {
+		component := components.Headers[name]
+		if err = resolve("#/components/headers/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveHeaderRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
-	for _, component := range components.Parameters {
-		if err = swaggerLoader.resolveParameterRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.Parameters) {
+		component := components.Parameters[name]
+		if err = resolve("#/components/parameters/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveParameterRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
-	for _, component := range components.RequestBodies {
-		if err = swaggerLoader.resolveRequestBodyRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.RequestBodies) {
+		component := components.RequestBodies[name]
+		if err = resolve("#/components/requestBodies/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveRequestBodyRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
-	for _, component := range components.Responses {
-		if err = swaggerLoader.resolveResponseRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.Responses) {
+		component := components.Responses[name]
+		if err = resolve("#/components/responses/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveResponseRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
-	for _, component := range components.Schemas {
-		if err = swaggerLoader.resolveSchemaRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.Schemas) {
+		component := components.Schemas[name]
+		if err = resolve("#/components/schemas/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveSchemaRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
-	for _, component := range components.SecuritySchemes {
-		if err = swaggerLoader.resolveSecuritySchemeRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.SecuritySchemes) {
+		component := components.SecuritySchemes[name]
+		if err = resolve("#/components/securitySchemes/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveSecuritySchemeRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
-	for _, component := range components.Examples {
-		if err = swaggerLoader.resolveExampleRef(swagger, component, path); err != nil {
+	for _, name := range sortedMapKeys(components.Examples) {
+		component := components.Examples[name]
+		if err = resolve("#/components/examples/"+escapeJSONPointerToken(name), func() error {
+			return swaggerLoader.resolveExampleRef(swagger, component, path)
+		}); err != nil {
 			return
 		}
 	}
 
 	// Visit all operations
-	for entrypoint, pathItem := range swagger.Paths {
+	for _, entrypoint := range sortedMapKeys(swagger.Paths) {
+		pathItem := swagger.Paths[entrypoint]
 		if pathItem == nil {
 			continue
 		}
-		if err = swaggerLoader.resolvePathItemRef(swagger, entrypoint, pathItem, path); err != nil {
+		if err = resolve("#/paths/"+escapeJSONPointerToken(entrypoint), func() error {
+			return swaggerLoader.resolvePathItemRef(swagger, entrypoint, pathItem, path)
+		}); err != nil {
 			return
 		}
 	}
@@ -269,22 +435,42 @@ func (swaggerLoader *SwaggerLoader) resolveComponent(swagger *Swagger, ref strin
 	}
 
 	cursor = swagger
-	for _, pathPart := range strings.Split(fragment[1:], "/") {
+	pathParts := strings.Split(fragment[1:], "/")
+	for i, pathPart := range pathParts {
+		pathPart = unescapeRefString(pathPart)
 
-		pathPart = strings.Replace(pathPart, "~1", "/", -1)
-		pathPart = strings.Replace(pathPart, "~0", "~", -1)
-
-		if cursor, err = drillIntoSwaggerField(cursor, pathPart); err != nil {
+		next, err := drillIntoSwaggerField(cursor, pathPart)
+		if err != nil {
+			if key := unescapedMapKey(cursor, pathParts[i:]); key != "" {
+				return nil, nil, refSegmentNotEscaped(ref, key)
+			}
 			return nil, nil, fmt.Errorf("Failed to resolve '%s' in fragment in URI: '%s': %v", ref, pathPart, err.Error())
 		}
-		if cursor == nil {
+		if next == nil {
 			return nil, nil, failedToResolveRefFragmentPart(ref, pathPart)
 		}
+		cursor = next
 	}
 
 	return cursor, componentPath, nil
 }
 
+// unescapedMapKey returns the key of the map cursor that is made of several of
+// the leading fragment parts, as happens when '/' in a key isn't escaped.
+func unescapedMapKey(cursor interface{}, parts []string) string {
+	val := reflect.Indirect(reflect.ValueOf(cursor))
+	if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+		return ""
+	}
+	for n := 2; n <= len(parts); n++ {
+		key := unescapeRefString(strings.Join(parts[:n], "/"))
+		if val.MapIndex(reflect.ValueOf(key).Convert(val.Type().Key())).IsValid() {
+			return key
+		}
+	}
+	return ""
+}
+
 func drillIntoSwaggerField(cursor interface{}, fieldName string) (interface{}, error) {
 	switch val := reflect.Indirect(reflect.ValueOf(cursor)); val.Kind() {
 	case reflect.Map:
@@ -328,6 +514,9 @@ func drillIntoSwaggerField(cursor interface{}, fieldName string) (interface{}, e
 }
 
 func (swaggerLoader *SwaggerLoader) resolveRefSwagger(swagger *Swagger, ref string, path *url.URL) (*Swagger, string, *url.URL, error) {
+	if err := validateRefSyntax(ref); err != nil {
+		return nil, "", nil, err
+	}
 	componentPath := path
 	if !strings.HasPrefix(ref, "#") {
 		if !swaggerLoader.IsExternalRefsAllowed {
@@ -345,9 +534,21 @@ func (swaggerLoader *SwaggerLoader) resolveRefSwagger(swagger *Swagger, ref stri
 			return nil, "", nil, fmt.Errorf("Error while resolving path: %v", err)
 		}
 
-		if swagger, err = swaggerLoader.loadSwaggerFromURIInternal(resolvedPath); err != nil {
+		loadedURI := resolvedPath
+		data, err := swaggerLoader.resolveRef(parsedURL, path)
+		if err == nil {
+			swagger, err = swaggerLoader.loadSwaggerFromDataWithPathInternal(data, resolvedPath)
+		} else if err == ErrUnsupportedRef && swaggerLoader.LoadSwaggerFromURIFunc != nil {
+			swagger, err = swaggerLoader.loadSwaggerFromURIInternal(resolvedPath)
+		} else if err == ErrUnsupportedRef {
+			if data, loadedURI, err = readFinalURL(resolvedPath); err == nil {
+				swagger, err = swaggerLoader.loadSwaggerFromDataWithPathInternal(data, resolvedPath)
+			}
+		}
+		if err != nil {
 			return nil, "", nil, fmt.Errorf("Error while resolving reference '%s': %v", ref, err)
 		}
+		swaggerLoader.externalRefLoaded(ref, loadedURI)
 		ref = fmt.Sprintf("#%s", fragment)
 		componentPath = resolvedPath
 	}
@@ -369,18 +570,18 @@ func (swaggerLoader *SwaggerLoader) resolveHeaderRef(swagger *Swagger, component
 		if isSingleRefElement(ref) {
 			var header Header
 			if err := swaggerLoader.loadSingleElementFromURI(ref, path, &header); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			component.Value = &header
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, path)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*HeaderRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveHeaderRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -416,17 +617,17 @@ func (swaggerLoader *SwaggerLoader) resolveParameterRef(swagger *Swagger, compon
 		if isSingleRefElement(ref) {
 			var param Parameter
 			if err := swaggerLoader.loadSingleElementFromURI(ref, documentPath, &param); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			component.Value = &param
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, documentPath)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*ParameterRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveParameterRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -477,18 +678,18 @@ func (swaggerLoader *SwaggerLoader) resolveRequestBodyRef(swagger *Swagger, comp
 		if isSingleRefElement(ref) {
 			var requestBody RequestBody
 			if err := swaggerLoader.loadSingleElementFromURI(ref, path, &requestBody); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			component.Value = &requestBody
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, path)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*RequestBodyRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err = swaggerLoader.resolveRequestBodyRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -533,18 +734,18 @@ func (swaggerLoader *SwaggerLoader) resolveResponseRef(swagger *Swagger, compone
 		if isSingleRefElement(ref) {
 			var resp Response
 			if err := swaggerLoader.loadSingleElementFromURI(ref, documentPath, &resp); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			component.Value = &resp
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, documentPath)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*ResponseRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveResponseRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -607,18 +808,18 @@ func (swaggerLoader *SwaggerLoader) resolveSchemaRef(swagger *Swagger, component
 		if isSingleRefElement(ref) {
 			var schema Schema
 			if err := swaggerLoader.loadSingleElementFromURI(ref, documentPath, &schema); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			component.Value = &schema
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, documentPath)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			resolved, ok := untypedResolved.(*SchemaRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveSchemaRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -643,11 +844,16 @@ func (swaggerLoader *SwaggerLoader) resolveSchemaRef(swagger *Swagger, component
 			return err
 		}
 	}
-	for _, v := range value.Properties {
+	for _, v := range value.PrefixItems {
 		if err := swaggerLoader.resolveSchemaRef(swagger, v, refDocumentPath); err != nil {
 			return err
 		}
 	}
+	for _, name := range sortedMapKeys(value.Properties) {
+		if err := swaggerLoader.resolveSchemaRef(swagger, value.Properties[name], refDocumentPath); err != nil {
+			return err
+		}
+	}
 	if v := value.AdditionalProperties; v != nil {
 		if err := swaggerLoader.resolveSchemaRef(swagger, v, refDocumentPath); err != nil {
 			return err
@@ -658,6 +864,14 @@ func (swaggerLoader *SwaggerLoader) resolveSchemaRef(swagger *Swagger, component
 			return err
 		}
 	}
+	for _, v := range []*SchemaRef{value.If, value.Then, value.Else} {
+		if v == nil {
+			continue
+		}
+		if err := swaggerLoader.resolveSchemaRef(swagger, v, refDocumentPath); err != nil {
+			return err
+		}
+	}
 	for _, v := range value.AllOf {
 		if err := swaggerLoader.resolveSchemaRef(swagger, v, refDocumentPath); err != nil {
 			return err
@@ -674,6 +888,15 @@ func (swaggerLoader *SwaggerLoader) resolveSchemaRef(swagger *Swagger, component
 		}
 	}
 
+	// OpenAPI 3.1 applies the keywords next to a reference too, like allOf does
+	if siblings := component.Siblings; siblings != nil && ref != "" && strings.HasPrefix(swagger.OpenAPI, "3.1") {
+		if err := swaggerLoader.resolveSchemaRef(swagger, &SchemaRef{Value: siblings}, documentPath); err != nil {
+			return err
+		}
+		combined := *siblings
+		combined.AllOf = append([]*SchemaRef{{Ref: ref, Value: value}}, siblings.AllOf...)
+		component.Value = &combined
+	}
 	return nil
 }
 
@@ -692,18 +915,18 @@ func (swaggerLoader *SwaggerLoader) resolveSecuritySchemeRef(swagger *Swagger, c
 		if isSingleRefElement(ref) {
 			var scheme SecurityScheme
 			if err := swaggerLoader.loadSingleElementFromURI(ref, path, &scheme); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			component.Value = &scheme
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, path)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*SecuritySchemeRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveSecuritySchemeRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -729,18 +952,18 @@ func (swaggerLoader *SwaggerLoader) resolveExampleRef(swagger *Swagger, componen
 		if isSingleRefElement(ref) {
 			var example Example
 			if err := swaggerLoader.loadSingleElementFromURI(ref, path, &example); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			component.Value = &example
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, path)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*ExampleRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveExampleRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -766,18 +989,18 @@ func (swaggerLoader *SwaggerLoader) resolveLinkRef(swagger *Swagger, component *
 		if isSingleRefElement(ref) {
 			var link Link
 			if err := swaggerLoader.loadSingleElementFromURI(ref, path, &link); err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 
 			component.Value = &link
 		} else {
 			untypedResolved, componentPath, err := swaggerLoader.resolveComponent(swagger, ref, path)
 			if err != nil {
-				return err
+				return swaggerLoader.refFailed(swagger, ref, component, err)
 			}
 			resolved, ok := untypedResolved.(*LinkRef)
 			if !ok {
-				return failedToResolveRefFragment(ref)
+				return swaggerLoader.refFailed(swagger, ref, component, refKindMismatch(component, ref, untypedResolved))
 			}
 			if err := swaggerLoader.resolveLinkRef(swagger, resolved, componentPath); err != nil {
 				return err
@@ -829,6 +1052,9 @@ func (swaggerLoader *SwaggerLoader) resolvePathItemRef(swagger *Swagger, entrypo
 			}
 			resolved := definitions[id]
 			if resolved == nil {
+				if rawID := ref[len(prefix):]; strings.Contains(rawID, "/") {
+					return refSegmentNotEscaped(ref, rawID)
+				}
 				return failedToResolveRefFragmentPart(ref, id)
 			}
 
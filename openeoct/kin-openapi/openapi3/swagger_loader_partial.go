@@ -0,0 +1,95 @@
+package openapi3
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// LoadError is an error of a partial load, see LoadPartial.
+type LoadError struct {
+	// Pointer locates the component or path of the document which failed to
+	// load, e.g. "#/components/schemas/Job" or "#/paths/~1jobs".
+	Pointer string
+	// Ref is the reference which failed to resolve, if any.
+	Ref string
+	// Component is the reference which failed to resolve, e.g. a *SchemaRef.
+	// It's left unresolved, with a nil Value.
+	Component interface{}
+	Err       error
+}
+
+func (err *LoadError) Error() string {
+	if err.Ref != "" {
+		return fmt.Sprintf("%s: failed to resolve '%s': %v", err.Pointer, err.Ref, err.Err)
+	}
+	return fmt.Sprintf("%s: %v", err.Pointer, err.Err)
+}
+
+func (err *LoadError) Unwrap() error {
+	return err.Err
+}
+
+// LoadErrors are the errors of a partial load.
+type LoadErrors []*LoadError
+
+// Pointers returns the pointers of the document parts which failed to load,
+// e.g. to skip them in the validation with ValidationOptions.IgnoredPointers.
+func (errs LoadErrors) Pointers() []string {
+	pointers := make([]string, 0, len(errs))
+	seen := make(map[string]bool, len(errs))
+	for _, err := range errs {
+		if !seen[err.Pointer] {
+			seen[err.Pointer] = true
+			pointers = append(pointers, err.Pointer)
+		}
+	}
+	return pointers
+}
+
+// partialLoad collects the errors of the document of a partial load.
+type partialLoad struct {
+	swagger *Swagger
+	// pointer locates the component or path being resolved.
+	pointer string
+	errs    LoadErrors
+}
+
+// LoadPartial loads the document like LoadSwaggerFromDataWithPath, but doesn't
+// fail on references which can't be resolved, e.g. to inspect the valid parts
+// of a broken document. The references are left unresolved, with a nil Value,
+// unlike resolved references, and reported as load errors, with those of the
+// other errors of the document parts. The error is only returned if the data
+// can't be parsed at all.
+func (swaggerLoader *SwaggerLoader) LoadPartial(data []byte, path *url.URL) (*Swagger, LoadErrors, error) {
+	swaggerLoader.reset()
+	swagger, err := unmarshalSwagger(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if path != nil {
+		baseURI, err := copyURL(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		swagger.baseURI = baseURI
+	}
+	partial := &partialLoad{swagger: swagger}
+	swaggerLoader.partial = partial
+	defer func() { swaggerLoader.partial = nil }()
+	if err := swaggerLoader.ResolveRefsIn(swagger, path); err != nil {
+		return nil, nil, err
+	}
+	return swagger, partial.errs, nil
+}
+
+// refFailed records the error of resolving the reference of component during
+// a partial load of swagger, returning nil to continue with the other parts.
+// Otherwise, the error is returned.
+func (swaggerLoader *SwaggerLoader) refFailed(swagger *Swagger, ref string, component interface{}, err error) error {
+	partial := swaggerLoader.partial
+	if partial == nil || partial.swagger != swagger {
+		return err
+	}
+	partial.errs = append(partial.errs, &LoadError{Pointer: partial.pointer, Ref: ref, Component: component, Err: err})
+	return nil
+}
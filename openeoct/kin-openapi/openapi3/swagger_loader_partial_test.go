@@ -0,0 +1,67 @@
+package openapi3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPartial(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: A broken API, version: v1}
+components:
+  schemas:
+    Job:
+      type: object
+      properties:
+        id: {type: string}
+        process: {$ref: '#/components/schemas/Process'}
+        logs: {$ref: '#/components/schemas/Logs'}
+    Collection: {type: object, properties: {id: {type: string}}}
+paths:
+  /jobs/{job_id}:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/job_id'
+      responses:
+        '200':
+          description: The job.
+          content:
+            application/json: {schema: {$ref: '#/components/schemas/Job'}}
+  /collections:
+    get:
+      responses:
+        '200':
+          description: The collections.
+          content:
+            application/json: {schema: {$ref: '#/components/schemas/Collection'}}
+`)
+	_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.Error(t, err)
+
+	swagger, errs, err := openapi3.NewSwaggerLoader().LoadPartial(spec, nil)
+	require.NoError(t, err)
+	require.Len(t, errs, 3)
+	require.Equal(t, "#/components/schemas/Job", errs[0].Pointer)
+	require.Equal(t, "#/components/schemas/Logs", errs[0].Ref)
+	require.Equal(t, "#/components/schemas/Process", errs[1].Ref)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}", errs[2].Pointer)
+	require.Equal(t, "#/components/parameters/job_id", errs[2].Ref)
+	require.Contains(t, errs[2].Error(), "#/paths/~1jobs~1{job_id}: failed to resolve '#/components/parameters/job_id': ")
+	require.Equal(t, []string{"#/components/schemas/Job", "#/paths/~1jobs~1{job_id}"}, errs.Pointers())
+
+	// Unresolved references are left without value, unlike the resolved ones
+	process := swagger.Components.Schemas["Job"].Value.Properties["process"]
+	require.Same(t, process, errs[1].Component)
+	require.Nil(t, process.Value)
+	require.NotNil(t, swagger.Components.Schemas["Job"].Value.Properties["id"].Value)
+	require.NotNil(t, swagger.Paths["/collections"].Get.Responses["200"].Value.Content["application/json"].Schema.Value)
+
+	// The resolvable parts can be validated
+	require.Error(t, swagger.Validate(context.Background()))
+	c := openapi3.WithValidationOptions(context.Background(), &openapi3.ValidationOptions{IgnoredPointers: errs.Pointers()})
+	require.NoError(t, swagger.Validate(c))
+}
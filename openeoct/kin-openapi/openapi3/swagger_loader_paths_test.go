@@ -1,6 +1,7 @@
 package openapi3_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -38,3 +39,110 @@ paths:
 		}
 	}
 }
+
+func TestCatchAllPathParameters(t *testing.T) {
+	spec := `
+openapi: "3.0"
+info:
+  version: "1.0"
+  title: sample
+paths:
+  PATH:
+    get:
+      parameters:
+        - name: path
+          in: path
+          required: true
+          schema:
+            type: TYPE
+      responses:
+        200:
+          description: description
+`
+
+	for _, tc := range []struct {
+		path, typ, expectedErr string
+	}{
+		{"/files/{+path}", "string", ""},
+		{"/files/{path}", "integer", ""},
+		{"/files/{+path}", "integer", "invalid paths: catch-all parameter \"path\" of path \"/files/{+path}\" must have a string schema, not \"integer\""},
+		{"/files/{+path}/meta", "string", "invalid paths: path \"/files/{+path}/meta\" has a catch-all parameter which isn't its last segment"},
+	} {
+		loader := openapi3.NewSwaggerLoader()
+		doc, err := loader.LoadSwaggerFromData([]byte(strings.NewReplacer("PATH", tc.path, "TYPE", tc.typ).Replace(spec)))
+		require.NoError(t, err)
+		err = doc.Validate(loader.Context)
+		if tc.expectedErr != "" {
+			require.EqualError(t, err, tc.expectedErr)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestPathsWithSameOperations(t *testing.T) {
+	newPathItem := func(operations ...string) *openapi3.PathItem {
+		pathItem := &openapi3.PathItem{}
+		for _, method := range operations {
+			pathItem.SetOperation(method, &openapi3.Operation{Responses: openapi3.NewResponses()})
+		}
+		return pathItem
+	}
+	doc := &openapi3.Swagger{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "MyAPI", Version: "0.1"},
+		Paths: openapi3.Paths{
+			"/jobs/{job_id}": newPathItem("GET", "DELETE"),
+			"/jobs/{id}":     newPathItem("PATCH", "GET"),
+		},
+	}
+	require.EqualError(t, doc.Validate(context.Background()),
+		`invalid paths: operations GET "/jobs/{id}" and GET "/jobs/{job_id}" have the same path and method`)
+
+	doc.Paths["/jobs/{id}"] = newPathItem("PATCH")
+	require.EqualError(t, doc.Validate(context.Background()),
+		`invalid paths: conflicting paths "/jobs/{job_id}" and "/jobs/{id}"`)
+
+	delete(doc.Paths, "/jobs/{id}")
+	doc.Paths["/jobs/{+id}"] = newPathItem("GET")
+	require.NoError(t, doc.Validate(context.Background()))
+}
+
+func TestPathsWithRepeatedTemplateVariables(t *testing.T) {
+	newPathItem := func() *openapi3.PathItem {
+		return &openapi3.PathItem{Get: &openapi3.Operation{Responses: openapi3.NewResponses()}}
+	}
+	doc := &openapi3.Swagger{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "MyAPI", Version: "0.1"},
+		Paths: openapi3.Paths{
+			"/jobs/{id}/results/{asset_id}": newPathItem(),
+		},
+	}
+	require.NoError(t, doc.Validate(context.Background()))
+
+	doc.Paths["/files/{id}/versions/{+id}"] = newPathItem()
+	require.EqualError(t, doc.Validate(context.Background()),
+		`invalid paths: path "/files/{id}/versions/{+id}" uses the template variable "id" more than once`)
+}
+
+func TestMatchPathPattern(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"/collections/**", "/collections", true},
+		{"/collections/**", "/collections/{collection_id}/items", true},
+		{"/collections/**", "/collections_x", false},
+		{"/collections/*", "/collections/{collection_id}", true},
+		{"/collections/*", "/collections/{collection_id}/items", false},
+		{"/**/items", "/collections/{collection_id}/items", true},
+		{"/**", "/", true},
+		{"/jobs", "/jobs/", true},
+		{"/jobs", "/jobs/{job_id}", false},
+		{"/jobs/{job_id}", "/jobs/{job_id}", true},
+	} {
+		require.Equal(t, tt.match, openapi3.MatchPathPattern(tt.pattern, tt.path), "%s %s", tt.pattern, tt.path)
+	}
+}
@@ -1,7 +1,9 @@
 package openapi3_test
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -445,6 +447,23 @@ func TestLoadYamlFileWithExternalPathRef(t *testing.T) {
 	require.Equal(t, "string", swagger.Paths["/test"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Type)
 }
 
+func TestLoadFromReaderWithBaseURI(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/testref.openapi.yml")
+	require.NoError(t, err)
+	baseURI := &url.URL{Path: "testdata/testref.openapi.yml"}
+
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	swagger, err := loader.LoadSwaggerFromReader(bytes.NewReader(data), baseURI)
+	require.NoError(t, err)
+	require.NotNil(t, swagger.Components.Schemas["AnotherTestSchema"].Value.Type)
+	require.Equal(t, baseURI, swagger.BaseURI())
+
+	// Without a base URI the relative external ref cannot be found
+	_, err = loader.LoadSwaggerFromReader(bytes.NewReader(data), nil)
+	require.Error(t, err)
+}
+
 func TestResolveResponseLinkRef(t *testing.T) {
 	source := []byte(`
 openapi: 3.0.1
@@ -551,3 +570,272 @@ paths:
 	err = doc.Validate(loader.Context)
 	require.NoError(t, err)
 }
+
+func TestLoadMalformedRefs(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{
+			ref:  `#/paths//items/put/requestBody/content/application~1json/schema`,
+			want: `ref '#/paths//items/put/requestBody/content/application~1json/schema' segment '/items' is not escaped`,
+		},
+		{
+			ref:  `#/paths/~1items/put/requestBody/content/application/json/schema`,
+			want: `segment 'application/json' is not escaped`,
+		},
+		{
+			ref:  `#/components/schemas/New~Item`,
+			want: `ref '#/components/schemas/New~Item' segment 'New~Item' is not escaped`,
+		},
+		{
+			ref:  `schemas\item.json`,
+			want: `ref 'schemas\item.json' contains a backslash, use '/' as path separator`,
+		},
+		{
+			ref:  `schemas\item.json#/Item`,
+			want: `contains a backslash`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    NewItem: {type: string}
+paths:
+  /items:
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/NewItem'}
+      responses:
+        default: {description: ok}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '` + test.ref + `'}
+      responses:
+        default: {description: ok}
+`)
+			loader := openapi3.NewSwaggerLoader()
+			loader.IsExternalRefsAllowed = true
+			_, err := loader.LoadSwaggerFromData(spec)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.want)
+		})
+	}
+}
+
+func TestLoadNonNumericSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{"{type: number, minimum: '0'}", "#/components/schemas/Job/properties/costs/minimum must be a number, found a string"},
+		{"{type: number, multipleOf: }", "#/components/schemas/Job/properties/costs/multipleOf must be a number, found null"},
+		{"{type: string, maxLength: '3'}", "#/components/schemas/Job/properties/costs/maxLength must be a non-negative integer, found a string"},
+		{"{type: array, items: {type: number}, minItems: 1.5}", "#/components/schemas/Job/properties/costs/minItems must be a non-negative integer, found 1.5"},
+		{"{type: number, exclusiveMaximum: 'true'}", "#/components/schemas/Job/properties/costs/exclusiveMaximum must be a boolean or a number, found a string"},
+	}
+	for _, test := range tests {
+		spec := "openapi: 3.0.0\ninfo: {title: API, version: v1}\npaths: {}\ncomponents: {schemas: {Job: {type: object, properties: {costs: " + test.schema + "}}}}"
+		_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+		require.EqualError(t, err, test.err)
+		keywordErr, ok := err.(*openapi3.KeywordTypeError)
+		require.True(t, ok)
+		require.Equal(t, "#/components/schemas/Job/properties/costs/"+keywordErr.Keyword, keywordErr.Pointer)
+	}
+
+	// Properties and examples named like the keywords are not checked
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: API, version: v1}
+components:
+  schemas:
+    Range:
+      type: object
+      properties:
+        minimum: {type: number, minimum: 0, exclusiveMinimum: true}
+        maxLength: {type: integer, minimum: 0}
+      example: {minimum: '0', maxLength: 'unbounded'}
+paths: {}
+`)
+	_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+}
+
+func TestLoadNonStringTextFields(t *testing.T) {
+	tests := []struct {
+		spec string
+		err  string
+	}{
+		{
+			"info: {title: 123, version: v1}\npaths: {}",
+			"#/info/title must be a string, found a number",
+		},
+		{
+			"info: {title: API, description: , version: v1}\npaths: {}",
+			"#/info/description must be a string, found null",
+		},
+		{
+			"info: {title: API, version: v1}\npaths: {/jobs: {get: {summary: true, responses: {'200': {description: OK}}}}}",
+			"#/paths/~1jobs/get/summary must be a string, found a boolean",
+		},
+		{
+			"info: {title: API, version: v1}\ncomponents: {schemas: {Job: {type: object, properties: {title: {type: string, description: {text: t}}}}}}\npaths: {}",
+			"#/components/schemas/Job/properties/title/description must be a string, found an object",
+		},
+		{
+			"info: {title: API, version: v1}\npaths: {/jobs: {post: {requestBody: {description: [1], content: {}}, responses: {'201': {description: OK}}}}}",
+			"#/paths/~1jobs/post/requestBody/description must be a string, found an array",
+		},
+	}
+	for _, test := range tests {
+		_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte("openapi: 3.0.0\n" + test.spec))
+		require.EqualError(t, err, test.err)
+	}
+
+	// Properties, examples and extensions named like the fields are not checked
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: API, version: v1, x-summary: {short: API}}
+components:
+  schemas:
+    title: {type: string}
+    Collection:
+      type: object
+      properties:
+        title: {type: string, example: {title: 1}}
+        summary: {$ref: '#/components/schemas/title'}
+      example: {description: 5}
+  examples:
+    description: {summary: Example, value: {summary: 1}}
+paths:
+  /jobs:
+    post:
+      operationId: createJob
+      responses:
+        '201':
+          description: Created
+          links:
+            get:
+              operationId: createJob
+              parameters: {title: $response.body#/title}
+              requestBody: {description: [1]}
+`)
+	_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+}
+
+type registryResolver map[string]string
+
+func (registry registryResolver) Resolve(refURI *url.URL, baseURI *url.URL) ([]byte, error) {
+	if refURI.Scheme != "registry" {
+		return nil, openapi3.ErrUnsupportedRef
+	}
+	data, ok := registry[refURI.String()]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the registry", refURI)
+	}
+	return []byte(data), nil
+}
+
+func TestLoadWithRefResolver(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Process: {$ref: 'registry://openeo/processes.yaml#/components/schemas/Process'}
+    JobId: {$ref: 'registry://openeo/job_id.yaml'}
+    Name: {$ref: 'testdata/components.openapi.yml#/components/schemas/Name'}
+paths: {}
+`)
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.RefResolver = registryResolver{
+		"registry://openeo/processes.yaml": `
+openapi: 3.0.0
+info: {title: Processes, version: v1}
+components:
+  schemas:
+    Process: {type: object, properties: {id: {type: string}}}
+paths: {}
+`,
+		"registry://openeo/job_id.yaml": `{type: string, pattern: '^[\w\-\.~]+$'}`,
+	}
+	swagger, err := loader.LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	require.Equal(t, "object", swagger.Components.Schemas["Process"].Value.Type)
+	require.Equal(t, `^[\w\-\.~]+$`, swagger.Components.Schemas["JobId"].Value.Pattern)
+	require.Equal(t, "string", swagger.Components.Schemas["Name"].Value.Type)
+
+	loader.RefResolver = registryResolver{}
+	_, err = loader.LoadSwaggerFromData(spec)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "registry://openeo/")
+}
+
+func TestLoadOpenAPIVersions(t *testing.T) {
+	for _, version := range []string{"3.0", "3.0.0", "3.0.3", "3.0.4", "3.1.0", "3.1.1"} {
+		_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte("openapi: '" + version + "'\ninfo: {title: An API, version: v1}\npaths: {}"))
+		require.NoError(t, err, version)
+	}
+	for version, message := range map[string]string{
+		"2.0":       `unsupported OpenAPI version "2.0", the supported versions are 3.0.x and 3.1.x`,
+		"3.2.0":     `unsupported OpenAPI version "3.2.0", the supported versions are 3.0.x and 3.1.x`,
+		"3.0.x":     `invalid OpenAPI version "3.0.x", expected a version like 3.0.3`,
+		"v3.1.0":    `invalid OpenAPI version "v3.1.0", expected a version like 3.0.3`,
+		"3.0.3.1":   `invalid OpenAPI version "3.0.3.1", expected a version like 3.0.3`,
+		"3.1.0-rc1": `invalid OpenAPI version "3.1.0-rc1", expected a version like 3.0.3`,
+	} {
+		_, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte("openapi: '" + version + "'\ninfo: {title: An API, version: v1}\npaths: {}"))
+		require.EqualError(t, err, message)
+	}
+
+	version, err := openapi3.ParseOpenAPIVersion("3.0.4")
+	require.NoError(t, err)
+	require.True(t, version.Supported())
+	require.False(t, version.Tested())
+	latest, _ := version.LatestTested()
+	require.Equal(t, "3.0.3", latest.String())
+}
+
+func TestExternalRefLoaded(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("testdata")))
+	mux.Handle("/v1/", http.RedirectHandler("/components.openapi.yml", http.StatusMovedPermanently))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Remote: {$ref: '` + ts.URL + `/v1/components.openapi.yml#/components/schemas/CustomTestSchema'}
+    Again: {$ref: '` + ts.URL + `/v1/components.openapi.yml#/components/schemas/CustomTestSchema'}
+    Local: {$ref: '#/components/schemas/Remote'}
+`)
+	var loaded []string
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ExternalRefLoaded = func(ref string, uri *url.URL) {
+		loaded = append(loaded, ref+" "+uri.String())
+	}
+	swagger, err := loader.LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	require.NotNil(t, swagger.Components.Schemas["Remote"].Value)
+	require.Equal(t, []string{ts.URL + "/v1/components.openapi.yml#/components/schemas/CustomTestSchema " + ts.URL + "/components.openapi.yml"}, loaded)
+
+	loaded = nil
+	_, err = loader.LoadSwaggerFromFile("testdata/testref.openapi.yml")
+	require.NoError(t, err)
+	require.Equal(t, []string{"components.openapi.yml#/components/schemas/CustomTestSchema testdata/components.openapi.yml"}, loaded)
+}
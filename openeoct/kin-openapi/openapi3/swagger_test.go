@@ -131,7 +131,9 @@ components:
     someSchema:
       description: Some schema
   headers:
-    otherHeader: {}
+    otherHeader:
+      schema:
+        type: string
     someHeader:
       "$ref": "#/components/headers/otherHeader"
   examples:
@@ -208,7 +210,11 @@ var specJSON = `
       }
     },
     "headers": {
-      "otherHeader": {},
+      "otherHeader": {
+        "schema": {
+          "type": "string"
+        }
+      },
       "someHeader": {
         "$ref": "#/components/headers/otherHeader"
       }
@@ -318,7 +324,7 @@ func spec() *openapi3.Swagger {
 					Ref: "#/components/headers/otherHeader",
 				},
 				"otherHeader": {
-					Value: &openapi3.Header{},
+					Value: &openapi3.Header{Schema: openapi3.NewStringSchema().NewRef()},
 				},
 			},
 			Examples: map[string]*openapi3.ExampleRef{
@@ -508,3 +514,52 @@ components:
 		})
 	}
 }
+
+func TestOperations(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  parameters:
+    JobID: {name: job_id, in: path, required: true, schema: {type: string}}
+paths:
+  /jobs/{job_id}:
+    parameters:
+      - $ref: '#/components/parameters/JobID'
+      - {name: limit, in: query, schema: {type: integer}}
+    get:
+      operationId: describe-job
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, minimum: 1}}
+      responses: {'200': {description: ok}}
+    delete:
+      responses: {'204': {description: deleted}}
+  /jobs:
+    get:
+      operationId: list-jobs
+      responses: {'200': {description: ok}}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+
+	operations := swagger.Operations()
+	require.Len(t, operations, 3)
+	require.Equal(t, "/jobs", operations[0].Path)
+	require.Equal(t, "list-jobs", operations[0].OperationID)
+	require.Empty(t, operations[0].Parameters)
+
+	require.Equal(t, "DELETE", operations[1].Method)
+	require.Len(t, operations[1].Parameters, 2)
+
+	get := operations[2]
+	require.Equal(t, "GET", get.Method)
+	require.Equal(t, "describe-job", get.OperationID)
+	require.Len(t, get.Parameters, 2)
+	require.Equal(t, "job_id", get.Parameters.GetByInAndName("path", "job_id").Name)
+	require.NotNil(t, get.Parameters.GetByInAndName("query", "limit").Schema.Value.Min)
+
+	// Nil parameters of documents built in code are skipped
+	swagger.Paths["/jobs"].Parameters = openapi3.Parameters{nil}
+	swagger.Paths["/jobs"].Get.Parameters = openapi3.Parameters{nil}
+	require.Nil(t, swagger.Operations()[0].Parameters.GetByInAndName("path", "job_id"))
+}
@@ -0,0 +1,193 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// textFields are the metadata fields of OpenAPI objects whose values must be strings.
+var textFields = map[string]bool{"title": true, "description": true, "summary": true}
+
+// namedMapFields are the fields whose values map names chosen by the document
+// authors, e.g. schema or property names, to OpenAPI objects.
+var namedMapFields = map[string]bool{
+	"paths": true, "webhooks": true, "schemas": true, "responses": true, "parameters": true,
+	"examples": true, "requestBodies": true, "headers": true, "securitySchemes": true,
+	"links": true, "pathItems": true, "properties": true, "patternProperties": true,
+	"dependentSchemas": true, "$defs": true, "definitions": true, "content": true,
+	"encoding": true, "variables": true,
+}
+
+// valueFields are the fields whose values are arbitrary data or maps of names
+// to other values than OpenAPI objects, which aren't checked.
+var valueFields = map[string]bool{
+	"example": true, "default": true, "enum": true, "const": true, "value": true,
+	"security": true, "scopes": true, "mapping": true, "dependentRequired": true,
+}
+
+// numberKeywords are the schema keywords whose values must be numbers, or
+// booleans for the exclusive limits of OpenAPI 3.0.
+var numberKeywords = map[string]bool{
+	"minimum": true, "maximum": true, "multipleOf": true,
+	"exclusiveMinimum": true, "exclusiveMaximum": true,
+}
+
+// integerKeywords are the schema keywords whose values must be non-negative integers.
+var integerKeywords = map[string]bool{
+	"minLength": true, "maxLength": true, "minItems": true, "maxItems": true,
+	"minProperties": true, "maxProperties": true,
+}
+
+// KeywordTypeError is a numeric schema keyword, e.g. minimum or maxLength,
+// whose value has another type, e.g. the string "0" of a sloppy conversion.
+type KeywordTypeError struct {
+	// Pointer locates the keyword, e.g. "#/components/schemas/Job/properties/costs/minimum".
+	Pointer string
+	Keyword string
+	// Value is the decoded JSON value of the keyword.
+	Value interface{}
+}
+
+func (err *KeywordTypeError) Error() string {
+	expected := "a number"
+	if integerKeywords[err.Keyword] {
+		expected = "a non-negative integer"
+	} else if strings.HasPrefix(err.Keyword, "exclusive") {
+		expected = "a boolean or a number"
+	}
+	found := jsonTypeName(err.Value)
+	if _, ok := err.Value.(float64); ok {
+		found = fmt.Sprintf("%v", err.Value)
+	}
+	return fmt.Sprintf("%s must be %s, found %s", err.Pointer, expected, found)
+}
+
+// validateKeywordType returns a *KeywordTypeError if the value of the numeric
+// schema keyword has another type.
+func validateKeywordType(pointer string, keyword string, value interface{}) error {
+	switch value := value.(type) {
+	case float64:
+		if !integerKeywords[keyword] || value >= 0 && value == math.Trunc(value) {
+			return nil
+		}
+	case bool:
+		if strings.HasPrefix(keyword, "exclusive") {
+			return nil
+		}
+	}
+	return &KeywordTypeError{Pointer: pointer, Keyword: keyword, Value: value}
+}
+
+// validateTextFields checks that the title, description and summary of the
+// objects of the document data are strings, and that numeric schema keywords
+// are numbers. The unmarshalling of the document reports other types without
+// their location, or accepts null.
+func validateTextFields(data []byte) error {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc, ok := doc.(map[string]interface{}); ok {
+		return validateTextFieldsOfObject("#", doc)
+	}
+	return nil
+}
+
+func validateTextFieldsOfObject(pointer string, object map[string]interface{}) error {
+	for _, key := range sortedMapKeys(object) {
+		value, ptr := object[key], pointer+"/"+escapeJSONPointerToken(key)
+		switch {
+		case strings.HasPrefix(key, "x-") || valueFields[key]:
+		case key == "requestBody" && (object["operationId"] != nil || object["operationRef"] != nil):
+			// Links pass arbitrary values as the request body
+		case textFields[key]:
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("%s must be a string, found %s", ptr, jsonTypeName(value))
+			}
+		case numberKeywords[key] || integerKeywords[key]:
+			if err := validateKeywordType(ptr, key, value); err != nil {
+				return err
+			}
+		case key == "callbacks":
+			if callbacks, ok := value.(map[string]interface{}); ok {
+				for _, name := range sortedMapKeys(callbacks) {
+					if err := validateTextFieldsOfNamedMap(ptr+"/"+escapeJSONPointerToken(name), callbacks[name]); err != nil {
+						return err
+					}
+				}
+			}
+		case namedMapFields[key]:
+			// Links map their parameters to arbitrary values, and the
+			// examples of schemas are lists of arbitrary values
+			if _, ok := value.(map[string]interface{}); ok && key == "parameters" && (object["operationId"] != nil || object["operationRef"] != nil) {
+				continue
+			}
+			if _, ok := value.([]interface{}); ok && key == "examples" {
+				continue
+			}
+			if err := validateTextFieldsOfNamedMap(ptr, value); err != nil {
+				return err
+			}
+		default:
+			if err := validateTextFieldsOfValue(ptr, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateTextFieldsOfNamedMap(pointer string, value interface{}) error {
+	named, ok := value.(map[string]interface{})
+	if !ok {
+		// e.g. the parameters of operations are lists
+		return validateTextFieldsOfValue(pointer, value)
+	}
+	for _, name := range sortedMapKeys(named) {
+		if object, ok := named[name].(map[string]interface{}); ok {
+			if err := validateTextFieldsOfObject(pointer+"/"+escapeJSONPointerToken(name), object); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateTextFieldsOfValue(pointer string, value interface{}) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return validateTextFieldsOfObject(pointer, value)
+	case []interface{}:
+		for i, item := range value {
+			if err := validateTextFieldsOfValue(fmt.Sprintf("%s/%d", pointer, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonTypeName returns the JSON type of a decoded JSON value, with an article.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return "a string"
+}
@@ -0,0 +1,89 @@
+package openapi3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ValidationCache stores results of (*Swagger).Validate keyed by a hash of the document.
+type ValidationCache interface {
+	// Get returns the validation result stored for key and whether it was found.
+	Get(key string) (result error, found bool)
+	// Set stores the validation result for key.
+	Set(key string, result error)
+}
+
+// NewValidationCache returns an in-memory ValidationCache which is safe for concurrent use.
+func NewValidationCache() ValidationCache {
+	return &memoryValidationCache{
+		results: make(map[string]error),
+	}
+}
+
+type memoryValidationCache struct {
+	mu      sync.RWMutex
+	results map[string]error
+}
+
+func (cache *memoryValidationCache) Get(key string) (error, bool) {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	result, found := cache.results[key]
+	return result, found
+}
+
+func (cache *memoryValidationCache) Set(key string, result error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.results[key] = result
+}
+
+// Hash returns a hex encoded SHA-256 hash of the canonical JSON serialization
+// of the document, see CanonicalJSON.
+//
+// References are serialized as such, so changes in externally referenced
+// documents do not change the hash.
+func (swagger *Swagger) Hash() (string, error) {
+	data, err := CanonicalJSON(swagger)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ValidateCached returns the result of Validate, reusing the result stored in
+// cache for a document with the same hash, ignored pointers and validated paths.
+// A nil cache, or validation options with extension validators, always validate.
+// Skipped document parts are only reported when validating, not for results
+// found in the cache.
+func (swagger *Swagger) ValidateCached(c context.Context, cache ValidationCache) error {
+	options := getValidationOptions(c)
+	if cache == nil || (options != nil && len(options.ExtensionValidators) != 0) {
+		return swagger.Validate(c)
+	}
+	key, err := swagger.Hash()
+	if err != nil {
+		return swagger.Validate(c)
+	}
+	if options != nil && len(options.IgnoredPointers) != 0 {
+		ignored := append([]string(nil), options.IgnoredPointers...)
+		sort.Strings(ignored)
+		key += " " + strings.Join(ignored, " ")
+	}
+	if options != nil && len(options.Paths) != 0 {
+		paths := append([]string(nil), options.Paths...)
+		sort.Strings(paths)
+		key += " paths: " + strings.Join(paths, " ")
+	}
+	if result, found := cache.Get(key); found {
+		return result
+	}
+	result := swagger.Validate(c)
+	cache.Set(key, result)
+	return result
+}
@@ -0,0 +1,39 @@
+package openapi3_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCached(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths: {}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	cache := openapi3.NewValidationCache()
+
+	key, err := swagger.Hash()
+	require.NoError(t, err)
+	require.NoError(t, swagger.ValidateCached(context.Background(), cache))
+	result, found := cache.Get(key)
+	require.True(t, found)
+	require.NoError(t, result)
+
+	// A cache hit returns the stored result without validating again
+	stored := errors.New("stored result")
+	cache.Set(key, stored)
+	require.Equal(t, stored, swagger.ValidateCached(context.Background(), cache))
+
+	// Editing the document changes its hash and re-validates it
+	swagger.Info.Title = ""
+	err = swagger.ValidateCached(context.Background(), cache)
+	require.Error(t, err)
+	require.NotEqual(t, stored, err)
+}
@@ -0,0 +1,111 @@
+package openapi3
+
+import (
+	"context"
+	"strings"
+)
+
+// ValidationOptions configures (*Swagger).Validate, see WithValidationOptions.
+type ValidationOptions struct {
+	// IgnoredPointers lists JSON pointer prefixes, e.g. "#/paths/~1jobs", of document
+	// parts which are not validated. They apply to the top level sections, components,
+	// paths and operations of the document.
+	IgnoredPointers []string
+	// Paths, if set, lists patterns of the paths which are validated, e.g.
+	// "/collections/**", see MatchPathPattern. Other paths are skipped like
+	// ignored pointers, while the components are still validated.
+	Paths []string
+	// Skipped, if set, is called with the pointer of every document part which
+	// isn't validated because it is ignored or its path doesn't match Paths.
+	// With a Concurrency above 1 it is called from several goroutines at once,
+	// in no particular order, so it must then be safe for concurrent use.
+	Skipped func(pointer string)
+	// ExtensionValidators validate the values of extensions of operations and
+	// schemas by extension name, e.g. "x-openeo-process-id".
+	ExtensionValidators map[string]ExtensionValidator
+	// Concurrency is the number of goroutines validating the components and
+	// paths of a document, which are independent of each other. The result is
+	// the same as when validating sequentially, which is the default. Extension
+	// validators must then be safe for concurrent use.
+	Concurrency int
+}
+
+type validationOptionsKey struct{}
+
+// WithValidationOptions returns a context which makes Validate use options.
+func WithValidationOptions(c context.Context, options *ValidationOptions) context.Context {
+	return context.WithValue(c, validationOptionsKey{}, options)
+}
+
+func getValidationOptions(c context.Context) *ValidationOptions {
+	if c == nil {
+		return nil
+	}
+	options, _ := c.Value(validationOptionsKey{}).(*ValidationOptions)
+	return options
+}
+
+// validationSkipped returns whether the document part at pointer is ignored,
+// reporting it as skipped if so.
+func validationSkipped(c context.Context, pointer string) bool {
+	options := getValidationOptions(c)
+	if options == nil {
+		return false
+	}
+	for _, prefix := range options.IgnoredPointers {
+		if !strings.HasPrefix(prefix, "#") {
+			prefix = "#" + prefix
+		}
+		prefix = strings.TrimSuffix(prefix, "/")
+		if pointer == prefix || strings.HasPrefix(pointer, prefix+"/") || prefix == "#" {
+			if options.Skipped != nil {
+				options.Skipped(pointer)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// validationPathSkipped returns whether path doesn't match the validated paths,
+// reporting the path at pointer as skipped if so.
+func validationPathSkipped(c context.Context, path string, pointer string) bool {
+	options := getValidationOptions(c)
+	if options == nil || len(options.Paths) == 0 {
+		return false
+	}
+	for _, pattern := range options.Paths {
+		if MatchPathPattern(pattern, path) {
+			return false
+		}
+	}
+	if options.Skipped != nil {
+		options.Skipped(pointer)
+	}
+	return true
+}
+
+type validationPointerKey struct{}
+
+// withValidationPointer returns a context locating the validated document part at pointer.
+func withValidationPointer(c context.Context, pointer string) context.Context {
+	if c == nil {
+		c = context.Background()
+	}
+	return context.WithValue(c, validationPointerKey{}, pointer)
+}
+
+// validationPointer returns the pointer of the validated document part extended by tokens.
+func validationPointer(c context.Context, tokens ...string) string {
+	var pointer string
+	if c != nil {
+		pointer, _ = c.Value(validationPointerKey{}).(string)
+	}
+	if pointer == "" {
+		pointer = "#"
+	}
+	for _, token := range tokens {
+		pointer += "/" + escapeJSONPointerToken(token)
+	}
+	return pointer
+}
@@ -0,0 +1,96 @@
+package openapi3_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationOptionsIgnoredPointers(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Broken: {type: string, format: unknown-format}
+    Valid: {type: string}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200':
+          description: ok
+          content: {application/json: {schema: {$ref: '#/components/schemas/Valid'}}}
+    post:
+      responses: {}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	require.Error(t, swagger.Validate(context.Background()))
+
+	var skipped []string
+	options := &openapi3.ValidationOptions{
+		IgnoredPointers: []string{"#/components/schemas/Broken", "/paths/~1jobs/post/"},
+		Skipped:         func(pointer string) { skipped = append(skipped, pointer) },
+	}
+	require.NoError(t, swagger.Validate(openapi3.WithValidationOptions(context.Background(), options)))
+	sort.Strings(skipped)
+	require.Equal(t, []string{"#/components/schemas/Broken", "#/paths/~1jobs/post"}, skipped)
+
+	// Only the ignored operation is skipped
+	options.IgnoredPointers = []string{"#/components/schemas/Broken", "#/paths/~1jobs/get"}
+	require.Error(t, swagger.Validate(openapi3.WithValidationOptions(context.Background(), options)))
+
+	// Cached results depend on the ignored pointers
+	cache := openapi3.NewValidationCache()
+	options.IgnoredPointers = []string{"#/components", "#/paths"}
+	require.NoError(t, swagger.ValidateCached(openapi3.WithValidationOptions(context.Background(), options), cache))
+	require.Error(t, swagger.ValidateCached(context.Background(), cache))
+}
+
+func TestValidationOptionsPaths(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /collections:
+    get:
+      responses: {'200': {description: ok}}
+  /collections/{collection_id}:
+    get:
+      parameters:
+      - {name: collection_id, in: path, required: true, schema: {type: string}}
+      responses: {'200': {description: ok}}
+  /jobs:
+    get:
+      responses: {}
+  /processes:
+    get:
+      responses: {}
+`)
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(spec)
+	require.NoError(t, err)
+	require.Error(t, swagger.Validate(context.Background()))
+
+	var skipped []string
+	options := &openapi3.ValidationOptions{
+		Paths:   []string{"/collections/**"},
+		Skipped: func(pointer string) { skipped = append(skipped, pointer) },
+	}
+	require.NoError(t, swagger.Validate(openapi3.WithValidationOptions(context.Background(), options)))
+	sort.Strings(skipped)
+	require.Equal(t, []string{"#/paths/~1jobs", "#/paths/~1processes"}, skipped)
+
+	options.Paths = []string{"/collections/**", "/jobs"}
+	require.Error(t, swagger.Validate(openapi3.WithValidationOptions(context.Background(), options)))
+
+	// Cached results depend on the validated paths
+	cache := openapi3.NewValidationCache()
+	options.Paths = []string{"/collections"}
+	require.NoError(t, swagger.ValidateCached(openapi3.WithValidationOptions(context.Background(), options), cache))
+	options.Paths = []string{"/jobs"}
+	require.Error(t, swagger.ValidateCached(openapi3.WithValidationOptions(context.Background(), options), cache))
+}
@@ -0,0 +1,83 @@
+package openapi3
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// validationTask validates a part of a document independently of the other parts.
+type validationTask struct {
+	// pointer locates the validated part, e.g. "#/paths/~1jobs".
+	pointer string
+	// value is the validated part, whose references make it depend on other parts.
+	value interface{}
+	run   func(c context.Context) error
+}
+
+// runValidationTasks runs tasks with up to workers goroutines. It returns the
+// error of the first failing task in order, so the result doesn't depend on the
+// number of workers.
+func runValidationTasks(c context.Context, tasks []validationTask, workers int) error {
+	if workers <= 1 {
+		for _, task := range tasks {
+			if err := task.run(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(tasks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(tasks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				errs[index] = tasks[index].run(c)
+			}
+		}()
+	}
+	for index := range tasks {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wrapValidationTasks returns tasks wrapping the errors of tasks as errors of a document section.
+func wrapValidationTasks(section string, tasks []validationTask) []validationTask {
+	wrapped := make([]validationTask, 0, len(tasks))
+	for _, task := range tasks {
+		task := task
+		wrapped = append(wrapped, validationTask{pointer: task.pointer, value: task.value, run: func(c context.Context) error {
+			if err := task.run(c); err != nil {
+				return fmt.Errorf("invalid %s: %v", section, err)
+			}
+			return nil
+		}})
+	}
+	return wrapped
+}
+
+// sortedMapKeys returns the keys of a map with string keys, sorted.
+func sortedMapKeys(m interface{}) []string {
+	value := reflect.ValueOf(m)
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
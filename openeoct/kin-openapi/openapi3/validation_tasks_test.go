@@ -0,0 +1,77 @@
+package openapi3_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+// largeSpec returns a document with n paths and component schemas, in which the
+// paths with an index in invalid have an invalid operation.
+func largeSpec(t testing.TB, n int, invalid ...int) *openapi3.Swagger {
+	var spec strings.Builder
+	spec.WriteString("openapi: 3.0.0\ninfo: {title: An API, version: v1}\ncomponents:\n  schemas:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&spec, `    Job%d:
+      type: object
+      required: [id, status]
+      properties:
+        id: {type: string, pattern: '^[\w\-\.~]+$'}
+        status: {type: string, enum: [created, queued, running, finished, error]}
+        progress: {type: number, minimum: 0, maximum: 100}
+        links: {type: array, items: {type: object, properties: {href: {type: string, format: uri}}}}
+`, i)
+	}
+	spec.WriteString("paths:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&spec, `  /jobs%d/{job_id}:
+    parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+    get:
+      responses:
+        '200': {description: ok, content: {application/json: {schema: {$ref: '#/components/schemas/Job%d'}}}}
+`, i, i)
+		for _, j := range invalid {
+			if i == j {
+				spec.WriteString("    post:\n      responses: {}\n")
+			}
+		}
+	}
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec.String()))
+	require.NoError(t, err)
+	return swagger
+}
+
+func TestValidateConcurrently(t *testing.T) {
+	concurrently := openapi3.WithValidationOptions(context.Background(), &openapi3.ValidationOptions{Concurrency: 8})
+
+	swagger := largeSpec(t, 50)
+	require.NoError(t, swagger.Validate(context.Background()))
+	require.NoError(t, swagger.Validate(concurrently))
+
+	// The first error in document order is reported, whichever task finishes first
+	swagger = largeSpec(t, 50, 7, 42)
+	sequentialErr := swagger.Validate(context.Background())
+	require.Error(t, sequentialErr)
+	require.Contains(t, sequentialErr.Error(), "invalid paths")
+	for i := 0; i < 10; i++ {
+		require.Equal(t, sequentialErr, swagger.Validate(concurrently))
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	swagger := largeSpec(b, 500)
+	for _, concurrency := range []int{1, 4} {
+		c := openapi3.WithValidationOptions(context.Background(), &openapi3.ValidationOptions{Concurrency: concurrency})
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := swagger.Validate(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
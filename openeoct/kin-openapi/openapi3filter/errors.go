@@ -17,6 +17,10 @@ var (
 type RouteError struct {
 	Route  Route
 	Reason string
+	// EnumParameter is the path parameter whose enum the value in the path
+	// isn't one of, see (*Router).WithEnumRouting. It's nil if the path doesn't
+	// match structurally.
+	EnumParameter *openapi3.Parameter
 }
 
 func (err *RouteError) Error() string {
@@ -58,6 +62,11 @@ func (err *RequestError) Error() string {
 	}
 }
 
+// Unwrap returns the underlying error, e.g. a *openapi3.SchemaError.
+func (err *RequestError) Unwrap() error {
+	return err.Err
+}
+
 type ResponseError struct {
 	Input  *ResponseValidationInput
 	Reason string
@@ -76,6 +85,11 @@ func (err *ResponseError) Error() string {
 	return reason
 }
 
+// Unwrap returns the underlying error, e.g. a *openapi3.SchemaError.
+func (err *ResponseError) Unwrap() error {
+	return err.Err
+}
+
 type SecurityRequirementsError struct {
 	SecurityRequirements openapi3.SecurityRequirements
 	Errors               []error
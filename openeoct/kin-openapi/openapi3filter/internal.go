@@ -1,6 +1,7 @@
 package openapi3filter
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -11,3 +12,42 @@ func parseMediaType(contentType string) string {
 	}
 	return contentType[:i]
 }
+
+// acceptsMediaType returns whether the media type of contentType is acceptable
+// under the media ranges of the Accept header, e.g. "application/json, image/*;q=0.5".
+// The most specific matching range decides, a range with q=0 excludes the media
+// type. Everything is acceptable without Accept header.
+func acceptsMediaType(accept string, contentType string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(parseMediaType(contentType)))
+	i := strings.IndexByte(mediaType, '/')
+	if i < 0 {
+		return false
+	}
+	mainType := mediaType[:i]
+	specificity, quality := 0, 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		switch r := strings.ToLower(strings.TrimSpace(params[0])); {
+		case r == mediaType && specificity < 3:
+			specificity = 3
+		case r == mainType+"/*" && specificity < 2:
+			specificity = 2
+		case r == "*/*" && specificity < 1:
+			specificity = 1
+		default:
+			continue
+		}
+		quality = 1
+		for _, param := range params[1:] {
+			if kv := strings.SplitN(strings.TrimSpace(param), "=", 2); len(kv) == 2 && strings.ToLower(kv[0]) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+	}
+	return quality > 0
+}
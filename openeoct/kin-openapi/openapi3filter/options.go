@@ -2,13 +2,68 @@ package openapi3filter
 
 import (
 	"context"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
 )
 
 var DefaultOptions = &Options{}
 
 type Options struct {
-	ExcludeRequestBody    bool
-	ExcludeResponseBody   bool
+	ExcludeRequestBody  bool
+	ExcludeResponseBody bool
+	// IncludeResponseStatus reports responses with a status for which the operation
+	// declares no response, neither for the exact code, its range nor as default.
 	IncludeResponseStatus bool
-	AuthenticationFunc    func(c context.Context, input *AuthenticationInput) error
+	// IncludeResponseAccept reports responses with a Content-Type which isn't
+	// acceptable under the Accept header of the request.
+	IncludeResponseAccept bool
+	// MatchResponseProfile selects the content of responses with a "profile"
+	// parameter in their Content-Type by the profile, see
+	// openapi3.Content.GetProfile, and reports profiles no content declares.
+	MatchResponseProfile bool
+	// PartialRequestBody skips "required" checks of request body properties, e.g. for
+	// PATCH requests sending only the changed properties. Present properties are
+	// still validated.
+	PartialRequestBody bool
+	// DisableFormatValidation skips "format" checks of values, other constraints are still enforced.
+	DisableFormatValidation bool
+	// FormatWarnings, if set, is called with the values which don't have a
+	// format of severity openapi3.FormatSeverityWarning.
+	FormatWarnings func(err *openapi3.SchemaError)
+	// SchemaCoverage, if set, records which schema parts validated values exercised.
+	SchemaCoverage *openapi3.SchemaCoverage
+	// MaxValidationDepth limits the nesting of schemas applied to a value,
+	// openapi3.DefaultMaxValidationDepth if 0.
+	MaxValidationDepth int
+	AuthenticationFunc func(c context.Context, input *AuthenticationInput) error
+}
+
+// schemaValidationOptions returns the options for validating values against schemas.
+func (options *Options) schemaValidationOptions() []openapi3.SchemaValidationOption {
+	if options == nil {
+		options = DefaultOptions
+	}
+	var opts []openapi3.SchemaValidationOption
+	if options.DisableFormatValidation {
+		opts = append(opts, openapi3.DisableFormatValidation())
+	}
+	if options.FormatWarnings != nil {
+		opts = append(opts, openapi3.FormatWarnings(options.FormatWarnings))
+	}
+	if options.SchemaCoverage != nil {
+		opts = append(opts, openapi3.WithSchemaCoverage(options.SchemaCoverage))
+	}
+	if options.MaxValidationDepth > 0 {
+		opts = append(opts, openapi3.MaxValidationDepth(options.MaxValidationDepth))
+	}
+	return opts
+}
+
+// requestBodyValidationOptions returns the options for validating request bodies against schemas.
+func (options *Options) requestBodyValidationOptions() []openapi3.SchemaValidationOption {
+	opts := options.schemaValidationOptions()
+	if options != nil && options.PartialRequestBody {
+		opts = append(opts, openapi3.DisableRequiredValidation())
+	}
+	return opts
 }
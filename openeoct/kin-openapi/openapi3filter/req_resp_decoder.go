@@ -235,6 +235,53 @@ func decodeStyledParameter(param *openapi3.Parameter, input *RequestValidationIn
 	return decodeValue(dec, param.Name, sm, param.Schema, param.Required)
 }
 
+// DecodeParameterValue returns the value of a parameter serialized as the raw
+// strings, applying the style, explode and schema type of the parameter, or
+// decoding its content. Raw are the values of the parameter as they appear in a
+// request, i.e. several values only for exploded arrays in query strings. For
+// query parameters serialized as several keys, i.e. exploded form and deepObject
+// objects, the single raw string is the query string of the keys, e.g.
+// "role=admin&firstName=Alex".
+// The function returns nil without raw values and ParseError when a raw value is invalid.
+func DecodeParameterValue(param *openapi3.Parameter, raw ...string) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if param.Schema == nil {
+		value, _, err := defaultContentParameterDecoder(param, raw)
+		return value, err
+	}
+	if param.Schema.Value == nil {
+		return nil, fmt.Errorf("parameter %q has an unresolved schema", param.Name)
+	}
+	sm, err := param.SerializationMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	var dec valueDecoder
+	switch param.In {
+	case openapi3.ParameterInPath:
+		dec = &pathParamDecoder{pathParams: map[string]string{paramKey(param.Name, sm): raw[0]}}
+	case openapi3.ParameterInQuery:
+		values := url.Values{param.Name: raw}
+		if param.Schema.Value.Type == "object" && (sm.Style == "deepObject" || sm.Style == "form" && sm.Explode) {
+			if values, err = url.ParseQuery(raw[0]); err != nil {
+				return nil, &ParseError{Kind: KindInvalidFormat, Value: raw[0], Reason: "a query string is expected", Cause: err}
+			}
+		}
+		dec = &urlValuesDecoder{values: values}
+	case openapi3.ParameterInHeader:
+		dec = &headerParamDecoder{header: http.Header{http.CanonicalHeaderKey(param.Name): raw[:1]}}
+	case openapi3.ParameterInCookie:
+		dec = &cookieParamDecoder{req: &http.Request{Header: http.Header{"Cookie": {param.Name + "=" + raw[0]}}}}
+	default:
+		return nil, fmt.Errorf("unsupported parameter's 'in': %s", param.In)
+	}
+
+	return decodeValue(dec, param.Name, sm, param.Schema, param.Required)
+}
+
 func decodeValue(dec valueDecoder, param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef, required bool) (interface{}, error) {
 	var decodeFn func(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) (interface{}, error)
 
@@ -495,11 +542,40 @@ func (d *urlValuesDecoder) DecodeArray(param string, sm *openapi3.SerializationM
 		case "pipeDelimited":
 			delim = "|"
 		}
-		values = strings.Split(values[0], delim)
+		return parseDelimitedArray(values[0], delim, schema)
 	}
 	return parseArray(values, schema)
 }
 
+// parseDelimitedArray returns an array parsed from the items of raw separated by delim.
+// An empty raw value is an empty array, and a trailing delimiter is invalid. Empty items
+// are empty strings for arrays of strings, and invalid otherwise.
+func parseDelimitedArray(raw, delim string, schema *openapi3.SchemaRef) ([]interface{}, error) {
+	if raw == "" {
+		return []interface{}{}, nil
+	}
+	if strings.HasSuffix(raw, delim) {
+		return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: fmt.Sprintf("a trailing %q delimiter", delim)}
+	}
+	items := strings.Split(raw, delim)
+	stringItems := schema.Value.Items != nil && schema.Value.Items.Value != nil && schema.Value.Items.Value.Type == "string"
+	for i, item := range items {
+		if item == "" && !stringItems {
+			return nil, &ParseError{path: []interface{}{i}, Cause: &ParseError{Kind: KindInvalidFormat, Reason: "an empty item"}}
+		}
+	}
+	value, err := parseArray(items, schema)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if item == "" {
+			value[i] = ""
+		}
+	}
+	return value, nil
+}
+
 func (d *urlValuesDecoder) DecodeObject(param string, sm *openapi3.SerializationMethod, schema *openapi3.SchemaRef) (map[string]interface{}, error) {
 	var propsFn func(url.Values) (map[string]string, error)
 	switch sm.Style {
@@ -776,6 +852,10 @@ type EncodingFn func(partName string) *openapi3.Encoding
 // An implementation must return a value that is a primitive, []interface{}, or map[string]interface{}.
 type BodyDecoder func(io.Reader, http.Header, *openapi3.SchemaRef, EncodingFn) (interface{}, error)
 
+// binaryMediaType is the media type of bodies with binary data, which are
+// validated by their size only, see (*openapi3.Schema).VisitBinary.
+const binaryMediaType = "application/octet-stream"
+
 // bodyDecoders contains decoders for supported content types of a body.
 // By default, there is content type "application/json" is supported only.
 var bodyDecoders = make(map[string]BodyDecoder)
@@ -828,7 +908,7 @@ func init() {
 	RegisterBodyDecoder("application/json", jsonBodyDecoder)
 	RegisterBodyDecoder("application/x-www-form-urlencoded", urlencodedBodyDecoder)
 	RegisterBodyDecoder("multipart/form-data", multipartBodyDecoder)
-	RegisterBodyDecoder("application/octet-stream", FileBodyDecoder)
+	RegisterBodyDecoder(binaryMediaType, FileBodyDecoder)
 }
 
 func plainBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
@@ -958,7 +1038,7 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 		}
 
 		var value interface{}
-		if value, err = decodeBody(part, http.Header(part.Header), valueSchema, subEncFn); err != nil {
+		if value, err = decodeMultipartPart(part, valueSchema, enc, subEncFn); err != nil {
 			if v, ok := err.(*ParseError); ok {
 				return nil, &ParseError{path: []interface{}{name}, Cause: v}
 			}
@@ -993,6 +1073,81 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 	return obj, nil
 }
 
+// decodeMultipartPart decodes a part of a multipart body.
+// The content types allowed for the part are the ones of its encoding or else the
+// contentMediaType of its schema. A part without Content-Type header defaults to
+// the first allowed one, or to a content type derived from the schema type.
+func decodeMultipartPart(part *multipart.Part, schema *openapi3.SchemaRef, enc *openapi3.Encoding, encFn EncodingFn) (interface{}, error) {
+	var allowed []string
+	if enc != nil && enc.ContentType != "" {
+		for _, v := range strings.Split(enc.ContentType, ",") {
+			allowed = append(allowed, strings.TrimSpace(v))
+		}
+	} else if schema.Value.ContentMediaType != "" {
+		allowed = []string{schema.Value.ContentMediaType}
+	}
+
+	header := http.Header(part.Header)
+	contentType := strings.ToLower(strings.TrimSpace(parseMediaType(header.Get("Content-Type"))))
+	if contentType == "" {
+		if len(allowed) != 0 && !strings.Contains(allowed[0], "*") {
+			contentType = parseMediaType(allowed[0])
+		} else {
+			contentType = defaultPartContentType(schema.Value)
+		}
+		header = http.Header{"Content-Type": {contentType}}
+	} else if len(allowed) != 0 && !matchesMediaRanges(contentType, allowed) {
+		return nil, &ParseError{
+			Kind:   KindUnsupportedFormat,
+			Reason: fmt.Sprintf("part content type %q is not one of %q", contentType, strings.Join(allowed, ", ")),
+		}
+	}
+
+	// Binary parts, and strings holding encoded content, are kept as they are.
+	if v := schema.Value; v.Type == "string" && (v.Format == "binary" || v.ContentMediaType != "") {
+		return FileBodyDecoder(part, header, schema, encFn)
+	}
+	value, err := decodeBody(part, header, schema, encFn)
+	if err != nil {
+		return nil, err
+	}
+	// Form fields are sent as plain text, also when the schema describes numbers or booleans.
+	if raw, ok := value.(string); ok && contentType == "text/plain" {
+		switch schema.Value.Type {
+		case "integer", "number", "boolean":
+			return parsePrimitive(raw, schema)
+		}
+	}
+	return value, nil
+}
+
+// defaultPartContentType returns the content type of a multipart part described by schema,
+// if the part and its encoding don't define any.
+func defaultPartContentType(schema *openapi3.Schema) string {
+	switch {
+	case schema.Type == "object" || schema.Type == "array":
+		return "application/json"
+	case schema.Type == "string" && schema.Format == "binary":
+		return "application/octet-stream"
+	default:
+		return "text/plain"
+	}
+}
+
+// matchesMediaRanges returns whether mediaType matches one of the media ranges, e.g. "image/*".
+func matchesMediaRanges(mediaType string, mediaRanges []string) bool {
+	for _, mediaRange := range mediaRanges {
+		mediaRange = strings.ToLower(strings.TrimSpace(parseMediaType(mediaRange)))
+		switch {
+		case mediaRange == "*/*", mediaRange == mediaType:
+			return true
+		case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, mediaRange[:len(mediaRange)-1]):
+			return true
+		}
+	}
+	return false
+}
+
 // FileBodyDecoder is a body decoder that decodes a file body to a string.
 func FileBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
 	data, err := ioutil.ReadAll(body)
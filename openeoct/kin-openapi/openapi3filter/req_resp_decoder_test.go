@@ -532,6 +532,42 @@ func TestDecodeParameter(t *testing.T) {
 					query: "param=foo&param=bar",
 					want:  []interface{}{"foo", "bar"},
 				},
+				{
+					name:  "pipeDelimited empty",
+					param: &openapi3.Parameter{Name: "param", In: "query", Style: "pipeDelimited", Explode: noExplode, Schema: arraySchema},
+					query: "param=",
+					want:  []interface{}{},
+				},
+				{
+					name:  "pipeDelimited empty string item",
+					param: &openapi3.Parameter{Name: "param", In: "query", Style: "pipeDelimited", Explode: noExplode, Schema: arraySchema},
+					query: "param=foo||bar",
+					want:  []interface{}{"foo", "", "bar"},
+				},
+				{
+					name:  "pipeDelimited empty integer item",
+					param: &openapi3.Parameter{Name: "param", In: "query", Style: "pipeDelimited", Explode: noExplode, Schema: arrayOf(integerSchema)},
+					query: "param=1||2",
+					err:   &ParseError{path: []interface{}{1}, Cause: &ParseError{Kind: KindInvalidFormat}},
+				},
+				{
+					name:  "pipeDelimited trailing delimiter",
+					param: &openapi3.Parameter{Name: "param", In: "query", Style: "pipeDelimited", Explode: noExplode, Schema: arraySchema},
+					query: "param=foo|bar|",
+					err:   &ParseError{Kind: KindInvalidFormat, Value: "foo|bar|"},
+				},
+				{
+					name:  "spaceDelimited numbers",
+					param: &openapi3.Parameter{Name: "param", In: "query", Style: "spaceDelimited", Explode: noExplode, Schema: arrayOf(numberSchema)},
+					query: "param=-180 -90 180 90",
+					want:  []interface{}{float64(-180), float64(-90), float64(180), float64(90)},
+				},
+				{
+					name:  "spaceDelimited trailing delimiter",
+					param: &openapi3.Parameter{Name: "param", In: "query", Style: "spaceDelimited", Explode: noExplode, Schema: arrayOf(numberSchema)},
+					query: "param=1 2 ",
+					err:   &ParseError{Kind: KindInvalidFormat, Value: "1 2 "},
+				},
 				{
 					name:  "default",
 					param: &openapi3.Parameter{Name: "param", In: "query", Schema: arraySchema},
@@ -953,6 +989,118 @@ func TestDecodeParameter(t *testing.T) {
 	}
 }
 
+func TestDecodeParameterValue(t *testing.T) {
+	var (
+		explode        = func(b bool) *bool { return &b }(true)
+		noExplode      = func(b bool) *bool { return &b }(false)
+		integerSchema  = openapi3.NewIntegerSchema().NewRef()
+		stringSchema   = openapi3.NewStringSchema().NewRef()
+		intArraySchema = openapi3.NewArraySchema().WithItems(openapi3.NewIntegerSchema()).NewRef()
+		objectSchema   = openapi3.NewObjectSchema().
+				WithProperty("role", openapi3.NewStringSchema()).
+				WithProperty("level", openapi3.NewIntegerSchema()).NewRef()
+	)
+
+	testCases := []struct {
+		name  string
+		param *openapi3.Parameter
+		raw   []string
+		want  interface{}
+		err   error
+	}{
+		{
+			name:  "no value",
+			param: &openapi3.Parameter{Name: "limit", In: "query", Schema: integerSchema},
+			want:  nil,
+		},
+		{
+			name:  "query integer",
+			param: &openapi3.Parameter{Name: "limit", In: "query", Schema: integerSchema},
+			raw:   []string{"10"},
+			want:  float64(10),
+		},
+		{
+			name:  "query integer invalid",
+			param: &openapi3.Parameter{Name: "limit", In: "query", Schema: integerSchema},
+			raw:   []string{"ten"},
+			err:   &ParseError{Kind: KindInvalidFormat, Value: "ten"},
+		},
+		{
+			name:  "path label",
+			param: &openapi3.Parameter{Name: "job_id", In: "path", Style: "label", Schema: stringSchema},
+			raw:   []string{".j-1"},
+			want:  "j-1",
+		},
+		{
+			name:  "header string",
+			param: &openapi3.Parameter{Name: "OpenEO-Identifier", In: "header", Schema: stringSchema},
+			raw:   []string{"j-1"},
+			want:  "j-1",
+		},
+		{
+			name:  "cookie integer",
+			param: &openapi3.Parameter{Name: "session", In: "cookie", Schema: integerSchema},
+			raw:   []string{"42"},
+			want:  float64(42),
+		},
+		{
+			name:  "path simple array",
+			param: &openapi3.Parameter{Name: "ids", In: "path", Schema: intArraySchema},
+			raw:   []string{"1,2,3"},
+			want:  []interface{}{float64(1), float64(2), float64(3)},
+		},
+		{
+			name:  "query form exploded array",
+			param: &openapi3.Parameter{Name: "ids", In: "query", Explode: explode, Schema: intArraySchema},
+			raw:   []string{"1", "2"},
+			want:  []interface{}{float64(1), float64(2)},
+		},
+		{
+			name:  "query pipeDelimited array",
+			param: &openapi3.Parameter{Name: "ids", In: "query", Style: "pipeDelimited", Explode: noExplode, Schema: intArraySchema},
+			raw:   []string{"1|2"},
+			want:  []interface{}{float64(1), float64(2)},
+		},
+		{
+			name:  "header simple object",
+			param: &openapi3.Parameter{Name: "X-User", In: "header", Schema: objectSchema},
+			raw:   []string{"role,admin,level,3"},
+			want:  map[string]interface{}{"role": "admin", "level": float64(3)},
+		},
+		{
+			name:  "query form exploded object",
+			param: &openapi3.Parameter{Name: "user", In: "query", Explode: explode, Schema: objectSchema},
+			raw:   []string{"role=admin&level=3"},
+			want:  map[string]interface{}{"role": "admin", "level": float64(3)},
+		},
+		{
+			name:  "query deepObject",
+			param: &openapi3.Parameter{Name: "user", In: "query", Style: "deepObject", Explode: explode, Schema: objectSchema},
+			raw:   []string{"user[role]=admin&user[level]=3"},
+			want:  map[string]interface{}{"role": "admin", "level": float64(3)},
+		},
+		{
+			name: "query content",
+			param: &openapi3.Parameter{Name: "filter", In: "query",
+				Content: openapi3.NewContentWithJSONSchema(openapi3.NewObjectSchema())},
+			raw:  []string{`{"role":"admin"}`},
+			want: map[string]interface{}{"role": "admin"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeParameterValue(tc.param, tc.raw...)
+			if tc.err != nil {
+				require.Error(t, err)
+				require.True(t, matchParseError(err, tc.err), "got error:\n%v\nwant error:\n%v", err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestDecodeBody(t *testing.T) {
 	boolPtr := func(b bool) *bool { return &b }
 
@@ -1005,6 +1153,27 @@ func TestDecodeBody(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	multipartEncoding, multipartMimeEncoding, err := newTestMultipartForm([]*testFormPart{
+		{name: "title", data: strings.NewReader("NDVI")},
+		{name: "count", data: strings.NewReader("3")},
+		{name: "meta", data: strings.NewReader(`{"epsg":4326}`)},
+		{name: "asset", contentType: "image/png", data: strings.NewReader("png"), filename: "ndvi.png"},
+	})
+	require.NoError(t, err)
+	multipartEncodingErr, multipartMimeEncodingErr, err := newTestMultipartForm([]*testFormPart{
+		{name: "asset", contentType: "text/csv", data: strings.NewReader("a,b"), filename: "ndvi.csv"},
+	})
+	require.NoError(t, err)
+	multipartContentMediaTypeErr, multipartMimeContentMediaTypeErr, err := newTestMultipartForm([]*testFormPart{
+		{name: "asset", contentType: "text/csv", data: strings.NewReader("a,b"), filename: "ndvi.csv"},
+	})
+	require.NoError(t, err)
+	multipartBinaryPart := openapi3.NewObjectSchema().
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("count", openapi3.NewIntegerSchema()).
+		WithProperty("meta", openapi3.NewObjectSchema().WithProperty("epsg", openapi3.NewIntegerSchema())).
+		WithProperty("asset", &openapi3.Schema{Type: "string", ContentMediaType: "image/png"})
+
 	testCases := []struct {
 		name     string
 		mime     string
@@ -1124,6 +1293,33 @@ func TestDecodeBody(t *testing.T) {
 			want:    map[string]interface{}{"a": "a1", "x": "x1"},
 			wantErr: &ParseError{Kind: KindOther},
 		},
+		{
+			name:   "multipartEncoding",
+			mime:   multipartMimeEncoding,
+			body:   multipartEncoding,
+			schema: multipartBinaryPart,
+			encoding: map[string]*openapi3.Encoding{
+				"meta": {ContentType: "application/json"},
+			},
+			want: map[string]interface{}{"title": "NDVI", "count": float64(3), "meta": map[string]interface{}{"epsg": float64(4326)}, "asset": "png"},
+		},
+		{
+			name:   "multipartEncodingContentTypeMismatch",
+			mime:   multipartMimeEncodingErr,
+			body:   multipartEncodingErr,
+			schema: multipartBinaryPart,
+			encoding: map[string]*openapi3.Encoding{
+				"asset": {ContentType: "image/png, image/jpeg"},
+			},
+			wantErr: &ParseError{path: []interface{}{"asset"}, Cause: &ParseError{Kind: KindUnsupportedFormat}},
+		},
+		{
+			name:    "multipartContentMediaTypeMismatch",
+			mime:    multipartMimeContentMediaTypeErr,
+			body:    multipartContentMediaTypeErr,
+			schema:  multipartBinaryPart,
+			wantErr: &ParseError{path: []interface{}{"asset"}, Cause: &ParseError{Kind: KindUnsupportedFormat}},
+		},
 		{
 			name: "file",
 			mime: "application/octet-stream",
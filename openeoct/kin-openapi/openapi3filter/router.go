@@ -55,8 +55,11 @@ func (routers Routers) FindRoute(method string, url *url.URL) (*Router, *Route,
 
 // Router maps a HTTP request to an OpenAPI operation.
 type Router struct {
-	swagger  *openapi3.Swagger
-	pathNode *pathpattern.Node
+	swagger           *openapi3.Swagger
+	pathNode          *pathpattern.Node
+	validationCache   openapi3.ValidationCache
+	validationOptions *openapi3.ValidationOptions
+	enumRouting       bool
 }
 
 // NewRouter creates a new router.
@@ -67,6 +70,28 @@ func NewRouter() *Router {
 	return &Router{}
 }
 
+// WithValidationCache makes the router reuse validation results of
+// identical OpenAPI documents stored in cache.
+func (router *Router) WithValidationCache(cache openapi3.ValidationCache) *Router {
+	router.validationCache = cache
+	return router
+}
+
+// WithValidationOptions makes the router validate OpenAPI documents with options.
+func (router *Router) WithValidationOptions(options *openapi3.ValidationOptions) *Router {
+	router.validationOptions = options
+	return router
+}
+
+// WithEnumRouting makes routes only match paths whose values of path parameters
+// with an enum schema are enum values, e.g. "/tiles/L1" but not "/tiles/L9" for
+// "/tiles/{level}" with the enum [L1, L2]. Such paths fail with a RouteError
+// having the EnumParameter.
+func (router *Router) WithEnumRouting() *Router {
+	router.enumRouting = true
+	return router
+}
+
 // WithSwaggerFromFile loads the Swagger file and adds it using WithSwagger.
 // Panics on any error.
 func (router *Router) WithSwaggerFromFile(path string) *Router {
@@ -96,7 +121,11 @@ func (router *Router) AddSwaggerFromFile(path string) error {
 
 // AddSwagger adds all operations in the OpenAPI specification.
 func (router *Router) AddSwagger(swagger *openapi3.Swagger) error {
-	if err := swagger.Validate(context.TODO()); err != nil {
+	c := context.TODO()
+	if router.validationOptions != nil {
+		c = openapi3.WithValidationOptions(c, router.validationOptions)
+	}
+	if err := swagger.ValidateCached(c, router.validationCache); err != nil {
 		return fmt.Errorf("Validating Swagger failed: %v", err)
 	}
 	router.swagger = swagger
@@ -212,7 +241,83 @@ func (router *Router) FindRoute(method string, url *url.URL) (*Route, map[string
 		if strings.HasSuffix(key, "*") {
 			key = key[:len(key)-1]
 		}
+		key = strings.TrimPrefix(key, "+")
 		pathParams[key] = value
 	}
+	if router.enumRouting && route != nil {
+		if parameter := enumMismatch(route, pathParams); parameter != nil {
+			return nil, nil, &RouteError{
+				Route:         *route,
+				Reason:        fmt.Sprintf("Path parameter '%s' value '%s' is not one of the enum values", parameter.Name, pathParams[parameter.Name]),
+				EnumParameter: parameter,
+			}
+		}
+	}
 	return route, pathParams, nil
 }
+
+// enumMismatch returns the path parameter of the route with an enum schema
+// which its value isn't one of, or nil if there is none. Parameters of the
+// operation override those of the path item.
+func enumMismatch(route *Route, pathParams map[string]string) *openapi3.Parameter {
+	var parameters openapi3.Parameters
+	if route.PathItem != nil {
+		parameters = append(parameters, route.PathItem.Parameters...)
+	}
+	if route.Operation != nil {
+		parameters = append(parameters, route.Operation.Parameters...)
+	}
+	byName := make(map[string]*openapi3.Parameter, len(parameters))
+	var names []string
+	for _, ref := range parameters {
+		if ref == nil || ref.Value == nil || ref.Value.In != openapi3.ParameterInPath {
+			continue
+		}
+		if byName[ref.Value.Name] == nil {
+			names = append(names, ref.Value.Name)
+		}
+		byName[ref.Value.Name] = ref.Value
+	}
+	for _, name := range names {
+		parameter := byName[name]
+		value, ok := pathParams[name]
+		if !ok || parameter.Schema == nil || parameter.Schema.Value == nil || len(parameter.Schema.Value.Enum) == 0 {
+			continue
+		}
+		matched := false
+		for _, member := range parameter.Schema.Value.Enum {
+			if fmt.Sprint(member) == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return parameter
+		}
+	}
+	return nil
+}
+
+// FindOperation returns the operation matching the method and URL, which is
+// either a path like "/jobs/j-1" or an absolute URL, and the values of its path
+// parameters by name. The values are the captured parts of the path, which
+// aren't deserialized according to the parameters, e.g. to numbers or arrays.
+func (router *Router) FindOperation(method string, rawURL string) (*openapi3.Operation, map[string]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, &RouteError{
+			Route:  Route{Swagger: router.swagger},
+			Reason: fmt.Sprintf("Invalid URL: %v", err),
+		}
+	}
+	method = strings.ToUpper(method)
+	route, pathParams, err := router.FindRoute(method, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	if route != nil {
+		return route.Operation, pathParams, nil
+	}
+	// Routes added with AddRoute are only found by their path
+	return router.swagger.Paths[u.Path].GetOperation(method), pathParams, nil
+}
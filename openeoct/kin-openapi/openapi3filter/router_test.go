@@ -1,7 +1,9 @@
 package openapi3filter_test
 
 import (
+	"context"
 	"net/http"
+	"net/url"
 	"sort"
 	"testing"
 
@@ -175,3 +177,118 @@ func TestRouter(t *testing.T) {
 		require.Nil(t, pathParams)
 	}
 }
+
+func TestRouterCatchAllParameter(t *testing.T) {
+	fileGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	filesGET := &openapi3.Operation{
+		Parameters: openapi3.Parameters{
+			&openapi3.ParameterRef{Value: openapi3.NewPathParameter("path").
+				WithSchema(openapi3.NewStringSchema().WithPattern(`^[\w./-]+$`))},
+		},
+		Responses: openapi3.NewResponses(),
+	}
+	swagger := &openapi3.Swagger{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "MyAPI", Version: "0.1"},
+		Paths: openapi3.Paths{
+			"/files/{path}/meta": &openapi3.PathItem{Get: fileGET},
+			"/files/{+path}":     &openapi3.PathItem{Get: filesGET},
+		},
+	}
+	router := openapi3filter.NewRouter().WithSwagger(swagger)
+
+	req, err := http.NewRequest(http.MethodGet, "/files/folder/sub/ndvi.tif", nil)
+	require.NoError(t, err)
+	route, pathParams, err := router.FindRoute(req.Method, req.URL)
+	require.NoError(t, err)
+	require.Equal(t, filesGET, route.Operation)
+	require.Equal(t, map[string]string{"path": "folder/sub/ndvi.tif"}, pathParams)
+	require.NoError(t, openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+	}))
+
+	route, _, err = router.FindRoute(http.MethodGet, &url.URL{Path: "/files/ndvi.tif/meta"})
+	require.NoError(t, err)
+	require.Equal(t, fileGET, route.Operation)
+
+	req, err = http.NewRequest(http.MethodGet, "/files/folder/ndvi%20copy.tif", nil)
+	require.NoError(t, err)
+	route, pathParams, err = router.FindRoute(req.Method, req.URL)
+	require.NoError(t, err)
+	require.Error(t, openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+	}))
+}
+
+func TestRouterFindOperation(t *testing.T) {
+	jobGET := &openapi3.Operation{Responses: openapi3.NewResponses()}
+	swagger := &openapi3.Swagger{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "MyAPI", Version: "0.1"},
+		Paths: openapi3.Paths{
+			"/jobs/{job_id}/results/{asset}": &openapi3.PathItem{Get: jobGET},
+		},
+	}
+	router := openapi3filter.NewRouter().WithSwagger(swagger)
+
+	operation, pathParams, err := router.FindOperation("get", "https://openeo.example/jobs/j-1/results/ndvi%20copy.tif?partial=true")
+	require.NoError(t, err)
+	require.Equal(t, jobGET, operation)
+	require.Equal(t, map[string]string{"job_id": "j-1", "asset": "ndvi copy.tif"}, pathParams)
+
+	_, _, err = router.FindOperation(http.MethodDelete, "/jobs/j-1/results/ndvi.tif")
+	require.Error(t, err)
+	_, _, err = router.FindOperation(http.MethodGet, "/jobs/j-1")
+	require.EqualError(t, err, "Path was not found")
+	_, _, err = router.FindOperation(http.MethodGet, "%zz")
+	require.Error(t, err)
+}
+
+func TestRouterEnumRouting(t *testing.T) {
+	tileGET := &openapi3.Operation{
+		Parameters: openapi3.Parameters{
+			&openapi3.ParameterRef{Value: openapi3.NewPathParameter("zoom").
+				WithSchema(openapi3.NewIntegerSchema().WithEnum(float64(10), float64(11)))},
+		},
+		Responses: openapi3.NewResponses(),
+	}
+	swagger := &openapi3.Swagger{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "MyAPI", Version: "0.1"},
+		Paths: openapi3.Paths{
+			"/tiles/{level}/{zoom}": &openapi3.PathItem{
+				Parameters: openapi3.Parameters{
+					&openapi3.ParameterRef{Value: openapi3.NewPathParameter("level").
+						WithSchema(openapi3.NewStringSchema().WithEnum("L1", "L2"))},
+				},
+				Get: tileGET,
+			},
+		},
+	}
+
+	router := openapi3filter.NewRouter().WithSwagger(swagger)
+	route, pathParams, err := router.FindRoute(http.MethodGet, &url.URL{Path: "/tiles/L9/10"})
+	require.NoError(t, err)
+	require.Equal(t, tileGET, route.Operation)
+	require.Equal(t, map[string]string{"level": "L9", "zoom": "10"}, pathParams)
+
+	router = openapi3filter.NewRouter().WithEnumRouting().WithSwagger(swagger)
+	route, _, err = router.FindRoute(http.MethodGet, &url.URL{Path: "/tiles/L2/11"})
+	require.NoError(t, err)
+	require.Equal(t, tileGET, route.Operation)
+
+	_, _, err = router.FindRoute(http.MethodGet, &url.URL{Path: "/tiles/L9/10"})
+	require.EqualError(t, err, "Path parameter 'level' value 'L9' is not one of the enum values")
+	require.Equal(t, "level", err.(*openapi3filter.RouteError).EnumParameter.Name)
+	require.Equal(t, "/tiles/{level}/{zoom}", err.(*openapi3filter.RouteError).Route.Path)
+	_, _, err = router.FindRoute(http.MethodGet, &url.URL{Path: "/tiles/L1/12"})
+	require.Equal(t, "zoom", err.(*openapi3filter.RouteError).EnumParameter.Name)
+
+	_, _, err = router.FindRoute(http.MethodGet, &url.URL{Path: "/files/L1/10"})
+	require.EqualError(t, err, "Path was not found")
+	require.Nil(t, err.(*openapi3filter.RouteError).EnumParameter)
+}
@@ -121,7 +121,7 @@ func ValidateParameter(c context.Context, input *RequestValidationInput, paramet
 		// A parameter's schema is not defined so skip validation of a parameter's value.
 		return nil
 	}
-	if err = schema.VisitJSON(value); err != nil {
+	if err = schema.VisitJSON(value, input.Options.schemaValidationOptions()...); err != nil {
 		return &RequestError{Input: input, Parameter: parameter, Err: err}
 	}
 	return nil
@@ -180,6 +180,19 @@ func ValidateRequestBody(c context.Context, input *RequestValidationInput, reque
 		return nil
 	}
 
+	if parseMediaType(inputMIME) == binaryMediaType {
+		// Binary data has no JSON structure, only its size is validated
+		if err := contentType.Schema.Value.VisitBinary(data, input.Options.requestBodyValidationOptions()...); err != nil {
+			return &RequestError{
+				Input:       input,
+				RequestBody: requestBody,
+				Reason:      "doesn't match the schema",
+				Err:         err,
+			}
+		}
+		return nil
+	}
+
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
 	value, err := decodeBody(bytes.NewReader(data), req.Header, contentType.Schema, encFn)
 	if err != nil {
@@ -192,7 +205,7 @@ func ValidateRequestBody(c context.Context, input *RequestValidationInput, reque
 	}
 
 	// Validate JSON with the schema
-	if err := contentType.Schema.Value.VisitJSON(value); err != nil {
+	if err := contentType.Schema.Value.VisitJSON(value, input.Options.requestBodyValidationOptions()...); err != nil {
 		return &RequestError{
 			Input:       input,
 			RequestBody: requestBody,
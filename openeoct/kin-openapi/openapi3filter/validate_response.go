@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
 )
@@ -52,17 +53,18 @@ func ValidateResponse(c context.Context, input *ResponseValidationInput) error {
 	if len(responses) == 0 {
 		return nil
 	}
-	responseRef := responses.Get(status) // Response
-	if responseRef == nil {
-		responseRef = responses.Default() // Default input
-	}
+	responseRef := responses.Status(status) // Exact, range or default response
 	if responseRef == nil {
 		// By default, status that is not documented is allowed.
 		if !options.IncludeResponseStatus {
 			return nil
 		}
 
-		return &ResponseError{Input: input, Reason: "status is not supported"}
+		return &ResponseError{
+			Input:  input,
+			Reason: "status is not supported",
+			Err:    fmt.Errorf("no response is declared for status %d, %dXX or default", status, status/100),
+		}
 	}
 	response := responseRef.Value
 	if response == nil {
@@ -82,12 +84,30 @@ func ValidateResponse(c context.Context, input *ResponseValidationInput) error {
 
 	inputMIME := input.Header.Get("Content-Type")
 	contentType := content.Get(inputMIME)
+	if options.MatchResponseProfile {
+		if profile := openapi3.MediaTypeProfile(inputMIME); profile != "" {
+			if contentType = content.GetProfile(inputMIME); contentType == nil {
+				return &ResponseError{
+					Input:  input,
+					Reason: fmt.Sprintf("input header 'Content-Type' has profile %q, which no content of the response declares: %q", profile, inputMIME),
+				}
+			}
+		}
+	}
 	if contentType == nil {
 		return &ResponseError{
 			Input:  input,
 			Reason: fmt.Sprintf("input header 'Content-Type' has unexpected value: %q", inputMIME),
 		}
 	}
+	if options.IncludeResponseAccept {
+		if accept := strings.Join(req.Header.Values("Accept"), ","); !acceptsMediaType(accept, inputMIME) {
+			return &ResponseError{
+				Input:  input,
+				Reason: fmt.Sprintf("input header 'Content-Type' has value %q, which the request header 'Accept' doesn't accept: %q", inputMIME, accept),
+			}
+		}
+	}
 
 	if contentType.Schema == nil {
 		// An operation does not contains a validation schema for responses with this status code.
@@ -118,6 +138,18 @@ func ValidateResponse(c context.Context, input *ResponseValidationInput) error {
 	// Put the data back into the response.
 	input.SetBodyBytes(data)
 
+	if parseMediaType(inputMIME) == binaryMediaType {
+		// Binary data has no JSON structure, only its size is validated
+		if err := contentType.Schema.Value.VisitBinary(data, options.schemaValidationOptions()...); err != nil {
+			return &ResponseError{
+				Input:  input,
+				Reason: "response body doesn't match the schema",
+				Err:    err,
+			}
+		}
+		return nil
+	}
+
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
 	value, err := decodeBody(bytes.NewBuffer(data), input.Header, contentType.Schema, encFn)
 	if err != nil {
@@ -129,7 +161,7 @@ func ValidateResponse(c context.Context, input *ResponseValidationInput) error {
 	}
 
 	// Validate data with the schema.
-	if err := contentType.Schema.Value.VisitJSON(value); err != nil {
+	if err := contentType.Schema.Value.VisitJSON(value, options.schemaValidationOptions()...); err != nil {
 		return &ResponseError{
 			Input:  input,
 			Reason: "response body doesn't match the schema",
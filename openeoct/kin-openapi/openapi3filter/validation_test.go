@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -449,6 +450,83 @@ func TestValidateRequestBody(t *testing.T) {
 	}
 }
 
+func TestValidateRequestBodyDisableFormatValidation(t *testing.T) {
+	body := openapi3.NewRequestBody().
+		WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewStringSchema().WithFormat("date-time")))
+	for _, disabled := range []bool{false, true} {
+		req := httptest.NewRequest(http.MethodPost, "/test", toJSON("2020-01-01"))
+		req.Header.Set("Content-Type", "application/json")
+		inp := &openapi3filter.RequestValidationInput{
+			Request: req,
+			Options: &openapi3filter.Options{DisableFormatValidation: disabled},
+		}
+		err := openapi3filter.ValidateRequestBody(context.Background(), inp, body)
+		if disabled {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestValidateRequestBodyPartial(t *testing.T) {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("budget", openapi3.NewFloat64Schema())
+	schema.Required = []string{"title", "budget"}
+	body := openapi3.NewRequestBody().WithContent(openapi3.NewContentWithJSONSchema(schema))
+	validate := func(value interface{}, partial bool) error {
+		req := httptest.NewRequest(http.MethodPatch, "/jobs/1", toJSON(value))
+		req.Header.Set("Content-Type", "application/json")
+		inp := &openapi3filter.RequestValidationInput{
+			Request: req,
+			Options: &openapi3filter.Options{PartialRequestBody: partial},
+		}
+		return openapi3filter.ValidateRequestBody(context.Background(), inp, body)
+	}
+
+	require.Error(t, validate(map[string]interface{}{"title": "NDVI"}, false))
+	require.NoError(t, validate(map[string]interface{}{"title": "NDVI"}, true))
+	require.Error(t, validate(map[string]interface{}{"budget": "free"}, true))
+}
+
+func TestValidateRequestBodyMultipart(t *testing.T) {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("file", openapi3.NewStringSchema().WithFormat("binary"))
+	schema.Required = []string{"file"}
+	body := openapi3.NewRequestBody().WithContent(openapi3.Content{
+		"multipart/form-data": openapi3.NewMediaType().WithSchema(schema),
+	})
+	newRequest := func(withFile bool) *http.Request {
+		form := &bytes.Buffer{}
+		w := multipart.NewWriter(form)
+		require.NoError(t, w.WriteField("title", "NDVI"))
+		if withFile {
+			fw, err := w.CreateFormFile("file", "ndvi.tif")
+			require.NoError(t, err)
+			_, err = fw.Write([]byte("II*"))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		req := httptest.NewRequest(http.MethodPost, "/test", form)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+
+	inp := &openapi3filter.RequestValidationInput{Request: newRequest(true)}
+	require.NoError(t, openapi3filter.ValidateRequestBody(context.Background(), inp, body))
+
+	inp = &openapi3filter.RequestValidationInput{Request: newRequest(false)}
+	err := openapi3filter.ValidateRequestBody(context.Background(), inp, body)
+	require.Error(t, err)
+	requestErr, ok := err.(*openapi3filter.RequestError)
+	require.True(t, ok)
+	schemaErr, ok := requestErr.Err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "Property 'file' is missing", schemaErr.Reason)
+}
+
 func matchReqBodyError(want, got error) bool {
 	if want == got {
 		return true
@@ -853,3 +931,169 @@ func makeAuthFunc(schemes map[string]bool) func(c context.Context, input *openap
 		return fmt.Errorf("security scheme for %q is unknown", input.SecuritySchemeName)
 	}
 }
+
+func TestValidateResponseStatusRanges(t *testing.T) {
+	operation := openapi3.NewOperation()
+	operation.Responses = openapi3.Responses{
+		"200": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithJSONSchema(openapi3.NewObjectSchema())},
+		"2XX": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithJSONSchema(openapi3.NewStringSchema())},
+		"4XX": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithJSONSchema(openapi3.NewObjectSchema().
+			WithProperty("code", openapi3.NewStringSchema()).WithProperty("message", openapi3.NewStringSchema()))},
+	}
+	validate := func(status int, body string, options *openapi3filter.Options) error {
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request: httptest.NewRequest(http.MethodGet, "/jobs", nil),
+				Route:   &openapi3filter.Route{Operation: operation},
+			},
+			Status:  status,
+			Header:  http.Header{"Content-Type": []string{"application/json"}},
+			Options: options,
+		}
+		input.SetBodyBytes([]byte(body))
+		return openapi3filter.ValidateResponse(context.Background(), input)
+	}
+
+	// Exact codes take precedence over ranges
+	require.NoError(t, validate(200, `{}`, nil))
+	require.Error(t, validate(200, `"created"`, nil))
+	require.NoError(t, validate(201, `"created"`, nil))
+	require.Error(t, validate(201, `{}`, nil))
+	require.NoError(t, validate(404, `{"code": "NotFound", "message": "Job not found"}`, nil))
+	require.Error(t, validate(404, `"Job not found"`, nil))
+
+	// Statuses without any response are only reported if requested
+	require.NoError(t, validate(500, `"Internal error"`, nil))
+	err := validate(500, `"Internal error"`, &openapi3filter.Options{IncludeResponseStatus: true})
+	require.EqualError(t, err, "status is not supported: no response is declared for status 500, 5XX or default")
+
+	operation.Responses["default"] = &openapi3.ResponseRef{Value: openapi3.NewResponse().WithJSONSchema(openapi3.NewStringSchema())}
+	require.NoError(t, validate(500, `"Internal error"`, &openapi3filter.Options{IncludeResponseStatus: true}))
+	require.Error(t, validate(404, `"Job not found"`, &openapi3filter.Options{IncludeResponseStatus: true}))
+}
+
+func TestValidateNonJSONBodies(t *testing.T) {
+	maxLength := uint64(4)
+	logSchema := openapi3.NewStringSchema().WithMinLength(1).WithPattern(`^\[\w+\] `)
+	fileSchema := &openapi3.Schema{Type: "string", Format: "binary", MinLength: 2, MaxLength: &maxLength}
+	operation := openapi3.NewOperation()
+	operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithContent(openapi3.Content{
+		"text/plain":               openapi3.NewMediaType().WithSchema(logSchema),
+		"application/octet-stream": openapi3.NewMediaType().WithSchema(fileSchema),
+	})}
+	operation.Responses = openapi3.Responses{
+		"200": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithContent(openapi3.Content{
+			"application/octet-stream": openapi3.NewMediaType().WithSchema(fileSchema),
+		})},
+	}
+	route := &openapi3filter.Route{Swagger: &openapi3.Swagger{}, PathItem: &openapi3.PathItem{Post: operation}, Operation: operation}
+	validateRequest := func(contentType string, body string) error {
+		req := httptest.NewRequest(http.MethodPost, "/files", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return openapi3filter.ValidateRequest(context.Background(), &openapi3filter.RequestValidationInput{Request: req, Route: route})
+	}
+
+	require.NoError(t, validateRequest("text/plain; charset=utf-8", "[info] job started"))
+	require.Error(t, validateRequest("text/plain", "job started"))
+
+	// Binary data is only checked by its size in bytes, as it has no JSON structure
+	require.NoError(t, validateRequest("application/octet-stream", "\xff\xfe\x00"))
+	err := validateRequest("application/octet-stream", "\xff\xfe\x00\x01\x02")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Maximum size is 4 bytes, data has 5 bytes")
+	require.Error(t, validateRequest("application/octet-stream", "\xff"))
+
+	validateResponse := func(body string) error {
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request: httptest.NewRequest(http.MethodGet, "/files", nil),
+				Route:   route,
+			},
+			Status: 200,
+			Header: http.Header{"Content-Type": []string{"application/octet-stream"}},
+		}
+		input.SetBodyBytes([]byte(body))
+		return openapi3filter.ValidateResponse(context.Background(), input)
+	}
+	require.NoError(t, validateResponse("\x89PNG"))
+	require.Error(t, validateResponse("\x89PNG\r\n"))
+}
+
+func TestValidateResponseAccept(t *testing.T) {
+	operation := openapi3.NewOperation()
+	operation.Responses = openapi3.Responses{
+		"200": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithContent(openapi3.Content{
+			"application/json": openapi3.NewMediaType().WithSchema(openapi3.NewObjectSchema()),
+			"image/png":        openapi3.NewMediaType(),
+		})},
+	}
+	route := &openapi3filter.Route{Swagger: &openapi3.Swagger{}, PathItem: &openapi3.PathItem{Get: operation}, Operation: operation}
+	validateResponse := func(accept string, contentType string, body string) error {
+		req := httptest.NewRequest(http.MethodGet, "/jobs/j-1/results/1", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req, Route: route},
+			Status:                 200,
+			Header:                 http.Header{"Content-Type": []string{contentType}},
+			Options:                &openapi3filter.Options{IncludeResponseAccept: true},
+		}
+		input.SetBodyBytes([]byte(body))
+		return openapi3filter.ValidateResponse(context.Background(), input)
+	}
+
+	require.NoError(t, validateResponse("", "application/json", "{}"))
+	require.NoError(t, validateResponse("*/*", "image/png", "\x89PNG"))
+	require.NoError(t, validateResponse("image/*", "image/png", "\x89PNG"))
+	require.NoError(t, validateResponse("text/html, application/json;q=0.9", "application/json; charset=utf-8", "{}"))
+	require.NoError(t, validateResponse("*/*;q=0.1, image/png", "image/png", "\x89PNG"))
+
+	err := validateResponse("image/*", "application/json", "{}")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `which the request header 'Accept' doesn't accept: "image/*"`)
+	require.Error(t, validateResponse("*/*, image/png;q=0", "image/png", "\x89PNG"))
+
+	// The response media type must be declared by the operation, too
+	err = validateResponse("*/*", "image/jpeg", "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `header 'Content-Type' has unexpected value: "image/jpeg"`)
+}
+
+func TestValidateResponseProfile(t *testing.T) {
+	versioned := func(required string) *openapi3.MediaType {
+		schema := openapi3.NewObjectSchema().WithProperty(required, openapi3.NewStringSchema())
+		schema.Required = []string{required}
+		return openapi3.NewMediaType().WithSchema(schema)
+	}
+	operation := openapi3.NewOperation()
+	operation.Responses = openapi3.Responses{
+		"200": &openapi3.ResponseRef{Value: openapi3.NewResponse().WithContent(openapi3.Content{
+			"application/json": openapi3.NewMediaType().WithSchema(openapi3.NewObjectSchema()),
+			`application/json; profile="https://openeo.org/v1"`: versioned("id"),
+			`application/json; profile="https://openeo.org/v2"`: versioned("job_id"),
+		})},
+	}
+	route := &openapi3filter.Route{Swagger: &openapi3.Swagger{}, PathItem: &openapi3.PathItem{Get: operation}, Operation: operation}
+	validateResponse := func(matchProfile bool, contentType string, body string) error {
+		input := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{Request: httptest.NewRequest(http.MethodGet, "/jobs/j-1", nil), Route: route},
+			Status:                 200,
+			Header:                 http.Header{"Content-Type": []string{contentType}},
+			Options:                &openapi3filter.Options{MatchResponseProfile: matchProfile},
+		}
+		input.SetBodyBytes([]byte(body))
+		return openapi3filter.ValidateResponse(context.Background(), input)
+	}
+
+	v2 := `application/json;profile="https://openeo.org/v2"`
+	require.NoError(t, validateResponse(false, v2, `{"id": "j-1"}`))
+	require.NoError(t, validateResponse(true, v2, `{"job_id": "j-1"}`))
+	err := validateResponse(true, v2, `{"id": "j-1"}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Property 'job_id' is missing")
+	require.NoError(t, validateResponse(true, "application/json", `{"id": "j-1"}`))
+
+	err = validateResponse(true, `application/json; profile="https://openeo.org/v3"`, `{}`)
+	require.EqualError(t, err, `input header 'Content-Type' has profile "https://openeo.org/v3", which no content of the response declares: "application/json; profile=\"https://openeo.org/v3\""`)
+}
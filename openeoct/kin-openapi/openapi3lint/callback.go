@@ -0,0 +1,74 @@
+package openapi3lint
+
+import (
+	"context"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-CALLBACK-RESPONSE-TARGET",
+		Severity:    SeverityWarning,
+		Description: "The $response expressions of callbacks should reference a header or a body location which a response of the operation declares.",
+		Rationale:   "The expression evaluates to nothing at runtime, so the callback is silently sent to the wrong URL or not at all. Fix the typo in the expression, or declare the header or property in the response.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          headers:
+            Location: {schema: {type: string}}
+      callbacks:
+        finished:
+          '{$response.header.OpenEO-Identifier}':
+            post:
+              responses:
+                '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          headers:
+            OpenEO-Identifier: {schema: {type: string}}
+      callbacks:
+        finished:
+          '{$response.header.OpenEO-Identifier}':
+            post:
+              responses:
+                '200': {description: ok}
+`,
+		Check: checkCallbackResponseTarget,
+	})
+}
+
+func checkCallbackResponseTarget(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		for _, name := range sortedKeys(operation.Callbacks) {
+			ref := operation.Callbacks[name]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			for _, template := range sortedKeys(*ref.Value) {
+				// Invalid expressions are reported by the validation
+				exprs, _ := openapi3.RuntimeExpressions(template)
+				for _, expr := range exprs {
+					if err := openapi3.ValidateResponseExpressionTarget(operation, expr); err != nil {
+						report(ptr+"/callbacks/"+pointerTokenEscaper.Replace(name)+"/"+pointerTokenEscaper.Replace(template),
+							"callback %q of %s %s: %v", name, method, path, err)
+					}
+				}
+			}
+		}
+	})
+}
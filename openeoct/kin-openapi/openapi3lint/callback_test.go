@@ -0,0 +1,63 @@
+package openapi3lint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackResponseTarget(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          headers:
+            Location: {schema: {type: string}}
+          content:
+            application/json:
+              schema:
+                type: object
+                additionalProperties: false
+                properties:
+                  id: {type: string}
+      callbacks:
+        finished:
+          '{$response.body#/id}?status={$response.body#/status}':
+            post:
+              responses:
+                '200': {description: ok}
+          '{$response.header.location}':
+            post:
+              responses:
+                '200': {description: ok}
+  /jobs/{job_id}:
+    delete:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '204': {description: deleted}
+      callbacks:
+        deleted:
+          '{$response.body#/id}':
+            post:
+              responses:
+                '200': {description: ok}
+`
+	issues := lintCodes(t, spec, "OAS-CALLBACK-RESPONSE-TARGET")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1jobs/post/callbacks/finished/{$response.body#~1id}?status={$response.body#~1status}", issues[0].Pointer)
+	require.Contains(t, issues[0].Message, `"$response.body#/status" references a location not in any response body schema`)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/delete/callbacks/deleted/{$response.body#~1id}", issues[1].Pointer)
+	require.Contains(t, issues[1].Message, "no response of the operation has content")
+
+	// The document is still valid
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	require.NoError(t, swagger.Validate(context.Background()))
+}
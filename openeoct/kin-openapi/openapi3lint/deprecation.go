@@ -0,0 +1,91 @@
+package openapi3lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-DEPRECATED-NO-REASON",
+		Severity:    SeverityWarning,
+		Description: "Deprecated operations, parameters and schemas should explain the deprecation with an x-deprecation-reason extension.",
+		Rationale:   "Clients only learn that something is going away, but not why, since when or what replaces it, so they can't plan the migration. Add an x-deprecation-reason, or another extension of the DeprecationExtensions setting, naming the replacement.",
+		Optional:    true,
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /process_graphs:
+    get:
+      deprecated: true
+      responses:
+        '200': {description: the process graphs}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /process_graphs:
+    get:
+      deprecated: true
+      x-deprecation-reason: Replaced by GET /processes?namespace=user.
+      responses:
+        '200': {description: the process graphs}
+`,
+		Check: checkDeprecatedNoReason,
+	})
+}
+
+func (settings *Settings) deprecationExtensions() []string {
+	if len(settings.DeprecationExtensions) == 0 {
+		return []string{"x-deprecation-reason"}
+	}
+	return settings.DeprecationExtensions
+}
+
+func checkDeprecatedNoReason(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	extensions := lintSettings(c).deprecationExtensions()
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if operation.Deprecated && !explainsDeprecation(operation.ExtensionProps, extensions) {
+			report(ptr, "operation %s %s is deprecated without a reason", method, path)
+		}
+	})
+	walkParameters(swagger, func(ptr string, parameter *openapi3.Parameter) {
+		if parameter.Deprecated && !explainsDeprecation(parameter.ExtensionProps, extensions) {
+			report(ptr, "%s parameter %q is deprecated without a reason", parameter.In, parameter.Name)
+		}
+	})
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if schema.Deprecated && !explainsDeprecation(schema.ExtensionProps, extensions) {
+			report(ptr, "schema is deprecated without a reason")
+		}
+	})
+}
+
+// explainsDeprecation returns whether the extensions include one of the
+// given extensions.
+func explainsDeprecation(props openapi3.ExtensionProps, extensions []string) bool {
+	for _, name := range extensions {
+		value, ok := props.Extensions[name]
+		if !ok {
+			continue
+		}
+		raw, ok := value.(json.RawMessage)
+		if !ok {
+			// Set programmatically
+			if text, isString := value.(string); value != nil && (!isString || text != "") {
+				return true
+			}
+			continue
+		}
+		raw = bytes.TrimSpace(raw)
+		if !bytes.Equal(raw, []byte("null")) && !bytes.Equal(raw, []byte(`""`)) {
+			return true
+		}
+	}
+	return false
+}
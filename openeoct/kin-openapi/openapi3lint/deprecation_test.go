@@ -0,0 +1,60 @@
+package openapi3lint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecatedNoReason(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /process_graphs:
+    get:
+      deprecated: true
+      parameters:
+        - {name: limit, in: query, deprecated: true, schema: {type: integer}}
+        - {name: offset, in: query, deprecated: true, x-deprecation-reason: 'Use the links instead.', schema: {type: integer}}
+      responses:
+        '200':
+          description: the process graphs
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  processes: {type: array, items: {type: object}}
+                  links: {type: array, deprecated: true, x-deprecation-reason: '', items: {type: object}}
+  /processes:
+    get:
+      deprecated: true
+      x-deprecation-reason: {replacement: 'GET /processes/{namespace}'}
+      responses:
+        '200': {description: the processes}
+`
+	issues := lintCodes(t, spec, "OAS-DEPRECATED-NO-REASON")
+	require.Len(t, issues, 3)
+	require.Equal(t, "#/paths/~1process_graphs/get", issues[0].Pointer)
+	require.Equal(t, "operation GET /process_graphs is deprecated without a reason", issues[0].Message)
+	require.Equal(t, "#/paths/~1process_graphs/get/parameters/0", issues[1].Pointer)
+	require.Equal(t, `query parameter "limit" is deprecated without a reason`, issues[1].Message)
+	require.Equal(t, "#/paths/~1process_graphs/get/responses/200/content/application~1json/schema/properties/links", issues[2].Pointer)
+
+	// Other extensions replace the default
+	settings := openapi3lint.Settings{DeprecationExtensions: []string{"x-replaced-by"}}
+	issues = lintCodesWith(t, spec, settings, "OAS-DEPRECATED-NO-REASON")
+	require.Len(t, issues, 5)
+	require.Equal(t, "#/paths/~1processes/get", issues[1].Pointer)
+	require.Equal(t, "#/paths/~1process_graphs/get/parameters/1", issues[3].Pointer)
+
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	for _, issue := range openapi3lint.Lint(context.Background(), swagger) {
+		require.NotEqual(t, "OAS-DEPRECATED-NO-REASON", issue.Code, "the rule is optional")
+	}
+}
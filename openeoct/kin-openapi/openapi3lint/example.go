@@ -0,0 +1,178 @@
+package openapi3lint
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-EXAMPLE-KEY-COLLISION",
+		Severity:    SeverityWarning,
+		Description: "Example keys should not differ only by surrounding whitespace, some tools treat them as duplicates.",
+		Rationale:   "Tools which trim example keys, e.g. documentation renderers, only keep one of the examples. Rename one of them.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema: {type: integer}
+      examples:
+        small: {value: 10}
+        'small ': {value: 20}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema: {type: integer}
+      examples:
+        small: {value: 10}
+        large: {value: 1000}
+`,
+		Check: checkExampleKeyCollision,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-OPERATION-EXAMPLES",
+		Severity:    SeverityWarning,
+		Description: "Operations should provide examples of their parameters, JSON request bodies and JSON success responses.",
+		Rationale:   "Documentation generated from the document is much easier to follow with an example of every input and of the result. Add an example to the parameter, media type or schema, and restrict the rule to the operations of the public documentation with the ExampleTags setting.",
+		Optional:    true,
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: object}}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}, example: 10}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {type: object}
+              example: {jobs: []}
+`,
+		Check: checkOperationExamples,
+	})
+}
+
+func checkOperationExamples(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	exampleTags := lintSettings(c).ExampleTags
+	tags := make(map[string]bool, len(exampleTags))
+	for _, tag := range exampleTags {
+		tags[tag] = true
+	}
+	// Path parameters and components are shared by operations, report them once
+	visited := make(map[interface{}]bool)
+	parameter := func(ptr string, parameter *openapi3.Parameter) {
+		if parameter == nil || visited[parameter] {
+			return
+		}
+		visited[parameter] = true
+		if parameter.Example != nil || len(parameter.Examples) != 0 || hasSchemaExample(parameter.Schema) {
+			return
+		}
+		for _, mediaType := range parameter.Content {
+			if hasMediaTypeExample(mediaType) {
+				return
+			}
+		}
+		report(ptr, "%s parameter %q has no example", parameter.In, parameter.Name)
+	}
+	content := func(ptr string, what string, content openapi3.Content) {
+		for _, name := range sortedKeys(content) {
+			mediaType := content[name]
+			if !isJSONMediaType(name) || mediaType == nil || visited[mediaType] {
+				continue
+			}
+			visited[mediaType] = true
+			if !hasMediaTypeExample(mediaType) {
+				report(ptr+"/"+pointerTokenEscaper.Replace(name), "%s has no example of its %s content", what, name)
+			}
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if len(tags) != 0 && !hasAnyTag(operation, tags) {
+			return
+		}
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			for i, ref := range pathItem.Parameters {
+				if ref != nil && ref.Value != nil && operation.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name) == nil {
+					parameter(pointer("paths", path, "parameters", strconv.Itoa(i)), ref.Value)
+				}
+			}
+		}
+		for i, ref := range operation.Parameters {
+			if ref != nil {
+				parameter(ptr+"/parameters/"+strconv.Itoa(i), ref.Value)
+			}
+		}
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			content(ptr+"/requestBody/content", "request body of "+method+" "+path, ref.Value.Content)
+		}
+		for _, status := range sortedKeys(operation.Responses) {
+			if ref := operation.Responses[status]; strings.HasPrefix(status, "2") && ref != nil && ref.Value != nil {
+				content(ptr+"/responses/"+status+"/content", "response "+status+" of "+method+" "+path, ref.Value.Content)
+			}
+		}
+	})
+}
+
+func hasAnyTag(operation *openapi3.Operation, tags map[string]bool) bool {
+	for _, tag := range operation.Tags {
+		if tags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMediaTypeExample(mediaType *openapi3.MediaType) bool {
+	return mediaType != nil && (mediaType.Example != nil || len(mediaType.Examples) != 0 || hasSchemaExample(mediaType.Schema))
+}
+
+func hasSchemaExample(ref *openapi3.SchemaRef) bool {
+	return ref != nil && ref.Value != nil && (ref.Value.Example != nil || len(ref.Value.Examples) != 0)
+}
+
+func checkExampleKeyCollision(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkExamples(swagger, func(ptr string, examples map[string]*openapi3.ExampleRef) {
+		seen := make(map[string]string, len(examples))
+		for _, key := range sortedKeys(examples) {
+			trimmed := strings.TrimSpace(key)
+			if other, ok := seen[trimmed]; ok {
+				report(ptr+"/"+pointerTokenEscaper.Replace(key), "example key %q collides with %q after trimming whitespace", key, other)
+				continue
+			}
+			seen[trimmed] = key
+		}
+	})
+}
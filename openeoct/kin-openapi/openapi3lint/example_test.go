@@ -0,0 +1,93 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExampleKeyCollision(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /processes:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema: {type: integer}
+          examples:
+            small: {value: 10}
+            ' small': {value: 1}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema: {type: object}
+              examples:
+                full: {value: {}}
+                'full ': {value: {}}
+                minimal: {value: {}}
+`
+	issues := lintCodes(t, spec, "OAS-EXAMPLE-KEY-COLLISION")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1processes/get/parameters/0/examples/small", issues[0].Pointer)
+	require.Equal(t, `example key "small" collides with " small" after trimming whitespace`, issues[0].Message)
+	require.Equal(t, "#/paths/~1processes/get/responses/200/content/application~1json/examples/full ", issues[1].Pointer)
+}
+
+func TestOperationExamples(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}:
+    parameters:
+      - {name: job_id, in: path, required: true, schema: {type: string, example: j-1}}
+    get:
+      tags: [Batch Jobs]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: object}}
+    patch:
+      tags: [Batch Jobs]
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object}
+            examples:
+              title: {value: {title: NDVI}}
+      responses:
+        '204': {description: updated}
+  /jobs/{job_id}/results:
+    parameters:
+      - {name: job_id, in: path, required: true, schema: {type: string}}
+    get:
+      tags: [Data Processing]
+      parameters:
+        - {name: partial, in: query, schema: {type: boolean}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: object, example: {assets: {}}}}
+            image/png: {schema: {type: string, format: binary}}
+`
+	issues := lintCodes(t, spec, "OAS-OPERATION-EXAMPLES")
+	require.Len(t, issues, 3)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json", issues[0].Pointer)
+	require.Equal(t, "response 200 of GET /jobs/{job_id} has no example of its application/json content", issues[0].Message)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1results/parameters/0", issues[1].Pointer)
+	require.Equal(t, `path parameter "job_id" has no example`, issues[1].Message)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1results/get/parameters/0", issues[2].Pointer)
+
+	settings := openapi3lint.Settings{ExampleTags: []string{"Batch Jobs"}}
+	issues = lintCodesWith(t, spec, settings, "OAS-OPERATION-EXAMPLES")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/get/responses/200/content/application~1json", issues[0].Pointer)
+}
@@ -0,0 +1,56 @@
+package openapi3lint
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-EXTERNAL-DOCS-URL",
+		Severity:    SeverityWarning,
+		Description: "The url of external documentation should be an absolute URL.",
+		Rationale:   "Documentation tools resolve relative URLs differently, if at all, and an empty URL links nowhere. Use an absolute URL with a host.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+externalDocs: {url: docs/api.html}
+paths: {}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+externalDocs: {url: 'https://example.com/docs/api.html'}
+paths: {}
+`,
+		Check: checkExternalDocsURL,
+	})
+}
+
+func checkExternalDocsURL(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	check := func(ptr string, docs *openapi3.ExternalDocs) {
+		if docs == nil {
+			return
+		}
+		if docs.URL == "" {
+			report(ptr, "externalDocs url is empty")
+		} else if u, err := url.Parse(docs.URL); err != nil || !u.IsAbs() || u.Host == "" {
+			report(ptr, "externalDocs url %q is not an absolute URL", docs.URL)
+		}
+	}
+	check("#", swagger.ExternalDocs)
+	for i, tag := range swagger.Tags {
+		if tag != nil {
+			check(pointer("tags", strconv.Itoa(i)), tag.ExternalDocs)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		check(ptr, operation.ExternalDocs)
+	})
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		check(ptr, schema.ExternalDocs)
+	})
+}
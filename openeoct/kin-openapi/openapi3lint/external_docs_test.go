@@ -0,0 +1,38 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalDocsURL(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+externalDocs: {url: 'https://openeo.org/documentation/1.0/'}
+tags:
+  - name: Process Discovery
+    externalDocs: {url: /documentation/processes}
+components:
+  schemas:
+    process:
+      type: object
+      externalDocs: {url: 'https://processes.openeo.org'}
+      properties:
+        id: {type: string, externalDocs: {url: 'https://'}}
+paths:
+  /processes:
+    get:
+      tags: [Process Discovery]
+      externalDocs: {description: Processes}
+      responses: {'200': {description: ok}}
+`
+	issues := lintCodes(t, spec, "OAS-EXTERNAL-DOCS-URL")
+	require.Len(t, issues, 3)
+	require.Equal(t, "#/tags/0", issues[0].Pointer)
+	require.Equal(t, `externalDocs url "/documentation/processes" is not an absolute URL`, issues[0].Message)
+	require.Equal(t, "#/paths/~1processes/get", issues[1].Pointer)
+	require.Equal(t, "externalDocs url is empty", issues[1].Message)
+	require.Equal(t, "#/components/schemas/process/properties/id", issues[2].Pointer)
+}
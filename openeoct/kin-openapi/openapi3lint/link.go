@@ -0,0 +1,91 @@
+package openapi3lint
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-LINK-DEPRECATED-TARGET",
+		Severity:    SeverityWarning,
+		Description: "Links of non-deprecated operations should not point clients to deprecated operations.",
+		Rationale:   "Clients following the link keep calling an operation which is about to be removed. Point the link to the operation replacing it.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          links:
+            job: {operationId: describeJob}
+  /jobs/{job_id}:
+    get:
+      operationId: describeJob
+      deprecated: true
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          links:
+            job: {operationId: describeJob}
+  /jobs/{job_id}:
+    get:
+      operationId: describeJob
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkLinkDeprecatedTarget,
+	})
+}
+
+func checkLinkDeprecatedTarget(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	// Responses shared through references are checked at their definition
+	visited := make(map[*openapi3.Response]bool)
+	links := func(ptr string, response *openapi3.Response) {
+		visited[response] = true
+		for _, name := range sortedKeys(response.Links) {
+			ref := response.Links[name]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			target := swagger.LinkedOperation(ref.Value)
+			if target == nil || !target.Operation.Deprecated {
+				continue
+			}
+			report(ptr+"/links/"+pointerTokenEscaper.Replace(name),
+				"link %q targets deprecated operation %s",
+				name, pointer("paths", target.Path, strings.ToLower(target.Method)))
+		}
+	}
+	for _, name := range sortedKeys(swagger.Components.Responses) {
+		if ref := swagger.Components.Responses[name]; ref != nil && ref.Value != nil {
+			links(pointer("components", "responses", name), ref.Value)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if operation.Deprecated {
+			return
+		}
+		for _, status := range sortedKeys(operation.Responses) {
+			if ref := operation.Responses[status]; ref != nil && ref.Value != nil && !visited[ref.Value] {
+				links(ptr+"/responses/"+status, ref.Value)
+			}
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkDeprecatedTarget(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  responses:
+    JobCreated:
+      description: created
+      links:
+        download: {operationRef: '#/paths/~1jobs~1{job_id}~1download/get'}
+paths:
+  /jobs:
+    put:
+      responses:
+        '201': {$ref: '#/components/responses/JobCreated'}
+    post:
+      responses:
+        '201':
+          description: created
+          links:
+            results:
+              operationId: get-results
+            logs:
+              operationRef: '#/paths/~1jobs~1{job_id}~1logs/get'
+            old:
+              operationRef: '#/paths/~1jobs~1{job_id}~1download/get'
+  /jobs/{job_id}/results:
+    get:
+      operationId: get-results
+      deprecated: true
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          links:
+            download: {operationRef: '#/paths/~1jobs~1{job_id}~1download/get'}
+  /jobs/{job_id}/logs:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses: {'200': {description: ok}}
+  /jobs/{job_id}/download:
+    get:
+      deprecated: true
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses: {'200': {description: ok}}
+`
+	issues := lintCodes(t, spec, "OAS-LINK-DEPRECATED-TARGET")
+	require.Len(t, issues, 3)
+	// Shared responses are reported once, at their definition
+	require.Equal(t, "#/components/responses/JobCreated/links/download", issues[0].Pointer)
+	require.Equal(t, "#/paths/~1jobs/post/responses/201/links/old", issues[1].Pointer)
+	require.Contains(t, issues[1].Message, "#/paths/~1jobs~1{job_id}~1download/get")
+	require.Equal(t, "#/paths/~1jobs/post/responses/201/links/results", issues[2].Pointer)
+}
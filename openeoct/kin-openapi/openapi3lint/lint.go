@@ -0,0 +1,243 @@
+// Package openapi3lint reports questionable constructs in OpenAPI 3 documents.
+//
+// Unlike (*openapi3.Swagger).Validate, which rejects documents that break a
+// MUST of the specification, the linter collects issues of varying severity
+// without stopping at the first one.
+package openapi3lint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+// Severity ranks a lint issue.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(severity))
+}
+
+// Issue is a single finding of a rule.
+type Issue struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	// Pointer is a JSON pointer into the document, e.g. "#/paths/~1jobs/get".
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+func (issue *Issue) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", issue.Severity, issue.Code, issue.Pointer, issue.Message)
+}
+
+// ReportFunc is used by rules to report an issue at the given JSON pointer.
+type ReportFunc func(pointer string, format string, args ...interface{})
+
+// Rule is a single check run by the linter.
+type Rule struct {
+	Code        string
+	Severity    Severity
+	Description string
+	// Optional rules check best practices, NewLinter doesn't run them, see (*Linter).EnableRule.
+	Optional bool
+	// Rationale explains why the issues matter and how to fix them, see Explain.
+	Rationale string
+	// Failing and Passing are minimal documents with and without an issue of the rule.
+	Failing string
+	Passing string
+	Check   func(c context.Context, swagger *openapi3.Swagger, report ReportFunc)
+}
+
+// Explain describes the rule for users looking up the code of an issue: its
+// description and rationale, followed by its failing and passing documents.
+func (rule *Rule) Explain() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s", rule.Code, rule.Severity)
+	if rule.Optional {
+		b.WriteString(", optional")
+	}
+	fmt.Fprintf(&b, ")\n\n%s\n", rule.Description)
+	if rule.Rationale != "" {
+		fmt.Fprintf(&b, "\n%s\n", rule.Rationale)
+	}
+	for _, example := range []struct{ title, document string }{
+		{"Failing", rule.Failing},
+		{"Passing", rule.Passing},
+	} {
+		if document := strings.TrimSpace(example.document); document != "" {
+			fmt.Fprintf(&b, "\n%s:\n\n    %s\n", example.title, strings.Replace(document, "\n", "\n    ", -1))
+		}
+	}
+	return b.String()
+}
+
+var rules []*Rule
+
+// RegisterRule adds a rule to the set of rules run by NewLinter.
+// It panics if a rule with the same code was already registered.
+func RegisterRule(rule *Rule) {
+	if FindRule(rule.Code) != nil {
+		panic(fmt.Sprintf("lint rule %q is already registered", rule.Code))
+	}
+	rules = append(rules, rule)
+}
+
+// Rules returns all registered rules, in registration order.
+func Rules() []*Rule {
+	return append([]*Rule(nil), rules...)
+}
+
+// FindRule returns the registered rule with the given code, or nil.
+func FindRule(code string) *Rule {
+	for _, rule := range rules {
+		if rule.Code == code {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Linter runs a set of rules against OpenAPI documents.
+type Linter struct {
+	Rules []*Rule
+	// Severities overrides the severities of the issues of rules by rule code.
+	Severities map[string]Severity
+	// Settings configure the rules.
+	Settings Settings
+}
+
+// Settings configure the rules of a linter, the zero value of a setting is its
+// default. Linters with different settings may run at the same time.
+type Settings struct {
+	// PropertyNameCasing is the casing of property names which the
+	// OAS-PROPERTY-NAME-CASING rule checks, CasingSnake by default. Names with
+	// a prefix like "cube:dimensions", as used by STAC extensions, are checked
+	// by parts. Names aren't checked for unknown casings.
+	PropertyNameCasing Casing
+	// PropertyNameAcronyms are words of property names which may be written in
+	// uppercase in any casing, e.g. "EPSG".
+	PropertyNameAcronyms []string
+	// PropertyNameExceptions are property names which the rule doesn't check.
+	PropertyNameExceptions []string
+	// MaxHeaderParameterLength is the largest maxLength of string header
+	// parameters accepted by the OAS-HEADER-PARAMETER-MAX-LENGTH rule, 8 KB by
+	// default.
+	MaxHeaderParameterLength uint64
+	// PublicOperations are the operations which the OAS-OPERATION-UNSECURED
+	// rule accepts without security, by operationId or by method and path,
+	// e.g. "GET /.well-known/openeo".
+	PublicOperations []string
+	// ExampleTags are the tags of the operations which the
+	// OAS-OPERATION-EXAMPLES rule checks. All operations are checked if empty.
+	ExampleTags []string
+	// DeprecationExtensions are the extensions which explain a deprecation for
+	// the OAS-DEPRECATED-NO-REASON rule, x-deprecation-reason by default. Any
+	// of them with a value other than null or an empty string suffices.
+	DeprecationExtensions []string
+	// IdempotentExtensions are the extensions which mark operations as
+	// idempotent or cacheable for the OAS-IDEMPOTENT-REQUEST-BODY rule, with
+	// the value true, x-idempotent and x-cacheable by default.
+	IdempotentExtensions []string
+}
+
+type settingsKey struct{}
+
+// lintSettings returns the settings of the linter running the rules with the
+// context, the defaults if there is none.
+func lintSettings(c context.Context) *Settings {
+	if c != nil {
+		if settings, ok := c.Value(settingsKey{}).(*Settings); ok {
+			return settings
+		}
+	}
+	return &Settings{}
+}
+
+// NewLinter returns a linter running all registered rules which aren't optional.
+func NewLinter() *Linter {
+	var enabled []*Rule
+	for _, rule := range rules {
+		if !rule.Optional {
+			enabled = append(enabled, rule)
+		}
+	}
+	return &Linter{
+		Rules: enabled,
+	}
+}
+
+// EnableRule makes the linter also run the registered rule with the given code,
+// e.g. an optional one. It returns false if there is no such rule.
+func (linter *Linter) EnableRule(code string) bool {
+	rule := FindRule(code)
+	if rule == nil {
+		return false
+	}
+	for _, enabled := range linter.Rules {
+		if enabled.Code == code {
+			return true
+		}
+	}
+	linter.Rules = append(linter.Rules, rule)
+	return true
+}
+
+// DisableRule stops the linter from running the rule with the given code.
+func (linter *Linter) DisableRule(code string) {
+	enabled := linter.Rules[:0:0]
+	for _, rule := range linter.Rules {
+		if rule.Code != code {
+			enabled = append(enabled, rule)
+		}
+	}
+	linter.Rules = enabled
+}
+
+// Lint runs all registered rules which aren't optional against the document,
+// or those of the preset of the context, see WithPreset.
+func Lint(c context.Context, swagger *openapi3.Swagger) []*Issue {
+	return presetLinter(c).Lint(c, swagger)
+}
+
+// Lint runs the linter's rules against the document and returns the issues
+// found, grouped by rule.
+func (linter *Linter) Lint(c context.Context, swagger *openapi3.Swagger) []*Issue {
+	if c == nil {
+		c = context.Background()
+	}
+	c = context.WithValue(c, settingsKey{}, &linter.Settings)
+	var issues []*Issue
+	for _, rule := range linter.Rules {
+		rule := rule
+		severity, ok := linter.Severities[rule.Code]
+		if !ok {
+			severity = rule.Severity
+		}
+		rule.Check(c, swagger, func(pointer string, format string, args ...interface{}) {
+			issues = append(issues, &Issue{
+				Code:     rule.Code,
+				Severity: severity,
+				Pointer:  pointer,
+				Message:  fmt.Sprintf(format, args...),
+			})
+		})
+	}
+	return issues
+}
@@ -0,0 +1,182 @@
+package openapi3lint_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinterOptionalRulesAndSeverities(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201': {description: created}
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	codes := func(issues []*openapi3lint.Issue) (codes []string) {
+		for _, issue := range issues {
+			codes = append(codes, issue.Code)
+		}
+		return
+	}
+
+	linter := openapi3lint.NewLinter()
+	require.NotContains(t, codes(linter.Lint(context.Background(), swagger)), "OAS-CREATED-RESPONSE-LOCATION")
+
+	require.False(t, linter.EnableRule("OAS-UNKNOWN"))
+	require.True(t, linter.EnableRule("OAS-CREATED-RESPONSE-LOCATION"))
+	require.True(t, linter.EnableRule("OAS-CREATED-RESPONSE-LOCATION"))
+	linter.Severities = map[string]openapi3lint.Severity{"OAS-CREATED-RESPONSE-LOCATION": openapi3lint.SeverityError}
+	var found []*openapi3lint.Issue
+	for _, issue := range linter.Lint(context.Background(), swagger) {
+		if issue.Code == "OAS-CREATED-RESPONSE-LOCATION" {
+			found = append(found, issue)
+		}
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, openapi3lint.SeverityError, found[0].Severity)
+
+	linter.DisableRule("OAS-CREATED-RESPONSE-LOCATION")
+	require.NotContains(t, codes(linter.Lint(context.Background(), swagger)), "OAS-CREATED-RESPONSE-LOCATION")
+}
+
+func TestRuleExplanations(t *testing.T) {
+	for _, rule := range openapi3lint.Rules() {
+		require.NotEmpty(t, rule.Rationale, rule.Code)
+		linter := &openapi3lint.Linter{Rules: []*openapi3lint.Rule{rule}}
+		lint := func(document string) []*openapi3lint.Issue {
+			swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(document))
+			require.NoError(t, err, rule.Code)
+			return linter.Lint(context.Background(), swagger)
+		}
+		require.NotEmpty(t, lint(rule.Failing), rule.Code)
+		require.Empty(t, lint(rule.Passing), rule.Code)
+	}
+
+	explanation := openapi3lint.FindRule("OAS-CREATED-RESPONSE-LOCATION").Explain()
+	require.True(t, strings.HasPrefix(explanation, "OAS-CREATED-RESPONSE-LOCATION (warning, optional)\n\nA 201 or 202 response should declare"))
+	require.Contains(t, explanation, "\nFailing:\n\n    openapi: 3.0.3\n    info: {title: An API, version: v1}\n")
+	require.Contains(t, explanation, "\nPassing:\n\n")
+}
+
+func TestPresets(t *testing.T) {
+	codes := func(rules []*openapi3lint.Rule) (codes []string) {
+		for _, rule := range rules {
+			codes = append(codes, rule.Code)
+		}
+		return
+	}
+
+	minimal, err := openapi3lint.NewPresetLinter(openapi3lint.PresetMinimal)
+	require.NoError(t, err)
+	require.NotEmpty(t, minimal.Rules)
+	for _, rule := range minimal.Rules {
+		require.False(t, rule.Optional, rule.Code)
+		require.Equal(t, openapi3lint.SeverityError, rule.Severity, rule.Code)
+	}
+
+	recommended, err := openapi3lint.NewPresetLinter(openapi3lint.PresetRecommended)
+	require.NoError(t, err)
+	require.Equal(t, codes(openapi3lint.NewLinter().Rules), codes(recommended.Rules))
+	require.Subset(t, codes(recommended.Rules), codes(minimal.Rules))
+
+	strictest, err := openapi3lint.NewPresetLinter(openapi3lint.PresetStrictest)
+	require.NoError(t, err)
+	require.Equal(t, codes(openapi3lint.Rules()), codes(strictest.Rules))
+	require.Contains(t, codes(strictest.Rules), "OAS-CREATED-RESPONSE-LOCATION")
+
+	_, err = openapi3lint.NewPresetLinter("pedantic")
+	require.EqualError(t, err, `unknown lint preset "pedantic", expected minimal, recommended or strictest`)
+
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201': {description: created}
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	lint := func(c context.Context) []*openapi3lint.Issue {
+		var found []*openapi3lint.Issue
+		for _, issue := range openapi3lint.Lint(c, swagger) {
+			if issue.Code == "OAS-CREATED-RESPONSE-LOCATION" || issue.Code == "OAS-OPERATION-EXAMPLES" {
+				found = append(found, issue)
+			}
+		}
+		return found
+	}
+	require.Empty(t, lint(openapi3lint.WithPreset(context.Background(), openapi3lint.PresetMinimal)))
+	issues := lint(openapi3lint.WithPreset(context.Background(), openapi3lint.PresetStrictest))
+	require.NotEmpty(t, issues)
+	for _, issue := range issues {
+		require.Equal(t, openapi3lint.SeverityError, issue.Severity, issue.Code)
+	}
+	require.Len(t, lint(openapi3lint.WithPreset(context.Background(), "pedantic")), len(lint(context.Background())))
+}
+
+func TestRulesIgnoreNilRefs(t *testing.T) {
+	// Documents built in code may have nil references
+	operation := openapi3.NewOperation()
+	operation.Parameters = openapi3.Parameters{nil}
+	operation.Responses = openapi3.Responses{"200": nil}
+	swagger := &openapi3.Swagger{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: "An API", Version: "v1"},
+		Paths: openapi3.Paths{
+			"/jobs": &openapi3.PathItem{Get: operation, Parameters: openapi3.Parameters{nil}},
+		},
+	}
+	for _, code := range []string{
+		"OAS-SCHEMA-CLOSED-EMPTY-OBJECT",
+		"OAS-RESPONSE-CONTENT-SCHEMA-MISMATCH",
+		"OAS-SUCCESS-RESPONSE-SCALAR",
+		"OAS-OPERATION-EXAMPLES",
+		"OAS-LINK-DEPRECATED-TARGET",
+	} {
+		linter := &openapi3lint.Linter{Rules: []*openapi3lint.Rule{openapi3lint.FindRule(code)}}
+		require.NotPanics(t, func() { linter.Lint(context.Background(), swagger) }, code)
+	}
+}
+
+func TestLinterSettings(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Process:
+      type: object
+      properties:
+        process_graph: {type: object}
+`
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	rule := openapi3lint.FindRule("OAS-PROPERTY-NAME-CASING")
+	snake := &openapi3lint.Linter{Rules: []*openapi3lint.Rule{rule}}
+	camel := &openapi3lint.Linter{Rules: []*openapi3lint.Rule{rule}, Settings: openapi3lint.Settings{PropertyNameCasing: openapi3lint.CasingCamel}}
+
+	// Linters with different settings run at the same time
+	var wg sync.WaitGroup
+	var snakeIssues, camelIssues []*openapi3lint.Issue
+	wg.Add(2)
+	go func() { defer wg.Done(); snakeIssues = snake.Lint(context.Background(), swagger) }()
+	go func() { defer wg.Done(); camelIssues = camel.Lint(context.Background(), swagger) }()
+	wg.Wait()
+	require.Empty(t, snakeIssues)
+	require.Len(t, camelIssues, 1)
+	require.Equal(t, `property name "process_graph" isn't camelCase`, camelIssues[0].Message)
+}
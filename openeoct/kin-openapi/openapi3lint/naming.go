@@ -0,0 +1,111 @@
+package openapi3lint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-PROPERTY-NAME-CASING",
+		Severity:    SeverityWarning,
+		Description: "Property names of object schemas should follow the casing convention of the document, snake_case by default.",
+		Rationale:   "Clients address properties by their exact names, so a property named e.g. backendVersion instead of backend_version is missed by clients following the convention. Rename the property, or add it to the PropertyNameExceptions setting if the name is given, e.g. by another standard. Casing and acronyms are configured with the PropertyNameCasing and PropertyNameAcronyms settings.",
+		Optional:    true,
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Capabilities:
+      type: object
+      properties:
+        backendVersion: {type: string}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Capabilities:
+      type: object
+      properties:
+        backend_version: {type: string}
+        cube:dimensions: {type: object}
+`,
+		Check: checkPropertyNameCasing,
+	})
+}
+
+// Casing is a naming convention of the words of names, see Settings.PropertyNameCasing.
+type Casing string
+
+const (
+	// CasingSnake names are lowercase words separated by underscores, e.g. backend_version.
+	CasingSnake Casing = "snake_case"
+	// CasingCamel names are words starting uppercase after a first lowercase word, e.g. backendVersion.
+	CasingCamel Casing = "camelCase"
+	// CasingKebab names are lowercase words separated by hyphens, e.g. backend-version.
+	CasingKebab Casing = "kebab-case"
+)
+
+// propertyNameCasing returns the PropertyNameCasing setting.
+func (settings *Settings) propertyNameCasing() Casing {
+	if settings.PropertyNameCasing == "" {
+		return CasingSnake
+	}
+	return settings.PropertyNameCasing
+}
+
+func checkPropertyNameCasing(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	settings := lintSettings(c)
+	casing := settings.propertyNameCasing()
+	pattern := casingPattern(casing, settings.PropertyNameAcronyms)
+	if pattern == nil {
+		return
+	}
+	exceptions := make(map[string]bool, len(settings.PropertyNameExceptions))
+	for _, name := range settings.PropertyNameExceptions {
+		exceptions[name] = true
+	}
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		for _, name := range sortedKeys(schema.Properties) {
+			if exceptions[name] {
+				continue
+			}
+			for _, part := range strings.Split(name, ":") {
+				if !pattern.MatchString(part) {
+					report(ptr+"/properties/"+pointerTokenEscaper.Replace(name), "property name %q isn't %s", name, casing)
+					break
+				}
+			}
+		}
+	})
+}
+
+// casingPattern returns the pattern of the names of the casing, nil for
+// unknown casings.
+func casingPattern(casing Casing, acronyms []string) *regexp.Regexp {
+	acronym := ""
+	for _, word := range acronyms {
+		acronym += "|" + regexp.QuoteMeta(word)
+	}
+	var pattern string
+	switch casing {
+	case CasingSnake:
+		pattern = `(?:[a-z0-9]+%[1]s)(?:_(?:[a-z0-9]+%[1]s))*`
+	case CasingKebab:
+		pattern = `(?:[a-z0-9]+%[1]s)(?:-(?:[a-z0-9]+%[1]s))*`
+	case CasingCamel:
+		pattern = `(?:[a-z][a-z0-9]*%[1]s)(?:[A-Z][a-z0-9]*%[1]s)*`
+	default:
+		return nil
+	}
+	return regexp.MustCompile("^" + fmt.Sprintf(pattern, acronym) + "$")
+}
@@ -0,0 +1,51 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyNameCasing(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    collection:
+      type: object
+      properties:
+        stac_version: {type: string}
+        cube:dimensions: {type: object}
+        sci:doiURL: {type: string}
+        backendVersion: {type: string}
+        EPSG_code: {type: integer}
+        extent:
+          type: object
+          properties:
+            Spatial: {type: object}
+`
+	issues := lintCodes(t, spec, "OAS-PROPERTY-NAME-CASING")
+	require.Len(t, issues, 4)
+	require.Equal(t, "#/components/schemas/collection/properties/EPSG_code", issues[0].Pointer)
+	require.Equal(t, `property name "EPSG_code" isn't snake_case`, issues[0].Message)
+	require.Equal(t, "#/components/schemas/collection/properties/backendVersion", issues[1].Pointer)
+	require.Equal(t, "#/components/schemas/collection/properties/sci:doiURL", issues[2].Pointer)
+	require.Equal(t, "#/components/schemas/collection/properties/extent/properties/Spatial", issues[3].Pointer)
+
+	settings := openapi3lint.Settings{PropertyNameAcronyms: []string{"EPSG"}, PropertyNameExceptions: []string{"Spatial"}}
+	issues = lintCodesWith(t, spec, settings, "OAS-PROPERTY-NAME-CASING")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/collection/properties/backendVersion", issues[0].Pointer)
+	require.Equal(t, "#/components/schemas/collection/properties/sci:doiURL", issues[1].Pointer)
+
+	settings.PropertyNameCasing = openapi3lint.CasingCamel
+	settings.PropertyNameAcronyms = []string{"URL"}
+	issues = lintCodesWith(t, spec, settings, "OAS-PROPERTY-NAME-CASING")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/collection/properties/EPSG_code", issues[0].Pointer)
+	require.Equal(t, `property name "EPSG_code" isn't camelCase`, issues[0].Message)
+	require.Equal(t, "#/components/schemas/collection/properties/stac_version", issues[1].Pointer)
+}
@@ -0,0 +1,264 @@
+package openapi3lint
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-PARAMETER-REQUIRED-ALLOW-EMPTY",
+		Severity:    SeverityWarning,
+		Description: "Required query parameters shouldn't allow empty values, which few servers handle consistently.",
+		Rationale:   "Some servers treat an empty value like a missing parameter and reject the request, others accept it. Don't allow empty values for required parameters, or make them optional.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /collections:
+    get:
+      parameters:
+        - {name: q, in: query, required: true, allowEmptyValue: true, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /collections:
+    get:
+      parameters:
+        - {name: q, in: query, allowEmptyValue: true, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkRequiredAllowEmptyParameter,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-PARAMETER-FORM-FIELD-COLLISION",
+		Severity:    SeverityWarning,
+		Description: "Parameters shouldn't share their name with a field of the form-encoded request body, which is ambiguous for clients.",
+		Rationale:   "Clients can't tell whether to send the value in the query or in the form, and servers may read either of them. Rename the parameter or the form field.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /credentials/oidc:
+    post:
+      parameters:
+        - {name: scope, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                scope: {type: string}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /credentials/oidc:
+    post:
+      parameters:
+        - {name: state, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                scope: {type: string}
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkParameterFormFieldCollision,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-PARAMETER-STYLE-EXPLODE",
+		Severity:    SeverityWarning,
+		Description: "Array and object query parameters should combine style and explode in a way clients serialize interoperably.",
+		Rationale:   "Many clients only build the common serializations, e.g. form style, and can't send parameters with an unusual combination of style and explode. Use form style, or deepObject style for objects.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /processes:
+    get:
+      parameters:
+        - {name: ids, in: query, style: pipeDelimited, explode: true, schema: {type: array, items: {type: string}}}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /processes:
+    get:
+      parameters:
+        - {name: ids, in: query, style: form, explode: false, schema: {type: array, items: {type: string}}}
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkParameterStyleExplode,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-HEADER-PARAMETER-MAX-LENGTH",
+		Severity:    SeverityWarning,
+		Description: "String header parameters should declare a maxLength of at most the MaxHeaderParameterLength setting, 8 KB by default.",
+		Rationale:   "Servers and proxies reject requests with overly long headers, often beyond 8 KB for all headers, so values the schema allows may never reach the back end. Declare a maxLength, or pass long values in the body.",
+		Optional:    true,
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      parameters:
+        - {name: OpenEO-Filter, in: header, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      parameters:
+        - {name: OpenEO-Filter, in: header, schema: {type: string, maxLength: 1024}}
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkHeaderParameterMaxLength,
+	})
+}
+
+// maxHeaderParameterLength returns the MaxHeaderParameterLength setting.
+func (settings *Settings) maxHeaderParameterLength() uint64 {
+	if settings.MaxHeaderParameterLength == 0 {
+		return 8 * 1024
+	}
+	return settings.MaxHeaderParameterLength
+}
+
+func checkRequiredAllowEmptyParameter(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkParameters(swagger, func(ptr string, parameter *openapi3.Parameter) {
+		if parameter.In == openapi3.ParameterInQuery && parameter.Required && parameter.AllowEmptyValue {
+			report(ptr, "query parameter %q is required and allows an empty value", parameter.Name)
+		}
+	})
+}
+
+func checkParameterFormFieldCollision(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+			return
+		}
+		fields := make(map[string]string)
+		content := operation.RequestBody.Value.Content
+		for _, mediaType := range sortedKeys(content) {
+			v := content[mediaType]
+			if !isFormMediaType(mediaType) || v == nil || v.Schema == nil || v.Schema.Value == nil {
+				continue
+			}
+			schemaPtr := ptr + "/requestBody/content/" + pointerTokenEscaper.Replace(mediaType) + "/schema"
+			for _, name := range sortedKeys(v.Schema.Value.Properties) {
+				if _, ok := fields[name]; !ok {
+					fields[name] = schemaPtr + "/properties/" + pointerTokenEscaper.Replace(name)
+				}
+			}
+		}
+		if len(fields) == 0 {
+			return
+		}
+		visit := func(ptr string, ref *openapi3.ParameterRef) {
+			if ref == nil || ref.Value == nil {
+				return
+			}
+			if field, ok := fields[ref.Value.Name]; ok {
+				report(ptr, "%s parameter %q collides with the form field at %s", ref.Value.In, ref.Value.Name, field)
+			}
+		}
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			for i, ref := range pathItem.Parameters {
+				if ref != nil && ref.Value != nil && operation.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name) != nil {
+					// Overridden by the operation
+					continue
+				}
+				visit(pointer("paths", path, "parameters", strconv.Itoa(i)), ref)
+			}
+		}
+		for i, ref := range operation.Parameters {
+			visit(ptr+"/parameters/"+strconv.Itoa(i), ref)
+		}
+	})
+}
+
+func checkParameterStyleExplode(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkParameters(swagger, func(ptr string, parameter *openapi3.Parameter) {
+		if parameter.In != openapi3.ParameterInQuery || parameter.Schema == nil || parameter.Schema.Value == nil {
+			return
+		}
+		schemaType := parameter.Schema.Value.Type
+		if schemaType != "array" && schemaType != "object" {
+			return
+		}
+		style := parameter.Style
+		if style == "" {
+			style = openapi3.SerializationForm
+		}
+		// Unlike (*openapi3.Parameter).SerializationMethod, explode defaults to true for form style only
+		explode := style == openapi3.SerializationForm
+		if parameter.Explode != nil {
+			explode = *parameter.Explode
+		}
+		var reason string
+		switch style {
+		case openapi3.SerializationSpaceDelimited, openapi3.SerializationPipeDelimited:
+			if explode {
+				reason = "which serializes it like form style, use form style instead"
+			} else if schemaType == "object" {
+				reason = "few clients serialize delimited objects, use form or deepObject style instead"
+			}
+		case openapi3.SerializationDeepObject:
+			if schemaType == "array" {
+				reason = "deepObject style only defines the serialization of objects, use form style instead"
+			}
+		}
+		if reason != "" {
+			report(ptr, "%s query parameter %q has style %s and explode %t, %s", schemaType, parameter.Name, style, explode, reason)
+		}
+	})
+}
+
+func checkHeaderParameterMaxLength(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	maxLength := lintSettings(c).maxHeaderParameterLength()
+	walkParameters(swagger, func(ptr string, parameter *openapi3.Parameter) {
+		if parameter.In != openapi3.ParameterInHeader || parameter.Schema == nil || parameter.Schema.Value == nil {
+			return
+		}
+		schema := parameter.Schema.Value
+		if schema.Type != "string" {
+			return
+		}
+		if schema.MaxLength == nil {
+			report(ptr, "header parameter %q declares no maxLength", parameter.Name)
+		} else if *schema.MaxLength > maxLength {
+			report(ptr, "header parameter %q has a maxLength of %d, more than %d", parameter.Name, *schema.MaxLength, maxLength)
+		}
+	})
+}
+
+// isFormMediaType returns whether mediaType is application/x-www-form-urlencoded.
+func isFormMediaType(mediaType string) bool {
+	return strings.ToLower(strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0])) == "application/x-www-form-urlencoded"
+}
@@ -0,0 +1,122 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredAllowEmptyParameter(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  parameters:
+    limit: {name: limit, in: query, required: true, allowEmptyValue: true, schema: {type: integer}}
+paths:
+  /collections:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/limit'
+        - {name: bbox, in: query, allowEmptyValue: true, schema: {type: string}}
+        - {name: q, in: query, required: true, allowEmptyValue: true, schema: {type: string}}
+        - {name: datetime, in: query, required: true, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+`
+	issues := lintCodes(t, spec, "OAS-PARAMETER-REQUIRED-ALLOW-EMPTY")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/parameters/limit", issues[0].Pointer)
+	require.Equal(t, `query parameter "limit" is required and allows an empty value`, issues[0].Message)
+	require.Equal(t, "#/paths/~1collections/get/parameters/2", issues[1].Pointer)
+}
+
+func TestParameterFormFieldCollision(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /credentials/oidc:
+    parameters:
+      - {name: client_id, in: query, schema: {type: string}}
+      - {name: scope, in: query, schema: {type: string}}
+    post:
+      parameters:
+        - {name: scope, in: query, schema: {type: string}}
+        - {name: state, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/x-www-form-urlencoded; charset=utf-8:
+            schema:
+              type: object
+              properties:
+                client_id: {type: string}
+                scope: {type: string}
+          application/json:
+            schema:
+              type: object
+              properties:
+                state: {type: string}
+      responses:
+        '200': {description: ok}
+`
+	issues := lintCodes(t, spec, "OAS-PARAMETER-FORM-FIELD-COLLISION")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1credentials~1oidc/parameters/0", issues[0].Pointer)
+	require.Equal(t, `query parameter "client_id" collides with the form field at `+
+		"#/paths/~1credentials~1oidc/post/requestBody/content/application~1x-www-form-urlencoded; charset=utf-8/schema/properties/client_id", issues[0].Message)
+	require.Equal(t, "#/paths/~1credentials~1oidc/post/parameters/0", issues[1].Pointer)
+}
+
+func TestParameterStyleExplode(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /collections:
+    get:
+      parameters:
+        - {name: bbox, in: query, style: spaceDelimited, explode: true, schema: {type: array, items: {type: number}}}
+        - {name: ids, in: query, style: pipeDelimited, schema: {type: array, items: {type: string}}}
+        - {name: filter, in: query, style: pipeDelimited, schema: {type: object}}
+        - {name: sortby, in: query, style: deepObject, explode: true, schema: {type: array, items: {type: string}}}
+        - {name: fields, in: query, style: deepObject, explode: true, schema: {type: object}}
+        - {name: q, in: query, style: spaceDelimited, explode: true, schema: {type: string}}
+        - {name: limit, in: query, schema: {type: array, items: {type: integer}}}
+      responses:
+        '200': {description: ok}
+`
+	issues := lintCodes(t, spec, "OAS-PARAMETER-STYLE-EXPLODE")
+	require.Len(t, issues, 3)
+	require.Equal(t, "#/paths/~1collections/get/parameters/0", issues[0].Pointer)
+	require.Equal(t, `array query parameter "bbox" has style spaceDelimited and explode true, which serializes it like form style, use form style instead`, issues[0].Message)
+	require.Equal(t, "#/paths/~1collections/get/parameters/2", issues[1].Pointer)
+	require.Equal(t, "#/paths/~1collections/get/parameters/3", issues[2].Pointer)
+}
+
+func TestHeaderParameterMaxLength(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      parameters:
+        - {name: Authorization, in: header, schema: {type: string}}
+        - {name: OpenEO-Filter, in: header, schema: {type: string, maxLength: 16384}}
+        - {name: OpenEO-Identifier, in: header, schema: {type: string, maxLength: 64}}
+        - {name: OpenEO-Costs, in: header, schema: {type: number}}
+        - {name: q, in: query, schema: {type: string}}
+      responses:
+        '200': {description: ok}
+`
+	issues := lintCodes(t, spec, "OAS-HEADER-PARAMETER-MAX-LENGTH")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1result/post/parameters/0", issues[0].Pointer)
+	require.Equal(t, `header parameter "Authorization" declares no maxLength`, issues[0].Message)
+	require.Equal(t, `header parameter "OpenEO-Filter" has a maxLength of 16384, more than 8192`, issues[1].Message)
+
+	settings := openapi3lint.Settings{MaxHeaderParameterLength: 32}
+	require.Len(t, lintCodesWith(t, spec, settings, "OAS-HEADER-PARAMETER-MAX-LENGTH"), 3)
+}
@@ -0,0 +1,70 @@
+package openapi3lint
+
+import (
+	"context"
+	"fmt"
+)
+
+// Presets are predefined rigor levels of the linter, see NewPresetLinter.
+const (
+	// PresetMinimal only runs the rules which aren't optional and report errors.
+	PresetMinimal = "minimal"
+	// PresetRecommended runs the rules which aren't optional at their severities,
+	// like NewLinter.
+	PresetRecommended = "recommended"
+	// PresetStrictest runs all rules, the optional ones too, and reports all
+	// issues as errors.
+	PresetStrictest = "strictest"
+)
+
+// Presets returns the names of the presets, from the most lenient to the strictest.
+func Presets() []string {
+	return []string{PresetMinimal, PresetRecommended, PresetStrictest}
+}
+
+// NewPresetLinter returns a linter running the registered rules of the preset
+// at its severities. The rules and severities of the linter can be changed
+// further like those of NewLinter. It returns an error for unknown presets.
+func NewPresetLinter(preset string) (*Linter, error) {
+	linter := &Linter{}
+	switch preset {
+	case PresetMinimal:
+		for _, rule := range rules {
+			if !rule.Optional && rule.Severity == SeverityError {
+				linter.Rules = append(linter.Rules, rule)
+			}
+		}
+	case PresetRecommended:
+		linter = NewLinter()
+	case PresetStrictest:
+		linter.Severities = make(map[string]Severity, len(rules))
+		for _, rule := range rules {
+			linter.Rules = append(linter.Rules, rule)
+			linter.Severities[rule.Code] = SeverityError
+		}
+	default:
+		return nil, fmt.Errorf("unknown lint preset %q, expected %s, %s or %s", preset, PresetMinimal, PresetRecommended, PresetStrictest)
+	}
+	return linter, nil
+}
+
+type presetKey struct{}
+
+// WithPreset returns a context which makes Lint run the rules of the preset,
+// see NewPresetLinter. Lint ignores unknown presets.
+func WithPreset(c context.Context, preset string) context.Context {
+	return context.WithValue(c, presetKey{}, preset)
+}
+
+// presetLinter returns the linter of the preset of the context, NewLinter if
+// there is no known one.
+func presetLinter(c context.Context) *Linter {
+	if c != nil {
+		if preset, ok := c.Value(presetKey{}).(string); ok {
+			if linter, err := NewPresetLinter(preset); err == nil {
+				return linter
+			}
+		}
+	}
+	return NewLinter()
+}
@@ -0,0 +1,144 @@
+package openapi3lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-REQUEST-BODY-NO-SCHEMA",
+		Severity:    SeverityWarning,
+		Description: "The media types of request bodies should declare a schema, unless they accept any content.",
+		Rationale:   "A media type without schema accepts any body, so neither clients nor the validator can check requests, e.g. the process graphs sent to a back end. Declare the schema, or mark the media type with \"x-any-content: true\" if any content is intended.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      requestBody:
+        content:
+          application/json: {}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [process]
+              properties:
+                process: {type: object}
+      responses:
+        '200': {description: ok}
+  /files/{path}:
+    put:
+      parameters:
+        - {name: path, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/octet-stream:
+            x-any-content: true
+      responses:
+        '204': {description: uploaded}
+`,
+		Check: checkRequestBodyNoSchema,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-IDEMPOTENT-REQUEST-BODY",
+		Severity:    SeverityWarning,
+		Description: "Operations marked as idempotent or cacheable, e.g. with \"x-idempotent: true\", shouldn't declare a request body.",
+		Rationale:   "Caches and retrying clients identify such requests by their method and URL, so requests with different bodies get the same cached response. Pass the inputs as parameters, or remove the mark if the operation depends on the body.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /processes:
+    get:
+      x-cacheable: true
+      requestBody:
+        content:
+          application/json: {schema: {type: object}}
+      responses:
+        '200': {description: the processes}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /processes:
+    get:
+      x-cacheable: true
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200': {description: the processes}
+`,
+		Check: checkIdempotentRequestBody,
+	})
+}
+
+// anyContentExtension marks media types which accept any content on purpose.
+const anyContentExtension = "x-any-content"
+
+func (settings *Settings) idempotentExtensions() []string {
+	if len(settings.IdempotentExtensions) == 0 {
+		return []string{"x-idempotent", "x-cacheable"}
+	}
+	return settings.IdempotentExtensions
+}
+
+func checkRequestBodyNoSchema(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		ref := operation.RequestBody
+		if ref == nil || ref.Value == nil {
+			return
+		}
+		for _, name := range sortedKeys(ref.Value.Content) {
+			mediaType := ref.Value.Content[name]
+			if mediaType == nil || mediaType.Schema != nil || acceptsAnyContent(name, mediaType) {
+				continue
+			}
+			report(ptr+"/requestBody/content/"+pointerTokenEscaper.Replace(name),
+				"request body of %s %s declares no schema for %s, so it accepts any content", method, path, name)
+		}
+	})
+}
+
+// acceptsAnyContent returns whether the media type is marked to accept any
+// content, or is a media range like "*/*" which clearly does.
+func acceptsAnyContent(name string, mediaType *openapi3.MediaType) bool {
+	if strings.HasSuffix(name, "/*") {
+		return true
+	}
+	raw, ok := mediaType.Extensions[anyContentExtension].(json.RawMessage)
+	return ok && bytes.Equal(bytes.TrimSpace(raw), []byte("true"))
+}
+
+func checkIdempotentRequestBody(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	extensions := lintSettings(c).idempotentExtensions()
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if operation.RequestBody == nil {
+			return
+		}
+		for _, name := range extensions {
+			raw, ok := operation.Extensions[name].(json.RawMessage)
+			if ok && bytes.Equal(bytes.TrimSpace(raw), []byte("true")) {
+				report(ptr+"/requestBody", "%s %s is marked with %s, but declares a request body", method, path, name)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBodyNoSchema(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  requestBodies:
+    ProcessGraph:
+      content:
+        application/json: {}
+paths:
+  /jobs:
+    post:
+      requestBody: {$ref: '#/components/requestBodies/ProcessGraph'}
+      responses:
+        '201': {description: created}
+  /files/{path}:
+    put:
+      parameters:
+        - {name: path, in: path, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          '*/*': {}
+          application/octet-stream: {x-any-content: true}
+          text/plain: {x-any-content: false}
+      responses:
+        '204': {description: uploaded}
+  /result:
+    post:
+      requestBody:
+        content:
+          application/json: {schema: {type: object}}
+      responses:
+        '200': {description: ok}
+`
+	issues := lintCodes(t, spec, "OAS-REQUEST-BODY-NO-SCHEMA")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1files~1{path}/put/requestBody/content/text~1plain", issues[0].Pointer)
+	require.Equal(t, "request body of PUT /files/{path} declares no schema for text/plain, so it accepts any content", issues[0].Message)
+	require.Equal(t, "#/paths/~1jobs/post/requestBody/content/application~1json", issues[1].Pointer)
+}
+
+func TestIdempotentRequestBody(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+components:
+  requestBodies:
+    Filter:
+      content:
+        application/json: {schema: {type: object}}
+paths:
+  /processes:
+    get:
+      x-cacheable: true
+      x-idempotent: true
+      requestBody: {$ref: '#/components/requestBodies/Filter'}
+      responses:
+        '200': {description: the processes}
+  /collections:
+    get:
+      x-cacheable: true
+      responses:
+        '200': {description: the collections}
+  /result:
+    post:
+      x-idempotent: false
+      requestBody: {$ref: '#/components/requestBodies/Filter'}
+      responses:
+        '200': {description: the result}
+`
+	issues := lintCodes(t, spec, "OAS-IDEMPOTENT-REQUEST-BODY")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1processes/get/requestBody", issues[0].Pointer)
+	require.Equal(t, "GET /processes is marked with x-idempotent, but declares a request body", issues[0].Message)
+	require.Equal(t, openapi3lint.SeverityWarning, issues[0].Severity)
+
+	// Other extensions replace the default
+	settings := openapi3lint.Settings{IdempotentExtensions: []string{"x-cacheable"}}
+	issues = lintCodesWith(t, spec, settings, "OAS-IDEMPOTENT-REQUEST-BODY")
+	require.Len(t, issues, 1)
+	require.Equal(t, "GET /processes is marked with x-cacheable, but declares a request body", issues[0].Message)
+}
@@ -0,0 +1,322 @@
+package openapi3lint
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-RESPONSE-HEADER-CONTENT-TYPE",
+		Severity:    SeverityWarning,
+		Description: "Response headers must not define Content-Type, it is described by the response content instead.",
+		Rationale:   "OpenAPI ignores a Content-Type response header, so its description never takes effect. Declare the media types as the content of the response instead.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      responses:
+        '200':
+          description: ok
+          headers:
+            Content-Type: {schema: {type: string}}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      responses:
+        '200':
+          description: ok
+          content:
+            image/png: {schema: {type: string, format: binary}}
+`,
+		Check: checkResponseHeaderContentType,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-READ-RESPONSE-NO-CONTENT",
+		Severity:    SeverityWarning,
+		Description: "A 200 response of a GET operation should declare its content.",
+		Rationale:   "Without a description of the content neither clients nor the validator can check the data returned by a read operation. Declare the content of the 200 response with a schema.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: object}}
+`,
+		Check: checkReadResponseContent,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-RESPONSE-CONTENT-SCHEMA-MISMATCH",
+		Severity:    SeverityWarning,
+		Description: "The schema of response content should fit its media type, e.g. no binary schema for JSON or object schema for plain text.",
+		Rationale:   "Such content can't be serialized as described, so responses never match or the schema misleads clients. Declare a schema fitting the media type, or the media type the response actually has.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: string, format: binary}}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /result:
+    post:
+      responses:
+        '200':
+          description: ok
+          content:
+            image/png: {schema: {type: string, format: binary}}
+`,
+		Check: checkResponseContentSchema,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-CREATED-RESPONSE-LOCATION",
+		Severity:    SeverityWarning,
+		Description: "A 201 or 202 response should declare a Location header pointing to the created resource, as openEO does for jobs and services.",
+		Optional:    true,
+		Rationale:   "Clients find the created resource through the Location header, e.g. the batch job created by POST /jobs in openEO. Declare the Location header of the response.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201': {description: created}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          headers:
+            Location: {schema: {type: string, format: uri}}
+`,
+		Check: checkCreatedResponseLocation,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-OPERATION-NO-SUCCESS-RESPONSE",
+		Severity:    SeverityWarning,
+		Description: "An operation should describe its success response, not only error responses.",
+		Rationale:   "Without a success response neither clients nor the validator know what a successful call returns. Declare the 2xx response, or a default response.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '400': {description: bad request}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: object}}
+        '400': {description: bad request}
+`,
+		Check: checkOperationSuccessResponse,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SUCCESS-RESPONSE-SCALAR",
+		Severity:    SeverityWarning,
+		Description: "JSON content of success responses should be an object or an array, not a bare string, number or boolean.",
+		Rationale:   "Clients handle openEO responses as objects or arrays, and a scalar can't be extended with further properties later. Wrap the value in an object.",
+		Optional:    true,
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}/estimate:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: number}}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}/estimate:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  costs: {type: number}
+`,
+		Check: checkSuccessResponseScalar,
+	})
+}
+
+func checkResponseHeaderContentType(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkResponses(swagger, func(ptr string, response *openapi3.Response) {
+		for _, name := range sortedKeys(response.Headers) {
+			if strings.EqualFold(name, "Content-Type") {
+				report(ptr+"/headers/"+pointerTokenEscaper.Replace(name),
+					"header %q is ignored, the media type is defined by the response content", name)
+			}
+		}
+	})
+}
+
+func checkReadResponseContent(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if method != http.MethodGet {
+			return
+		}
+		if ref := operation.Responses.Get(http.StatusOK); ref != nil && ref.Value != nil && len(ref.Value.Content) == 0 {
+			report(ptr+"/responses/200", "response of read operation %s %s declares no content", method, path)
+		}
+	})
+}
+
+func checkCreatedResponseLocation(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		for _, status := range []int{http.StatusCreated, http.StatusAccepted} {
+			ref := operation.Responses.Get(status)
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			location := false
+			for name := range ref.Value.Headers {
+				location = location || strings.EqualFold(name, "Location")
+			}
+			if !location {
+				report(ptr+"/responses/"+strconv.Itoa(status), "response %d of %s %s declares no Location header", status, method, path)
+			}
+		}
+	})
+}
+
+func checkOperationSuccessResponse(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if len(operation.Responses) == 0 {
+			// Operations without responses are invalid, see (openapi3.Responses).Validate
+			return
+		}
+		for status := range operation.Responses {
+			if status == "default" || strings.HasPrefix(status, "2") || strings.HasPrefix(status, "3") {
+				return
+			}
+		}
+		report(ptr+"/responses", "operation %s %s only declares error responses", method, path)
+	})
+}
+
+func checkSuccessResponseScalar(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		for _, status := range sortedKeys(operation.Responses) {
+			ref := operation.Responses[status]
+			if !strings.HasPrefix(status, "2") || ref == nil || ref.Value == nil {
+				continue
+			}
+			response := ref.Value
+			for _, mediaType := range sortedKeys(response.Content) {
+				v := response.Content[mediaType]
+				if !isJSONMediaType(mediaType) || v == nil || v.Schema == nil || v.Schema.Value == nil {
+					continue
+				}
+				switch schemaType := v.Schema.Value.Type; schemaType {
+				case "string", "number", "integer", "boolean":
+					report(ptr+"/responses/"+status+"/content/"+pointerTokenEscaper.Replace(mediaType)+"/schema",
+						"response %s of %s %s declares a %s as %s content, wrap it in an object", status, method, path, schemaType, mediaType)
+				}
+			}
+		}
+	})
+}
+
+func checkResponseContentSchema(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		for _, status := range sortedKeys(operation.Responses) {
+			ref := operation.Responses[status]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			response := ref.Value
+			for _, mediaType := range sortedKeys(response.Content) {
+				v := response.Content[mediaType]
+				if v == nil || v.Schema == nil || v.Schema.Value == nil {
+					continue
+				}
+				if nature := contentSchemaMismatch(mediaType, v.Schema.Value); nature != "" {
+					report(ptr+"/responses/"+status+"/content/"+pointerTokenEscaper.Replace(mediaType)+"/schema",
+						"response %s of %s %s declares %s content with %s schema", status, method, path, mediaType, nature)
+				}
+			}
+		}
+	})
+}
+
+// contentSchemaMismatch describes the nature of schema if it contradicts mediaType, or returns "".
+func contentSchemaMismatch(mediaType string, schema *openapi3.Schema) string {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0]))
+	binary := schema.Type == "string" && schema.Format == "binary"
+	structured := schema.Type == "object" || schema.Type == "array"
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if binary {
+			return "a binary"
+		}
+	case strings.HasPrefix(mediaType, "text/"):
+		if structured {
+			return "an " + schema.Type
+		}
+	case mediaType == "application/octet-stream", strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "video/"):
+		if structured {
+			return "an " + schema.Type
+		}
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package openapi3lint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+// lintCodes loads spec and returns the issues reported by the rule with the given code.
+func lintCodes(t *testing.T, spec string, code string) []*openapi3lint.Issue {
+	return lintCodesWith(t, spec, openapi3lint.Settings{}, code)
+}
+
+func lintCodesWith(t *testing.T, spec string, settings openapi3lint.Settings, code string) []*openapi3lint.Issue {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(spec))
+	require.NoError(t, err)
+	linter := &openapi3lint.Linter{Rules: []*openapi3lint.Rule{openapi3lint.FindRule(code)}, Settings: settings}
+	return linter.Lint(context.Background(), swagger)
+}
+
+func TestResponseHeaderContentType(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  responses:
+    Shared:
+      description: shared
+      headers:
+        content-type: {schema: {type: string}}
+paths:
+  /result:
+    post:
+      responses:
+        '200':
+          description: ok
+          headers:
+            Content-Type: {schema: {type: string}}
+            Content-Length: {schema: {type: integer}}
+        default:
+          $ref: '#/components/responses/Shared'
+`
+	issues := lintCodes(t, spec, "OAS-RESPONSE-HEADER-CONTENT-TYPE")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/responses/Shared/headers/content-type", issues[0].Pointer)
+	require.Equal(t, "#/paths/~1result/post/responses/200/headers/Content-Type", issues[1].Pointer)
+	require.Equal(t, openapi3lint.SeverityWarning, issues[1].Severity)
+}
+
+func TestReadResponseNoContent(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200': {description: ok}
+    post:
+      responses:
+        '200': {description: ok}
+  /jobs/{job_id}:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          content: {application/json: {schema: {type: object}}}
+`
+	issues := lintCodes(t, spec, "OAS-READ-RESPONSE-NO-CONTENT")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1jobs/get/responses/200", issues[0].Pointer)
+}
+
+func TestResponseContentSchemaMismatch(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}/results:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: string, format: binary}}
+            application/geo+json: {schema: {type: object}}
+            image/tiff: {schema: {type: string, format: binary}}
+        default:
+          description: error
+          content:
+            text/plain; charset=utf-8: {schema: {type: object}}
+            text/html: {schema: {type: string}}
+`
+	issues := lintCodes(t, spec, "OAS-RESPONSE-CONTENT-SCHEMA-MISMATCH")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1results/get/responses/200/content/application~1json/schema", issues[0].Pointer)
+	require.Equal(t, "response 200 of GET /jobs/{job_id}/results declares application/json content with a binary schema", issues[0].Message)
+	require.Equal(t, "response default of GET /jobs/{job_id}/results declares text/plain; charset=utf-8 content with an object schema", issues[1].Message)
+}
+
+func TestOperationNoSuccessResponse(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        4XX: {description: client error}
+        5XX: {description: server error}
+    post:
+      responses:
+        '201': {description: created}
+        4XX: {description: client error}
+  /jobs/{job_id}:
+    delete:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        default: {description: any}
+`
+	issues := lintCodes(t, spec, "OAS-OPERATION-NO-SUCCESS-RESPONSE")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1jobs/get/responses", issues[0].Pointer)
+}
+
+func TestCreatedResponseLocation(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201':
+          description: created
+          headers:
+            location: {required: true, schema: {type: string, format: uri}}
+  /jobs/{job_id}/results:
+    post:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '202': {description: accepted}
+`
+	issues := lintCodes(t, spec, "OAS-CREATED-RESPONSE-LOCATION")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1results/post/responses/202", issues[0].Pointer)
+	require.Equal(t, "response 202 of POST /jobs/{job_id}/results declares no Location header", issues[0].Message)
+}
+
+func TestSuccessResponseScalar(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}/estimate:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: number}}
+            text/plain: {schema: {type: string}}
+        2XX:
+          description: ok
+          content:
+            application/geo+json: {schema: {type: boolean}}
+        '400':
+          description: error
+          content:
+            application/json: {schema: {type: string}}
+  /jobs:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json: {schema: {type: array, items: {type: string}}}
+`
+	issues := lintCodes(t, spec, "OAS-SUCCESS-RESPONSE-SCALAR")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1estimate/get/responses/200/content/application~1json/schema", issues[0].Pointer)
+	require.Equal(t, "response 200 of GET /jobs/{job_id}/estimate declares a number as application/json content, wrap it in an object", issues[0].Message)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}~1estimate/get/responses/2XX/content/application~1geo+json/schema", issues[1].Pointer)
+	require.True(t, openapi3lint.FindRule("OAS-SUCCESS-RESPONSE-SCALAR").Optional)
+}
@@ -0,0 +1,780 @@
+package openapi3lint
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-CLOSED-EMPTY-OBJECT",
+		Severity:    SeverityWarning,
+		Description: "An object schema without properties that disallows additional properties only matches the empty object.",
+		Rationale:   "This is mostly a mistake, e.g. the properties were forgotten or misplaced. Declare the properties, or allow additional properties.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Options: {type: object, additionalProperties: false}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Options:
+      type: object
+      properties:
+        tile_size: {type: integer}
+      additionalProperties: false
+`,
+		Check: checkClosedEmptyObjectSchema,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-EMPTY-ENUM",
+		Severity:    SeverityWarning,
+		Description: "A schema with an empty enum never matches any value.",
+		Rationale:   "Every value is rejected, the allowed values were most likely forgotten. List the allowed values, or remove the enum.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Status: {type: string, enum: []}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Status: {type: string, enum: [created, running, finished]}
+`,
+		Check: checkEmptyEnumSchema,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-REQUEST-REQUIRED-READONLY",
+		Severity:    SeverityWarning,
+		Description: "Request body schemas must not require readOnly properties, as they are not sent in requests.",
+		Rationale:   "Clients don't send readOnly properties, so no request satisfies a schema requiring one. Only require the property in responses, e.g. with a separate schema for requests.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [id]
+              properties:
+                id: {type: string, readOnly: true}
+      responses:
+        '201': {description: created}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [process]
+              properties:
+                id: {type: string, readOnly: true}
+                process: {type: object}
+      responses:
+        '201': {description: created}
+`,
+		Check: checkRequestRequiredReadOnly,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-PARAMETER-READ-WRITE-ONLY",
+		Severity:    SeverityWarning,
+		Description: "Parameter and header schemas shouldn't be readOnly or writeOnly, which only apply to properties of bodies.",
+		Rationale:   "readOnly and writeOnly describe properties of request and response bodies, they have no effect on parameters and headers. Remove them.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, readOnly: true}}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkParameterReadWriteOnly,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-BINARY-LOCATION",
+		Severity:    SeverityWarning,
+		Description: "Binary string schemas are only valid for request and response bodies, not for parameters, headers or properties of JSON bodies.",
+		Rationale:   "Parameters, headers and JSON only hold text, so binary data can't be sent in them. Use the format byte for base64 encoded data, or send the data as a binary body.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /files:
+    get:
+      parameters:
+        - {name: token, in: query, schema: {type: string, format: binary}}
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /files:
+    get:
+      parameters:
+        - {name: token, in: query, schema: {type: string, format: byte}}
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkBinarySchemaLocation,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-REF-SIBLINGS",
+		Severity:    SeverityWarning,
+		Description: "OpenAPI 3.0 ignores the keywords next to a schema $ref, unlike OpenAPI 3.1, use allOf to combine them with the referenced schema.",
+		Rationale:   "The keywords look like they apply to the schema, but OpenAPI 3.0 documents ignore them. Wrap the reference in allOf and put the keywords next to it.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Id: {type: string}
+    Job:
+      type: object
+      properties:
+        id: {$ref: '#/components/schemas/Id', description: The id of the job.}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Id: {type: string}
+    Job:
+      type: object
+      properties:
+        id:
+          allOf: [{$ref: '#/components/schemas/Id'}]
+          description: The id of the job.
+`,
+		Check: checkSchemaRefSiblings,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-REQUIRED-DEPRECATED",
+		Severity:    SeverityWarning,
+		Description: "Schemas which aren't deprecated shouldn't require properties with a deprecated schema, which forces clients to keep using it.",
+		Rationale:   "Clients can't stop sending or expecting a required property, so it can never be removed. Make the property optional, or deprecate the schema requiring it too.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Job:
+      type: object
+      required: [plan]
+      properties:
+        plan: {type: string, deprecated: true}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Job:
+      type: object
+      properties:
+        plan: {type: string, deprecated: true}
+`,
+		Check: checkRequiredDeprecatedProperty,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-NAME-FORMAT",
+		Severity:    SeverityWarning,
+		Description: "Component schemas shouldn't be named like a well-known format, e.g. date-time or uuid, which is confused with the format in references.",
+		Rationale:   "References like #/components/schemas/date-time read like the format, so readers expect a string of that format. Name the schema after its content.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    date-time:
+      type: object
+      properties:
+        start: {type: string, format: date-time}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    TemporalExtent:
+      type: object
+      properties:
+        start: {type: string, format: date-time}
+`,
+		Check: checkSchemaNameFormat,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-TYPE-KEYWORDS",
+		Severity:    SeverityWarning,
+		Description: "Schemas shouldn't have object keywords unless their type is object, or array keywords unless their type is array.",
+		Rationale:   "Validation ignores the keywords of other types, so e.g. the properties of a string schema are never checked. This is mostly a slip, e.g. a misplaced type or properties. Fix the type, or move the keywords to the schema they belong to.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Link:
+      type: string
+      required: [href]
+      properties:
+        href: {type: string}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Link:
+      type: object
+      required: [href]
+      properties:
+        href: {type: string}
+`,
+		Check: checkSchemaTypeKeywords,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-PREFIX-ITEMS-VERSION",
+		Severity:    SeverityError,
+		Description: "prefixItems is an OpenAPI 3.1 keyword, OpenAPI 3.0 documents can't declare the schemas of the positions of array items.",
+		Rationale:   "Tools of OpenAPI 3.0 don't know prefixItems and don't validate the items with it, so e.g. the coordinates of a tuple are unchecked. Declare OpenAPI 3.1, or describe the items with items.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Point:
+      type: array
+      prefixItems: [{type: number}, {type: number}]
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Point:
+      type: array
+      items: {type: number}
+      minItems: 2
+      maxItems: 2
+`,
+		Check: checkPrefixItemsVersion,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-TUPLE-ITEMS",
+		Severity:    SeverityWarning,
+		Description: "The number of prefixItems of a tuple should fit its maxItems, and open tuples without maxItems should declare the schema of further items.",
+		Rationale:   "prefixItems only validate the leading items, the rest are validated by items, or not at all if there is none. A tuple without maxItems and items silently accepts more items of any type, and prefixItems which maxItems doesn't allow can never be used. Limit the items with maxItems, or declare items for the rest.",
+		Failing: `
+openapi: 3.1.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Point:
+      type: array
+      prefixItems: [{type: number}, {type: number}]
+`,
+		Passing: `
+openapi: 3.1.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Point:
+      type: array
+      prefixItems: [{type: number}, {type: number}]
+      maxItems: 2
+`,
+		Check: checkTupleItems,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-INLINE-DUPLICATE",
+		Severity:    SeverityWarning,
+		Description: "Inline schemas should reference the component schema they are identical to instead of repeating it.",
+		Rationale:   "The copies drift apart when only one of them is changed, and tools generate separate types for them. Replace the inline schema with a $ref to the component.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200':
+          description: the jobs
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: string, pattern: '^[\w\-\.~]+$'}
+components:
+  schemas:
+    job_id: {type: string, pattern: '^[\w\-\.~]+$'}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200':
+          description: the jobs
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: '#/components/schemas/job_id'}
+components:
+  schemas:
+    job_id: {type: string, pattern: '^[\w\-\.~]+$'}
+`,
+		Check: checkInlineDuplicateSchema,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-DISCRIMINATOR-UNUSED",
+		Severity:    SeverityWarning,
+		Description: "A discriminator only applies to a schema with oneOf or anyOf, or to a schema used by a oneOf, anyOf or allOf composition.",
+		Rationale:   "On any other schema the discriminator selects nothing, it's mostly left over from refactoring, and readers wrongly assume the payload is polymorphic. Remove the discriminator, or add the oneOf of the alternatives.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Service:
+      type: object
+      required: [type]
+      properties:
+        type: {type: string}
+      discriminator: {propertyName: type}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Service:
+      oneOf:
+        - $ref: '#/components/schemas/WMTS'
+        - $ref: '#/components/schemas/XYZ'
+      discriminator: {propertyName: type}
+    WMTS:
+      type: object
+      properties:
+        type: {type: string}
+        layer: {type: string}
+    XYZ:
+      type: object
+      properties:
+        type: {type: string}
+`,
+		Check: checkUnusedDiscriminator,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SCHEMA-ARRAY-ENUM",
+		Severity:    SeverityWarning,
+		Description: "The enum of an array schema lists allowed arrays, an enum of other values most likely belongs to the items.",
+		Rationale:   "An enum constrains the whole value, so an array schema with e.g. enum [asc, desc] rejects every array. Move the enum to the schema of the items.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: array
+            items: {type: string}
+            enum: [created, running, finished]
+      responses:
+        '200': {description: the jobs}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [created, running, finished]
+      responses:
+        '200': {description: the jobs}
+`,
+		Check: checkArrayEnum,
+	})
+}
+
+// wellKnownFormats are the formats of OpenAPI and JSON Schema, the formats
+// defined with openapi3.DefineStringFormat are well-known too.
+var wellKnownFormats = []string{
+	"int32", "int64", "float", "double",
+	"byte", "binary", "date", "date-time", "time", "duration", "password",
+	"email", "idn-email", "hostname", "idn-hostname", "ipv4", "ipv6",
+	"uri", "uri-reference", "iri", "iri-reference", "uri-template", "uuid",
+	"json-pointer", "relative-json-pointer", "regex",
+}
+
+func checkClosedEmptyObjectSchema(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if schema.Type != "object" || len(schema.Properties) != 0 {
+			return
+		}
+		if apa := schema.AdditionalPropertiesAllowed; apa != nil && !*apa {
+			report(ptr, "object schema has no properties and disallows additional properties, it only matches {}")
+		}
+	})
+}
+
+func checkEmptyEnumSchema(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if schema.Enum != nil && len(schema.Enum) == 0 {
+			report(ptr, "schema has an empty enum and never matches")
+		}
+	})
+}
+
+func checkRequestRequiredReadOnly(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkRequestSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		for _, name := range schema.Required {
+			if ref := schema.Properties[name]; ref != nil && ref.Value != nil && ref.Value.ReadOnly {
+				report(ptr+"/properties/"+pointerTokenEscaper.Replace(name),
+					"property %q is required and readOnly, so requests can't satisfy the schema", name)
+			}
+		}
+	})
+}
+
+func checkParameterReadWriteOnly(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkParameterSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if schema.ReadOnly {
+			report(ptr, "parameter or header schema is readOnly, which only applies to body properties")
+		}
+		if schema.WriteOnly {
+			report(ptr, "parameter or header schema is writeOnly, which only applies to body properties")
+		}
+	})
+}
+
+func checkBinarySchemaLocation(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	binary := func(schema *openapi3.Schema) bool {
+		return schema.Type == "string" && schema.Format == "binary"
+	}
+	walkParameterSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if binary(schema) {
+			report(ptr, "parameter or header schema is a binary string, which only applies to bodies")
+		}
+	})
+	walkJSONSubschemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if binary(schema) {
+			report(ptr, "schema in JSON content is a binary string, which JSON can't transmit")
+		}
+	})
+}
+
+func checkSchemaRefSiblings(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	if strings.HasPrefix(swagger.OpenAPI, "3.1") {
+		return
+	}
+	walkSchemaRefs(swagger, func(ptr string, ref *openapi3.SchemaRef) {
+		if ref.Siblings != nil {
+			report(ptr, "the keywords %s next to $ref %q are ignored", strings.Join(ref.SiblingKeywords(), ", "), ref.Ref)
+		}
+	})
+}
+
+func checkRequiredDeprecatedProperty(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if schema.Deprecated {
+			return
+		}
+		for _, name := range schema.Required {
+			ref := schema.Properties[name]
+			if ref == nil || ref.Value == nil || !ref.Value.Deprecated {
+				continue
+			}
+			propertyPtr := ptr + "/properties/" + pointerTokenEscaper.Replace(name)
+			if ref.Ref == "" {
+				report(propertyPtr, "required property %q is deprecated", name)
+				continue
+			}
+			alias := strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+			if deprecated := componentSchemaName(swagger, ref.Value); deprecated != "" && deprecated != alias {
+				report(propertyPtr, "required property %q has the deprecated schema %s, through %s", name, deprecated, alias)
+			} else {
+				report(propertyPtr, "required property %q has the deprecated schema %s", name, alias)
+			}
+		}
+	})
+}
+
+// componentSchemaName returns the name of the component schema which defines
+// the schema, rather than references it, or "" if there is none.
+func componentSchemaName(swagger *openapi3.Swagger, schema *openapi3.Schema) string {
+	for _, name := range sortedKeys(swagger.Components.Schemas) {
+		if ref := swagger.Components.Schemas[name]; ref != nil && ref.Ref == "" && ref.Value == schema {
+			return name
+		}
+	}
+	return ""
+}
+
+func checkSchemaNameFormat(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	formats := make(map[string]bool, len(wellKnownFormats)+len(openapi3.SchemaStringFormats))
+	for _, format := range wellKnownFormats {
+		formats[format] = true
+	}
+	for format := range openapi3.SchemaStringFormats {
+		formats[strings.ToLower(format)] = true
+	}
+	for _, name := range sortedKeys(swagger.Components.Schemas) {
+		if !formats[strings.ToLower(name)] {
+			continue
+		}
+		// A schema of the format itself is named aptly
+		if ref := swagger.Components.Schemas[name]; ref != nil && ref.Value != nil && strings.EqualFold(ref.Value.Format, name) {
+			continue
+		}
+		report(pointer("components", "schemas", name), "schema name %q is the name of a well-known format", name)
+	}
+}
+
+func checkSchemaTypeKeywords(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		// Without a type the keywords apply to the values of their type
+		if schema.Type == "" {
+			return
+		}
+		if keywords := objectKeywords(schema); schema.Type != "object" && len(keywords) != 0 {
+			report(ptr, "schema of type %s has the object keywords %s, which only apply to objects", schema.Type, strings.Join(keywords, ", "))
+		}
+		if keywords := arrayKeywords(schema); schema.Type != "array" && len(keywords) != 0 {
+			report(ptr, "schema of type %s has the array keywords %s, which only apply to arrays", schema.Type, strings.Join(keywords, ", "))
+		}
+	})
+}
+
+// objectKeywords returns the object keywords of the schema, in the order of
+// the JSON Schema specification.
+func objectKeywords(schema *openapi3.Schema) []string {
+	var keywords []string
+	if schema.MaxProps != nil {
+		keywords = append(keywords, "maxProperties")
+	}
+	if schema.MinProps != 0 {
+		keywords = append(keywords, "minProperties")
+	}
+	if len(schema.Required) != 0 {
+		keywords = append(keywords, "required")
+	}
+	if len(schema.DependentRequired) != 0 {
+		keywords = append(keywords, "dependentRequired")
+	}
+	if len(schema.Properties) != 0 {
+		keywords = append(keywords, "properties")
+	}
+	if schema.AdditionalProperties != nil || schema.AdditionalPropertiesAllowed != nil {
+		keywords = append(keywords, "additionalProperties")
+	}
+	return keywords
+}
+
+// arrayKeywords returns the array keywords of the schema, in the order of the
+// JSON Schema specification.
+func arrayKeywords(schema *openapi3.Schema) []string {
+	var keywords []string
+	if schema.MaxItems != nil {
+		keywords = append(keywords, "maxItems")
+	}
+	if schema.MinItems != 0 {
+		keywords = append(keywords, "minItems")
+	}
+	if schema.UniqueItems {
+		keywords = append(keywords, "uniqueItems")
+	}
+	if len(schema.PrefixItems) != 0 {
+		keywords = append(keywords, "prefixItems")
+	}
+	if schema.Items != nil {
+		keywords = append(keywords, "items")
+	}
+	return keywords
+}
+
+func checkPrefixItemsVersion(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	if strings.HasPrefix(swagger.OpenAPI, "3.1") {
+		return
+	}
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if len(schema.PrefixItems) != 0 {
+			report(ptr+"/prefixItems", "prefixItems is only supported by OpenAPI 3.1, not by OpenAPI %s", swagger.OpenAPI)
+		}
+	})
+}
+
+func checkTupleItems(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	// OAS-SCHEMA-PREFIX-ITEMS-VERSION reports the prefixItems of other versions
+	if !strings.HasPrefix(swagger.OpenAPI, "3.1") {
+		return
+	}
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		count := uint64(len(schema.PrefixItems))
+		if count == 0 {
+			return
+		}
+		switch {
+		case schema.MaxItems != nil && *schema.MaxItems < count:
+			report(ptr, "schema has %d prefixItems, but maxItems allows only %d items", count, *schema.MaxItems)
+		case schema.MaxItems == nil && schema.Items == nil:
+			report(ptr, "schema has %d prefixItems, but neither maxItems nor items, so it accepts any further items", count)
+		}
+	})
+}
+
+func checkInlineDuplicateSchema(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	components := make(map[string]string)
+	definitions := make(map[*openapi3.Schema]bool)
+	for _, name := range sortedKeys(swagger.Components.Schemas) {
+		ref := swagger.Components.Schemas[name]
+		// Aliases of other components are references themselves
+		if ref == nil || ref.Ref != "" || ref.Value == nil {
+			continue
+		}
+		definitions[ref.Value] = true
+		data, err := openapi3.CanonicalSchemaJSON(ref.Value)
+		// Schemas with a single keyword, e.g. {type: string}, are too common to
+		// be copies of the component
+		if err != nil || countJSONKeys(data) < 2 {
+			continue
+		}
+		if _, ok := components[string(data)]; !ok {
+			components[string(data)] = name
+		}
+	}
+	if len(components) == 0 {
+		return
+	}
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		// The walk may reach components through references first
+		if definitions[schema] {
+			return
+		}
+		data, err := openapi3.CanonicalSchemaJSON(schema)
+		if err != nil {
+			return
+		}
+		if name, ok := components[string(data)]; ok {
+			report(ptr, "schema is identical to the component %q, reference %s instead", name, pointer("components", "schemas", name))
+		}
+	})
+}
+
+// countJSONKeys returns the number of keys of a JSON object, 0 for other values.
+func countJSONKeys(data []byte) int {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return 0
+	}
+	return len(object)
+}
+
+func checkUnusedDiscriminator(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	// Schemas composed by others, also through allOf for inheritance
+	composed := make(map[*openapi3.Schema]bool)
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		for _, refs := range [][]*openapi3.SchemaRef{schema.OneOf, schema.AnyOf, schema.AllOf} {
+			for _, ref := range refs {
+				if ref != nil && ref.Value != nil {
+					composed[ref.Value] = true
+				}
+			}
+		}
+	})
+	for _, name := range sortedKeys(swagger.Components.Schemas) {
+		ref := swagger.Components.Schemas[name]
+		if ref == nil || ref.Ref != "" || ref.Value == nil || ref.Value.Discriminator == nil {
+			continue
+		}
+		schema := ref.Value
+		if len(schema.OneOf) != 0 || len(schema.AnyOf) != 0 || composed[schema] {
+			continue
+		}
+		report(pointer("components", "schemas", name, "discriminator"), "schema %q declares a discriminator, but has no oneOf or anyOf and isn't used by any composition", name)
+	}
+}
+
+func checkArrayEnum(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	walkSchemas(swagger, func(ptr string, schema *openapi3.Schema) {
+		if schema.Type != "array" {
+			return
+		}
+		for i, value := range schema.Enum {
+			// null is the value of nullable arrays
+			if _, ok := value.([]interface{}); !ok && value != nil {
+				data, _ := json.Marshal(value)
+				report(ptr+"/enum", "array schema has the enum value %s at index %d, which isn't an array, move the enum to items", data, i)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,444 @@
+package openapi3lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const deadSchemasSpec = `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Options:
+      type: object
+      additionalProperties: false
+    Format:
+      type: string
+      enum: []
+    Process:
+      type: object
+      additionalProperties: false
+      properties:
+        options: {$ref: '#/components/schemas/Options'}
+        id: {type: string, enum: [ndvi]}
+paths:
+  /processes:
+    get:
+      parameters:
+        - {name: format, in: query, schema: {$ref: '#/components/schemas/Format'}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  processes: {type: array, items: {type: object, additionalProperties: false}}
+`
+
+func TestClosedEmptyObjectSchema(t *testing.T) {
+	issues := lintCodes(t, deadSchemasSpec, "OAS-SCHEMA-CLOSED-EMPTY-OBJECT")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/Options", issues[0].Pointer)
+	require.Equal(t, "#/paths/~1processes/get/responses/200/content/application~1json/schema/properties/processes/items", issues[1].Pointer)
+}
+
+func TestEmptyEnumSchema(t *testing.T) {
+	issues := lintCodes(t, deadSchemasSpec, "OAS-SCHEMA-EMPTY-ENUM")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/components/schemas/Format", issues[0].Pointer)
+}
+
+func TestRequestRequiredReadOnly(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Job:
+      type: object
+      required: [id, process]
+      properties:
+        id: {type: string, readOnly: true}
+        process: {type: object}
+        created: {type: string, readOnly: true}
+paths:
+  /jobs:
+    post:
+      requestBody:
+        content: {application/json: {schema: {$ref: '#/components/schemas/Job'}}}
+      responses: {'201': {description: created}}
+  /jobs/{job_id}:
+    get:
+      parameters: [{name: job_id, in: path, required: true, schema: {type: string}}]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties: {id: {type: string, readOnly: true}}
+`
+	issues := lintCodes(t, spec, "OAS-REQUEST-REQUIRED-READONLY")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1jobs/post/requestBody/content/application~1json/schema/properties/id", issues[0].Pointer)
+}
+
+func TestParameterReadWriteOnly(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  parameters:
+    job_id: {name: job_id, in: path, required: true, schema: {type: string, readOnly: true}}
+paths:
+  /jobs/{job_id}:
+    parameters: [{$ref: '#/components/parameters/job_id'}]
+    get:
+      parameters:
+        - name: filter
+          in: query
+          schema:
+            type: object
+            properties: {token: {type: string, writeOnly: true}}
+      requestBody:
+        content: {application/json: {schema: {type: object, properties: {id: {type: string, readOnly: true}}}}}
+      responses:
+        '200':
+          description: ok
+          headers:
+            OpenEO-Costs: {schema: {type: number, writeOnly: true}}
+`
+	issues := lintCodes(t, spec, "OAS-PARAMETER-READ-WRITE-ONLY")
+	require.Len(t, issues, 3)
+	require.Equal(t, "#/components/parameters/job_id/schema", issues[0].Pointer)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/get/parameters/0/schema/properties/token", issues[1].Pointer)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/get/responses/200/headers/OpenEO-Costs/schema", issues[2].Pointer)
+}
+
+func TestBinarySchemaLocation(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Binary: {type: string, format: binary}
+paths:
+  /files/{path}:
+    put:
+      parameters:
+        - {name: path, in: path, required: true, schema: {type: string, format: binary}}
+      requestBody:
+        content:
+          application/octet-stream: {schema: {$ref: '#/components/schemas/Binary'}}
+          multipart/form-data:
+            schema: {type: object, properties: {file: {$ref: '#/components/schemas/Binary'}}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data: {type: array, items: {type: string, format: binary}}
+                  path: {type: string}
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-BINARY-LOCATION")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1files~1{path}/put/parameters/0/schema", issues[0].Pointer)
+	require.Equal(t, "#/paths/~1files~1{path}/put/responses/200/content/application~1json/schema/properties/data/items", issues[1].Pointer)
+}
+
+func TestSchemaRefSiblings(t *testing.T) {
+	spec := `
+openapi: OPENAPI
+info: {title: An API, version: v1}
+components:
+  schemas:
+    Id: {type: string}
+    Job:
+      type: object
+      properties:
+        id: {$ref: '#/components/schemas/Id', description: The id of the job., maxLength: 10}
+        parent: {$ref: '#/components/schemas/Id'}
+paths:
+  /jobs/{job_id}:
+    get:
+      parameters:
+        - {name: job_id, in: path, required: true, schema: {$ref: '#/components/schemas/Id', minLength: 1}}
+      responses:
+        '200': {description: ok}
+`
+	issues := lintCodes(t, strings.Replace(spec, "OPENAPI", "3.0.3", 1), "OAS-SCHEMA-REF-SIBLINGS")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/Job/properties/id", issues[0].Pointer)
+	require.Equal(t, `the keywords description, maxLength next to $ref "#/components/schemas/Id" are ignored`, issues[0].Message)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/get/parameters/0/schema", issues[1].Pointer)
+
+	require.Empty(t, lintCodes(t, strings.Replace(spec, "OPENAPI", "3.1.0", 1), "OAS-SCHEMA-REF-SIBLINGS"))
+}
+
+func TestRequiredDeprecatedProperty(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    OldBbox: {type: array, items: {type: number}, deprecated: true}
+    Bbox: {$ref: '#/components/schemas/OldBbox'}
+    SpatialExtent: {$ref: '#/components/schemas/Bbox'}
+    Collection:
+      type: object
+      required: [extent, bbox, legacy, spatial_extent]
+      properties:
+        extent: {$ref: '#/components/schemas/OldBbox'}
+        bbox: {$ref: '#/components/schemas/Bbox'}
+        legacy: {type: string, deprecated: true}
+        spatial_extent: {$ref: '#/components/schemas/SpatialExtent'}
+        spatial: {$ref: '#/components/schemas/OldBbox'}
+    OldCollection:
+      type: object
+      deprecated: true
+      required: [extent]
+      properties:
+        extent: {$ref: '#/components/schemas/OldBbox'}
+paths: {}
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-REQUIRED-DEPRECATED")
+	require.Len(t, issues, 4)
+	require.Equal(t, "#/components/schemas/Collection/properties/extent", issues[0].Pointer)
+	require.Equal(t, `required property "extent" has the deprecated schema OldBbox`, issues[0].Message)
+	// Aliases name the schema which is deprecated
+	require.Equal(t, `required property "bbox" has the deprecated schema OldBbox, through Bbox`, issues[1].Message)
+	require.Equal(t, `required property "legacy" is deprecated`, issues[2].Message)
+	require.Equal(t, `required property "spatial_extent" has the deprecated schema OldBbox, through SpatialExtent`, issues[3].Message)
+}
+
+func TestSchemaNameFormat(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  schemas:
+    date-time: {type: string, description: The time of a job.}
+    UUID: {type: string, format: uuid}
+    URI: {type: string}
+    job_id: {type: string}
+paths: {}
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-NAME-FORMAT")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/URI", issues[0].Pointer)
+	require.Equal(t, `schema name "URI" is the name of a well-known format`, issues[0].Message)
+	require.Equal(t, "#/components/schemas/date-time", issues[1].Pointer)
+}
+
+func TestSchemaTypeKeywords(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Bands:
+      type: string
+      items: {type: string}
+      minItems: 1
+    Link:
+      type: string
+      required: [href]
+      properties:
+        href: {type: string}
+      additionalProperties: false
+    Links:
+      type: array
+      items: {$ref: '#/components/schemas/Link'}
+      maxProperties: 10
+    Untyped:
+      required: [id]
+      items: {type: string}
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-TYPE-KEYWORDS")
+	require.Len(t, issues, 3)
+	require.Equal(t, "#/components/schemas/Bands", issues[0].Pointer)
+	require.Equal(t, "schema of type string has the array keywords minItems, items, which only apply to arrays", issues[0].Message)
+	require.Equal(t, "#/components/schemas/Link", issues[1].Pointer)
+	require.Equal(t, "schema of type string has the object keywords required, properties, additionalProperties, which only apply to objects", issues[1].Message)
+	require.Equal(t, "#/components/schemas/Links", issues[2].Pointer)
+	require.Equal(t, "schema of type array has the object keywords maxProperties, which only apply to objects", issues[2].Message)
+}
+
+func TestPrefixItemsVersion(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    BoundingBox:
+      type: object
+      properties:
+        southwest: {type: array, prefixItems: [{type: number}, {type: number}]}
+        northeast: {type: array, items: {type: number}}
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-PREFIX-ITEMS-VERSION")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/components/schemas/BoundingBox/properties/southwest/prefixItems", issues[0].Pointer)
+	require.Equal(t, "prefixItems is only supported by OpenAPI 3.1, not by OpenAPI 3.0.3", issues[0].Message)
+	require.Empty(t, lintCodes(t, spec, "OAS-SCHEMA-TUPLE-ITEMS"))
+
+	spec = strings.Replace(spec, "3.0.3", "3.1.0", 1)
+	require.Empty(t, lintCodes(t, spec, "OAS-SCHEMA-PREFIX-ITEMS-VERSION"))
+}
+
+func TestTupleItems(t *testing.T) {
+	spec := `
+openapi: 3.1.0
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    Extent:
+      type: array
+      items:
+        type: array
+        prefixItems: [{type: string}, {type: string}]
+        maxItems: 1
+    Point:
+      type: array
+      prefixItems: [{type: number}, {type: number}]
+    Position:
+      type: array
+      prefixItems: [{type: number}, {type: number}]
+      items: {type: number}
+    Pair:
+      type: array
+      prefixItems: [{type: number}, {type: number}]
+      maxItems: 2
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-TUPLE-ITEMS")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/Extent/items", issues[0].Pointer)
+	require.Equal(t, "schema has 2 prefixItems, but maxItems allows only 1 items", issues[0].Message)
+	require.Equal(t, "#/components/schemas/Point", issues[1].Pointer)
+	require.Equal(t, "schema has 2 prefixItems, but neither maxItems nor items, so it accepts any further items", issues[1].Message)
+}
+
+func TestInlineDuplicateSchema(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs/{job_id}:
+    parameters:
+      - name: job_id
+        in: path
+        required: true
+        schema: {type: string, pattern: '^[\w\-\.~]+$'}
+    get:
+      responses:
+        '200':
+          description: the job
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/job'}
+components:
+  schemas:
+    job_id: {pattern: '^[\w\-\.~]+$', type: string}
+    process_graph_id: {type: string, pattern: '^[\w\-\.~]+$'}
+    id_alias: {$ref: '#/components/schemas/job_id'}
+    title: {type: string}
+    job:
+      type: object
+      properties:
+        id: {type: string, pattern: '^[\w\-\.~]+$'}
+        process_graph_id: {$ref: '#/components/schemas/process_graph_id'}
+        title: {type: string}
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-INLINE-DUPLICATE")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/job/properties/id", issues[0].Pointer)
+	require.Equal(t, `schema is identical to the component "job_id", reference #/components/schemas/job_id instead`, issues[0].Message)
+	require.Equal(t, "#/paths/~1jobs~1{job_id}/parameters/0/schema", issues[1].Pointer)
+}
+
+func TestUnusedDiscriminator(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+components:
+  schemas:
+    process_graph:
+      type: object
+      properties:
+        process_id: {type: string}
+      discriminator: {propertyName: process_id}
+    service:
+      oneOf:
+        - $ref: '#/components/schemas/service_base'
+      discriminator: {propertyName: type}
+    service_base:
+      type: object
+      properties:
+        type: {type: string}
+      discriminator: {propertyName: type}
+    file_format:
+      type: object
+      properties:
+        gis_data_types: {type: array, items: {type: string}}
+      discriminator: {propertyName: gis_data_types}
+    input_format:
+      allOf:
+        - $ref: '#/components/schemas/file_format'
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-DISCRIMINATOR-UNUSED")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/components/schemas/process_graph/discriminator", issues[0].Pointer)
+	require.Equal(t, `schema "process_graph" declares a discriminator, but has no oneOf or anyOf and isn't used by any composition`, issues[0].Message)
+}
+
+func TestArrayEnum(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /collections:
+    get:
+      parameters:
+        - name: sortby
+          in: query
+          schema:
+            type: array
+            items: {type: string}
+            enum: [[id], asc]
+        - name: bbox
+          in: query
+          schema:
+            type: array
+            nullable: true
+            items: {type: number}
+            enum: [[-180, -90, 180, 90], null]
+      responses:
+        '200': {description: the collections}
+components:
+  schemas:
+    bands:
+      type: array
+      items: {type: string}
+      enum: [B01, B02]
+`
+	issues := lintCodes(t, spec, "OAS-SCHEMA-ARRAY-ENUM")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/schemas/bands/enum", issues[0].Pointer)
+	require.Equal(t, `array schema has the enum value "B01" at index 0, which isn't an array, move the enum to items`, issues[0].Message)
+	require.Equal(t, "#/paths/~1collections/get/parameters/0/schema/enum", issues[1].Pointer)
+	require.Equal(t, `array schema has the enum value "asc" at index 1, which isn't an array, move the enum to items`, issues[1].Message)
+}
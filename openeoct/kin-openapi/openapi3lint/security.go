@@ -0,0 +1,170 @@
+package openapi3lint
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-OPERATION-UNSECURED",
+		Severity:    SeverityWarning,
+		Description: "Operations should declare security or inherit the security of the document, unless they are public.",
+		Rationale:   "An operation without security requirements is served to anonymous clients, which is rarely intended for a secured back end. Declare the security of the operation or of the document, and declare public operations with an empty security list or add them to the PublicOperations setting.",
+		Optional:    true,
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    get:
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+components:
+  securitySchemes:
+    Bearer: {type: http, scheme: bearer}
+security:
+  - Bearer: []
+paths:
+  /jobs:
+    get:
+      responses:
+        '200': {description: ok}
+  /:
+    get:
+      security: []
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkOperationUnsecured,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-SECURITY-SCOPE-UNDECLARED",
+		Severity:    SeverityError,
+		Description: "The scopes which security requirements require of an OAuth2 security scheme must be declared by the flows of the scheme.",
+		Rationale:   "Clients request the scopes declared by the flows, so they can't be authorized for an undeclared scope and a back end would reject them. Declare the scope in the scopes of the flows, or fix its name in the security requirement.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+components:
+  securitySchemes:
+    OAuth2:
+      type: oauth2
+      flows:
+        authorizationCode:
+          authorizationUrl: https://auth.example/authorize
+          tokenUrl: https://auth.example/token
+          scopes: {openid: Sign in}
+paths:
+  /jobs:
+    get:
+      security:
+        - OAuth2: [openid, jobs]
+      responses:
+        '200': {description: ok}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+components:
+  securitySchemes:
+    OAuth2:
+      type: oauth2
+      flows:
+        authorizationCode:
+          authorizationUrl: https://auth.example/authorize
+          tokenUrl: https://auth.example/token
+          scopes: {openid: Sign in, jobs: Manage batch jobs}
+paths:
+  /jobs:
+    get:
+      security:
+        - OAuth2: [openid, jobs]
+      responses:
+        '200': {description: ok}
+`,
+		Check: checkUndeclaredScopes,
+	})
+}
+
+func checkOperationUnsecured(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	operations := lintSettings(c).PublicOperations
+	public := make(map[string]bool, len(operations))
+	for _, operation := range operations {
+		public[operation] = true
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if public[method+" "+path] || operation.OperationID != "" && public[operation.OperationID] {
+			return
+		}
+		// An explicitly empty security list declares a public operation
+		if operation.Security != nil || len(swagger.Security) != 0 {
+			return
+		}
+		report(ptr, "operation %s %s declares no security and the document declares no default security", method, path)
+	})
+}
+
+// checkUndeclaredScopes reports the scopes required of OAuth2 security schemes
+// which their flows don't declare. The scopes of OpenID Connect security schemes
+// are discovered, so they can't be checked.
+func checkUndeclaredScopes(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	// Users of the undeclared scopes by scheme and scope, in order
+	users := make(map[string]map[string][]string)
+	use := func(requirements openapi3.SecurityRequirements, user string) {
+		for _, requirement := range requirements {
+			for _, name := range sortedKeys(requirement) {
+				ref := swagger.Components.SecuritySchemes[name]
+				if ref == nil || ref.Value == nil || ref.Value.Type != "oauth2" {
+					continue
+				}
+				for _, scope := range requirement[name] {
+					if declaresScope(ref.Value.Flows, scope) {
+						continue
+					}
+					if users[name] == nil {
+						users[name] = make(map[string][]string)
+					}
+					if scopeUsers := users[name][scope]; len(scopeUsers) == 0 || scopeUsers[len(scopeUsers)-1] != user {
+						users[name][scope] = append(scopeUsers, user)
+					}
+				}
+			}
+		}
+	}
+	use(swagger.Security, "the document")
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if operation.Security != nil {
+			use(*operation.Security, method+" "+path)
+		} else {
+			use(swagger.Security, method+" "+path)
+		}
+	})
+	for _, name := range sortedKeys(users) {
+		for _, scope := range sortedKeys(users[name]) {
+			report(pointer("components", "securitySchemes", name, "flows"), "scope %q of security scheme %q isn't declared by its flows, but required by %s", scope, name, strings.Join(users[name][scope], ", "))
+		}
+	}
+}
+
+// declaresScope returns whether one of the flows declares the scope.
+func declaresScope(flows *openapi3.OAuthFlows, scope string) bool {
+	if flows == nil {
+		return false
+	}
+	for _, flow := range []*openapi3.OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+		if flow == nil {
+			continue
+		}
+		if _, ok := flow.Scopes[scope]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationUnsecured(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  securitySchemes:
+    Bearer: {type: http, scheme: bearer}
+paths:
+  /:
+    get:
+      operationId: capabilities
+      responses:
+        '200': {description: ok}
+  /.well-known/openeo:
+    get:
+      responses:
+        '200': {description: ok}
+  /credentials/basic:
+    get:
+      security:
+        - Bearer: []
+      responses:
+        '200': {description: ok}
+  /file_formats:
+    get:
+      security: []
+      responses:
+        '200': {description: ok}
+  /jobs:
+    get:
+      responses:
+        '200': {description: ok}
+    post:
+      responses:
+        '201': {description: created}
+`
+	issues := lintCodes(t, spec, "OAS-OPERATION-UNSECURED")
+	require.Len(t, issues, 4)
+	require.Equal(t, "#/paths/~1/get", issues[0].Pointer)
+	require.Equal(t, "operation GET / declares no security and the document declares no default security", issues[0].Message)
+
+	settings := openapi3lint.Settings{PublicOperations: []string{"capabilities", "GET /.well-known/openeo"}}
+	issues = lintCodesWith(t, spec, settings, "OAS-OPERATION-UNSECURED")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/paths/~1jobs/get", issues[0].Pointer)
+	require.Equal(t, "#/paths/~1jobs/post", issues[1].Pointer)
+
+	require.Empty(t, lintCodes(t, spec+"security: [{Bearer: []}]\n", "OAS-OPERATION-UNSECURED"))
+}
+
+func TestUndeclaredScopes(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+components:
+  securitySchemes:
+    OAuth2:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://auth.example/authorize
+          scopes: {openid: Sign in}
+        clientCredentials:
+          tokenUrl: https://auth.example/token
+          scopes: {jobs: Manage batch jobs}
+    OIDC: {type: openIdConnect, openIdConnectUrl: https://auth.example/.well-known/openid-configuration}
+security:
+  - OAuth2: [openid, profile]
+paths:
+  /me:
+    get:
+      responses:
+        '200': {description: ok}
+  /jobs:
+    get:
+      security:
+        - OAuth2: [jobs]
+        - OIDC: [jobs, earthengine]
+      responses:
+        '200': {description: ok}
+    post:
+      security:
+        - OAuth2: [jobs, jobs:write]
+        - OAuth2: [profile, jobs:write]
+      responses:
+        '201': {description: created}
+`
+	issues := lintCodes(t, spec, "OAS-SECURITY-SCOPE-UNDECLARED")
+	require.Len(t, issues, 2)
+	require.Equal(t, "#/components/securitySchemes/OAuth2/flows", issues[0].Pointer)
+	require.Equal(t, `scope "jobs:write" of security scheme "OAuth2" isn't declared by its flows, but required by POST /jobs`, issues[0].Message)
+	require.Equal(t, `scope "profile" of security scheme "OAuth2" isn't declared by its flows, but required by the document, POST /jobs, GET /me`, issues[1].Message)
+}
@@ -0,0 +1,100 @@
+package openapi3lint
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-TAG-UNDECLARED",
+		Severity:    SeverityWarning,
+		Description: "Tags used by operations should be declared in the tags of the document.",
+		Rationale:   "Documentation tools group operations by the declared tags and list the others apart, and undeclared tags are often typos. Declare the tag with a description in the tags of the document.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths:
+  /jobs:
+    post:
+      tags: [Batch Jobs]
+      responses:
+        '201': {description: created}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+tags:
+  - {name: Batch Jobs, description: Management of batch jobs.}
+paths:
+  /jobs:
+    post:
+      tags: [Batch Jobs]
+      responses:
+        '201': {description: created}
+`,
+		Check: checkUndeclaredTags,
+	})
+	RegisterRule(&Rule{
+		Code:        "OAS-TAG-UNUSED",
+		Severity:    SeverityWarning,
+		Description: "Tags declared in the document should be used by an operation.",
+		Rationale:   "Unused tags show up as empty groups in documentation and are often left over after renaming a tag. Remove the tag, or tag the operations with it.",
+		Failing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+tags:
+  - {name: Batch Jobs}
+paths:
+  /jobs:
+    post:
+      responses:
+        '201': {description: created}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+tags:
+  - {name: Batch Jobs}
+paths:
+  /jobs:
+    post:
+      tags: [Batch Jobs]
+      responses:
+        '201': {description: created}
+`,
+		Check: checkUnusedTags,
+	})
+}
+
+func checkUndeclaredTags(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	declared := make(map[string]bool, len(swagger.Tags))
+	for _, tag := range swagger.Tags {
+		if tag != nil {
+			declared[tag.Name] = true
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		for i, name := range operation.Tags {
+			if !declared[name] {
+				report(ptr+"/tags/"+strconv.Itoa(i), "tag %q of %s %s is not declared", name, method, path)
+			}
+		}
+	})
+}
+
+func checkUnusedTags(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	used := make(map[string]bool)
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		for _, name := range operation.Tags {
+			used[name] = true
+		}
+	})
+	for i, tag := range swagger.Tags {
+		if tag != nil && !used[tag.Name] {
+			report(pointer("tags", strconv.Itoa(i)), "tag %q is not used by any operation", tag.Name)
+		}
+	}
+}
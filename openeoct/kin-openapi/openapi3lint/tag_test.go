@@ -0,0 +1,39 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const tagsSpec = `
+openapi: 3.0.0
+info: {title: An API, version: v1}
+tags:
+  - {name: Capabilities}
+  - {name: Batch Jobs}
+  - {name: File Storage}
+paths:
+  /:
+    get:
+      tags: [Capabilities]
+      responses: {'200': {description: ok}}
+  /jobs:
+    get:
+      tags: [Batch Jobs, Data Processing]
+      responses: {'200': {description: ok}}
+`
+
+func TestUndeclaredTags(t *testing.T) {
+	issues := lintCodes(t, tagsSpec, "OAS-TAG-UNDECLARED")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/paths/~1jobs/get/tags/1", issues[0].Pointer)
+	require.Equal(t, `tag "Data Processing" of GET /jobs is not declared`, issues[0].Message)
+}
+
+func TestUnusedTags(t *testing.T) {
+	issues := lintCodes(t, tagsSpec, "OAS-TAG-UNUSED")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/tags/2", issues[0].Pointer)
+	require.Equal(t, `tag "File Storage" is not used by any operation`, issues[0].Message)
+}
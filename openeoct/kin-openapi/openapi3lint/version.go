@@ -0,0 +1,37 @@
+package openapi3lint
+
+import (
+	"context"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+func init() {
+	RegisterRule(&Rule{
+		Code:        "OAS-OPENAPI-VERSION-UNTESTED",
+		Severity:    SeverityWarning,
+		Description: "The openapi version is a newer patch version than the validator is tested with, so its changes may not be supported.",
+		Rationale:   "Patch versions only clarify the specification, but the validator may not handle all of the clarifications of an untested one. Check its results with care, or declare the latest tested patch version.",
+		Failing: `
+openapi: 3.0.9
+info: {title: An API, version: v1}
+paths: {}
+`,
+		Passing: `
+openapi: 3.0.3
+info: {title: An API, version: v1}
+paths: {}
+`,
+		Check: checkUntestedOpenAPIVersion,
+	})
+}
+
+func checkUntestedOpenAPIVersion(c context.Context, swagger *openapi3.Swagger, report ReportFunc) {
+	version, err := openapi3.ParseOpenAPIVersion(swagger.OpenAPI)
+	if err != nil || version.Tested() {
+		return
+	}
+	if latest, ok := version.LatestTested(); ok {
+		report(pointer("openapi"), "openapi version %s is newer than version %s, the latest the validator is tested with", swagger.OpenAPI, latest)
+	}
+}
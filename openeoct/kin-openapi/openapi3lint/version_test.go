@@ -0,0 +1,20 @@
+package openapi3lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUntestedOpenAPIVersion(t *testing.T) {
+	spec := func(version string) string {
+		return "openapi: " + version + "\ninfo: {title: An API, version: v1}\npaths: {}\n"
+	}
+	require.Empty(t, lintCodes(t, spec("3.0.3"), "OAS-OPENAPI-VERSION-UNTESTED"))
+	require.Empty(t, lintCodes(t, spec("3.1.0"), "OAS-OPENAPI-VERSION-UNTESTED"))
+
+	issues := lintCodes(t, spec("3.0.4"), "OAS-OPENAPI-VERSION-UNTESTED")
+	require.Len(t, issues, 1)
+	require.Equal(t, "#/openapi", issues[0].Pointer)
+	require.Equal(t, "openapi version 3.0.4 is newer than version 3.0.3, the latest the validator is tested with", issues[0].Message)
+}
@@ -0,0 +1,432 @@
+package openapi3lint
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
+)
+
+var pointerTokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointer builds a JSON pointer from unescaped reference tokens.
+func pointer(tokens ...string) string {
+	escaped := make([]string, 0, len(tokens)+1)
+	escaped = append(escaped, "#")
+	for _, token := range tokens {
+		escaped = append(escaped, pointerTokenEscaper.Replace(token))
+	}
+	return strings.Join(escaped, "/")
+}
+
+// walkOperations calls fn for every operation of the document, in a stable order.
+func walkOperations(swagger *openapi3.Swagger, fn func(ptr string, path string, method string, operation *openapi3.Operation)) {
+	for _, info := range swagger.Operations() {
+		fn(pointer("paths", info.Path, strings.ToLower(info.Method)), info.Path, info.Method, info.Operation)
+	}
+}
+
+// walkResponses calls fn for every response of the document, in a stable order.
+// Responses shared through references are only visited once, preferably at their
+// definition in the components.
+func walkResponses(swagger *openapi3.Swagger, fn func(ptr string, response *openapi3.Response)) {
+	visited := make(map[*openapi3.Response]bool)
+	visit := func(ptr string, responses map[string]*openapi3.ResponseRef) {
+		for _, key := range sortedKeys(responses) {
+			ref := responses[key]
+			if ref == nil || ref.Value == nil || visited[ref.Value] {
+				continue
+			}
+			visited[ref.Value] = true
+			fn(ptr+"/"+pointerTokenEscaper.Replace(key), ref.Value)
+		}
+	}
+	visit(pointer("components", "responses"), swagger.Components.Responses)
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		visit(ptr+"/responses", operation.Responses)
+	})
+}
+
+// sortedKeys returns the keys of a map with string keys, sorted.
+func sortedKeys(m interface{}) []string {
+	value := reflect.ValueOf(m)
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// walkSchemas calls fn for every schema of the document, including nested
+// schemas, in a stable order. Schemas shared through references are only
+// visited once, preferably at their definition in the components.
+func walkSchemas(swagger *openapi3.Swagger, fn func(ptr string, schema *openapi3.Schema)) {
+	w := &schemaWalker{
+		visited: make(map[*openapi3.Schema]bool),
+		fn:      fn,
+	}
+	w.document(swagger)
+}
+
+// walkSchemaRefs calls fn for every schema reference of the document, including
+// those in nested schemas, in a stable order. Each reference is visited once,
+// also if it's part of a shared schema.
+func walkSchemaRefs(swagger *openapi3.Swagger, fn func(ptr string, ref *openapi3.SchemaRef)) {
+	w := &schemaWalker{
+		visited: make(map[*openapi3.Schema]bool),
+		fn:      func(string, *openapi3.Schema) {},
+		refFn:   fn,
+	}
+	w.document(swagger)
+}
+
+// document visits the schemas of the document.
+func (w *schemaWalker) document(swagger *openapi3.Swagger) {
+	components := swagger.Components
+	for _, name := range sortedKeys(components.Schemas) {
+		w.schemaRef(pointer("components", "schemas", name), components.Schemas[name])
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		if ref := components.Parameters[name]; ref != nil {
+			w.parameter(pointer("components", "parameters", name), ref.Value)
+		}
+	}
+	for _, name := range sortedKeys(components.Headers) {
+		if ref := components.Headers[name]; ref != nil {
+			w.header(pointer("components", "headers", name), ref.Value)
+		}
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		if ref := components.RequestBodies[name]; ref != nil && ref.Value != nil {
+			w.content(pointer("components", "requestBodies", name, "content"), ref.Value.Content)
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if ref := components.Responses[name]; ref != nil {
+			w.response(pointer("components", "responses", name), ref.Value)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			for i, ref := range pathItem.Parameters {
+				if ref != nil {
+					w.parameter(pointer("paths", path, "parameters", strconv.Itoa(i)), ref.Value)
+				}
+			}
+		}
+		for i, ref := range operation.Parameters {
+			if ref != nil {
+				w.parameter(ptr+"/parameters/"+strconv.Itoa(i), ref.Value)
+			}
+		}
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			w.content(ptr+"/requestBody/content", ref.Value.Content)
+		}
+		for _, status := range sortedKeys(operation.Responses) {
+			if ref := operation.Responses[status]; ref != nil {
+				w.response(ptr+"/responses/"+status, ref.Value)
+			}
+		}
+	})
+}
+
+// walkRequestSchemas calls fn for every schema of request bodies, including
+// nested schemas, in a stable order.
+func walkRequestSchemas(swagger *openapi3.Swagger, fn func(ptr string, schema *openapi3.Schema)) {
+	w := &schemaWalker{
+		visited: make(map[*openapi3.Schema]bool),
+		fn:      fn,
+	}
+	requestBodies := swagger.Components.RequestBodies
+	for _, name := range sortedKeys(requestBodies) {
+		if ref := requestBodies[name]; ref != nil && ref.Value != nil {
+			w.content(pointer("components", "requestBodies", name, "content"), ref.Value.Content)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			w.content(ptr+"/requestBody/content", ref.Value.Content)
+		}
+	})
+}
+
+// walkParameterSchemas calls fn for every schema of parameters and headers,
+// including nested schemas, in a stable order.
+func walkParameterSchemas(swagger *openapi3.Swagger, fn func(ptr string, schema *openapi3.Schema)) {
+	w := &schemaWalker{
+		visited: make(map[*openapi3.Schema]bool),
+		fn:      fn,
+	}
+	components := swagger.Components
+	for _, name := range sortedKeys(components.Parameters) {
+		if ref := components.Parameters[name]; ref != nil {
+			w.parameter(pointer("components", "parameters", name), ref.Value)
+		}
+	}
+	for _, name := range sortedKeys(components.Headers) {
+		if ref := components.Headers[name]; ref != nil {
+			w.header(pointer("components", "headers", name), ref.Value)
+		}
+	}
+	responseHeaders := func(ptr string, response *openapi3.Response) {
+		for _, name := range sortedKeys(response.Headers) {
+			if ref := response.Headers[name]; ref != nil {
+				w.header(ptr+"/headers/"+pointerTokenEscaper.Replace(name), ref.Value)
+			}
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if ref := components.Responses[name]; ref != nil && ref.Value != nil {
+			responseHeaders(pointer("components", "responses", name), ref.Value)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			for i, ref := range pathItem.Parameters {
+				if ref != nil {
+					w.parameter(pointer("paths", path, "parameters", strconv.Itoa(i)), ref.Value)
+				}
+			}
+		}
+		for i, ref := range operation.Parameters {
+			if ref != nil {
+				w.parameter(ptr+"/parameters/"+strconv.Itoa(i), ref.Value)
+			}
+		}
+		for _, status := range sortedKeys(operation.Responses) {
+			if ref := operation.Responses[status]; ref != nil && ref.Value != nil {
+				responseHeaders(ptr+"/responses/"+status, ref.Value)
+			}
+		}
+	})
+}
+
+// walkParameters calls fn for every parameter of the document, in a stable
+// order. Parameters shared through references are only visited once,
+// preferably at their definition in the components.
+func walkParameters(swagger *openapi3.Swagger, fn func(ptr string, parameter *openapi3.Parameter)) {
+	visited := make(map[*openapi3.Parameter]bool)
+	visit := func(ptr string, ref *openapi3.ParameterRef) {
+		if ref == nil || ref.Value == nil || visited[ref.Value] {
+			return
+		}
+		visited[ref.Value] = true
+		fn(ptr, ref.Value)
+	}
+	for _, name := range sortedKeys(swagger.Components.Parameters) {
+		visit(pointer("components", "parameters", name), swagger.Components.Parameters[name])
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			for i, ref := range pathItem.Parameters {
+				visit(pointer("paths", path, "parameters", strconv.Itoa(i)), ref)
+			}
+		}
+		for i, ref := range operation.Parameters {
+			visit(ptr+"/parameters/"+strconv.Itoa(i), ref)
+		}
+	})
+}
+
+// walkJSONSubschemas calls fn for every schema nested in the schemas of JSON
+// request and response bodies, e.g. their properties, in a stable order. The
+// schemas of the bodies themselves aren't visited.
+func walkJSONSubschemas(swagger *openapi3.Swagger, fn func(ptr string, schema *openapi3.Schema)) {
+	w := &schemaWalker{
+		visited: make(map[*openapi3.Schema]bool),
+		fn:      fn,
+	}
+	content := func(ptr string, content openapi3.Content) {
+		for _, mediaType := range sortedKeys(content) {
+			if v := content[mediaType]; v != nil && v.Schema != nil && v.Schema.Value != nil && isJSONMediaType(mediaType) {
+				w.subschemas(ptr+"/"+pointerTokenEscaper.Replace(mediaType)+"/schema", v.Schema.Value)
+			}
+		}
+	}
+	components := swagger.Components
+	for _, name := range sortedKeys(components.RequestBodies) {
+		if ref := components.RequestBodies[name]; ref != nil && ref.Value != nil {
+			content(pointer("components", "requestBodies", name, "content"), ref.Value.Content)
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if ref := components.Responses[name]; ref != nil && ref.Value != nil {
+			content(pointer("components", "responses", name, "content"), ref.Value.Content)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			content(ptr+"/requestBody/content", ref.Value.Content)
+		}
+		for _, status := range sortedKeys(operation.Responses) {
+			if ref := operation.Responses[status]; ref != nil && ref.Value != nil {
+				content(ptr+"/responses/"+status+"/content", ref.Value.Content)
+			}
+		}
+	})
+}
+
+// isJSONMediaType returns whether mediaType, possibly with parameters, is JSON.
+func isJSONMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+type schemaWalker struct {
+	visited map[*openapi3.Schema]bool
+	fn      func(ptr string, schema *openapi3.Schema)
+	// refFn, if set, is called for every schema reference
+	refFn func(ptr string, ref *openapi3.SchemaRef)
+}
+
+func (w *schemaWalker) schemaRef(ptr string, ref *openapi3.SchemaRef) {
+	if ref != nil && ref.Ref != "" && w.refFn != nil {
+		w.refFn(ptr, ref)
+	}
+	if ref == nil || ref.Value == nil || w.visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	w.visited[schema] = true
+	w.fn(ptr, schema)
+	w.subschemas(ptr, schema)
+}
+
+// subschemas visits the schemas nested in schema.
+func (w *schemaWalker) subschemas(ptr string, schema *openapi3.Schema) {
+	for _, name := range sortedKeys(schema.Properties) {
+		w.schemaRef(ptr+"/properties/"+pointerTokenEscaper.Replace(name), schema.Properties[name])
+	}
+	w.schemaRef(ptr+"/additionalProperties", schema.AdditionalProperties)
+	w.schemaRef(ptr+"/items", schema.Items)
+	for i, item := range schema.PrefixItems {
+		w.schemaRef(ptr+"/prefixItems/"+strconv.Itoa(i), item)
+	}
+	for i, item := range schema.AllOf {
+		w.schemaRef(ptr+"/allOf/"+strconv.Itoa(i), item)
+	}
+	for i, item := range schema.AnyOf {
+		w.schemaRef(ptr+"/anyOf/"+strconv.Itoa(i), item)
+	}
+	for i, item := range schema.OneOf {
+		w.schemaRef(ptr+"/oneOf/"+strconv.Itoa(i), item)
+	}
+	w.schemaRef(ptr+"/not", schema.Not)
+	w.schemaRef(ptr+"/if", schema.If)
+	w.schemaRef(ptr+"/then", schema.Then)
+	w.schemaRef(ptr+"/else", schema.Else)
+}
+
+func (w *schemaWalker) content(ptr string, content openapi3.Content) {
+	for _, mediaType := range sortedKeys(content) {
+		if v := content[mediaType]; v != nil {
+			w.schemaRef(ptr+"/"+pointerTokenEscaper.Replace(mediaType)+"/schema", v.Schema)
+		}
+	}
+}
+
+func (w *schemaWalker) parameter(ptr string, parameter *openapi3.Parameter) {
+	if parameter == nil {
+		return
+	}
+	w.schemaRef(ptr+"/schema", parameter.Schema)
+	w.content(ptr+"/content", parameter.Content)
+}
+
+func (w *schemaWalker) header(ptr string, header *openapi3.Header) {
+	if header == nil {
+		return
+	}
+	w.schemaRef(ptr+"/schema", header.Schema)
+	w.content(ptr+"/content", header.Content)
+}
+
+func (w *schemaWalker) response(ptr string, response *openapi3.Response) {
+	if response == nil {
+		return
+	}
+	for _, name := range sortedKeys(response.Headers) {
+		if ref := response.Headers[name]; ref != nil {
+			w.header(ptr+"/headers/"+pointerTokenEscaper.Replace(name), ref.Value)
+		}
+	}
+	w.content(ptr+"/content", response.Content)
+}
+
+// walkExamples calls fn for the examples of every parameter and media type of
+// the document, in a stable order. Objects shared through references are only
+// visited once, preferably at their definition in the components.
+func walkExamples(swagger *openapi3.Swagger, fn func(ptr string, examples map[string]*openapi3.ExampleRef)) {
+	w := &exampleWalker{
+		visited: make(map[interface{}]bool),
+		fn:      fn,
+	}
+	components := swagger.Components
+	for _, name := range sortedKeys(components.Parameters) {
+		if ref := components.Parameters[name]; ref != nil {
+			w.parameter(pointer("components", "parameters", name), ref.Value)
+		}
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		if ref := components.RequestBodies[name]; ref != nil && ref.Value != nil {
+			w.content(pointer("components", "requestBodies", name, "content"), ref.Value.Content)
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if ref := components.Responses[name]; ref != nil && ref.Value != nil {
+			w.content(pointer("components", "responses", name, "content"), ref.Value.Content)
+		}
+	}
+	walkOperations(swagger, func(ptr string, path string, method string, operation *openapi3.Operation) {
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			for i, ref := range pathItem.Parameters {
+				if ref != nil {
+					w.parameter(pointer("paths", path, "parameters", strconv.Itoa(i)), ref.Value)
+				}
+			}
+		}
+		for i, ref := range operation.Parameters {
+			if ref != nil {
+				w.parameter(ptr+"/parameters/"+strconv.Itoa(i), ref.Value)
+			}
+		}
+		if ref := operation.RequestBody; ref != nil && ref.Value != nil {
+			w.content(ptr+"/requestBody/content", ref.Value.Content)
+		}
+		for _, status := range sortedKeys(operation.Responses) {
+			if ref := operation.Responses[status]; ref != nil && ref.Value != nil {
+				w.content(ptr+"/responses/"+status+"/content", ref.Value.Content)
+			}
+		}
+	})
+}
+
+type exampleWalker struct {
+	visited map[interface{}]bool
+	fn      func(ptr string, examples map[string]*openapi3.ExampleRef)
+}
+
+func (w *exampleWalker) parameter(ptr string, parameter *openapi3.Parameter) {
+	if parameter == nil || w.visited[parameter] {
+		return
+	}
+	w.visited[parameter] = true
+	if len(parameter.Examples) != 0 {
+		w.fn(ptr+"/examples", parameter.Examples)
+	}
+	w.content(ptr+"/content", parameter.Content)
+}
+
+func (w *exampleWalker) content(ptr string, content openapi3.Content) {
+	for _, mediaType := range sortedKeys(content) {
+		v := content[mediaType]
+		if v == nil || w.visited[v] || len(v.Examples) == 0 {
+			continue
+		}
+		w.visited[v] = true
+		w.fn(ptr+"/"+pointerTokenEscaper.Replace(mediaType)+"/examples", v.Examples)
+	}
+}
@@ -5,6 +5,7 @@
 //   * "/abc""
 //   * "/abc/{variable}" (matches until next '/' or end-of-string)
 //   * "/abc/{variable*}" (matches everything, including "/abc" if "/abc" has noot)
+//   * "/abc/{+variable}" (same as "/abc/{variable*}")
 //   * "/abc/{ variable | prefix_(.*}_suffix }" (matches regular expressions)
 package pathpattern
 
@@ -227,7 +228,7 @@ loop:
 					}
 				}
 				if suffix.Kind == SuffixKindVariable && options.SupportWildcard {
-					if strings.HasSuffix(variableName, "*") {
+					if strings.HasSuffix(variableName, "*") || strings.HasPrefix(variableName, "+") {
 						suffix.Kind = SuffixKindEverything
 					}
 				}
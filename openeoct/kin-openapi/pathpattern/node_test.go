@@ -22,6 +22,7 @@ func TestPatterns(t *testing.T) {
 	add("/abc/{fileName|(.*)\\.jpeg}", "JPEG")
 	add("/abc/{fileName|some_prefix_(.*)\\.jpeg}", "PREFIXED JPEG")
 	add("/root/{path*}", "DIRECTORY")
+	add("/files/{+path}", "FILES")
 	add("/impossible_route", "IMPOSSIBLE")
 
 	add(pathpattern.PathFromHost("www.nike.com", true), "WWW-HOST")
@@ -64,6 +65,8 @@ func TestPatterns(t *testing.T) {
 	expect("/root", "DIRECTORY", "")
 	expect("/root/", "DIRECTORY", "")
 	expect("/root/a/b/c", "DIRECTORY", "a/b/c")
+	expect("/files/a", "FILES", "a")
+	expect("/files/a/b/c", "FILES", "a/b/c")
 
 	expect(pathpattern.PathFromHost("www.nike.com", true), "WWW-HOST")
 	expect(pathpattern.PathFromHost("example.nike.com", true), "OTHER-HOST", "example")
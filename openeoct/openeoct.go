@@ -4,8 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-
-	//"fmt"
+	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -13,13 +14,16 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3"
 	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3filter"
+	"github.com/Open-EO/openeo-backend-validator/openeoct/kin-openapi/openapi3lint"
 
 	"github.com/BurntSushi/toml"
 	"github.com/mcuadros/go-version"
@@ -54,7 +58,8 @@ type CapEndpoint struct {
 
 // Capabilities helper"class"
 type Capability struct {
-	Endpoints []CapEndpoint
+	Api_version string
+	Endpoints   []CapEndpoint
 }
 
 // Endpoint "class"
@@ -104,6 +109,33 @@ type ComplianceTest struct {
 	debug        bool
 	router       *openapi3filter.Router
 	capabilities Capability
+
+	disableformatvalidation bool
+	ignorepointers          []string
+	checkcapabilities       bool
+	pathfilter              []string
+	checkresponsestatus     bool
+	matchprofiles           bool
+	checkprocesses          bool
+	checkpaging             bool
+	externalrefs            bool
+	preset                  string
+	severities              map[string]string
+	formatseverities        map[string]openapi3.FormatSeverity
+
+	// External documents loaded for references of the openEO API, the first reference by URI
+	loadedrefs map[string]string
+
+	conformance        []string
+	conformanceclasses map[string][]string
+
+	batch            bool
+	contract         bool
+	batchoperations  []string
+	batchconcurrency int
+	batchrate        float64
+	batchhostrates   map[string]float64
+	batchheaders     map[string]string
 }
 
 // Elements of the Config file
@@ -118,6 +150,113 @@ type Config struct {
 	Config         string
 	Variables      map[string]string
 	Backendversion string
+
+	Disableformatvalidation bool
+	Formatseverities        map[string]string
+	Ignorepointers          []string
+	Checkcapabilities       bool
+	Pathfilter              []string
+	Checkresponsestatus     bool
+	Matchprofiles           bool
+	Checkprocesses          bool
+	Checkpaging             bool
+	Externalrefs            bool
+	Preset                  string
+	Severities              map[string]string
+
+	Conformance        []string
+	Conformanceclasses map[string][]string
+
+	Batchoperations  []string
+	Batchconcurrency int
+	Batchrate        float64
+	Batchhostrates   map[string]float64
+	Batchheaders     map[string]string
+}
+
+// Result of a compliance test run, written to the output
+type Result struct {
+	Result  map[string](map[string]interface{}) `json:"result"`
+	Stats   map[string](map[string]interface{}) `json:"stats"`
+	Summary Summary                             `json:"summary"`
+}
+
+// Summary counts the checks of a run, i.e. the endpoint states in all groups,
+// by severity, by state and the lint issues by check code. Skipped endpoints
+// aren't counted as checks.
+type Summary struct {
+	Checks   int            `json:"checks"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	States   map[string]int `json:"states"`
+	Codes    map[string]int `json:"codes"`
+	Verdict  string         `json:"verdict"`
+}
+
+// The openEO API is loaded again for every endpoint, only validate it once
+var validationCache = openapi3.NewValidationCache()
+
+// Severities of the endpoint states in the summary, other states are errors. Notices aren't counted as checks.
+var DEFAULT_SEVERITIES = map[string]string{
+	"Valid":        "info",
+	"Skipped":      "info",
+	"Loaded":       "notice",
+	"NotSupported": "warning",
+	"NoExample":    "warning",
+	"LintWarning":  "warning",
+	"LintInfo":     "info",
+}
+
+// Severities of the endpoint states by preset, layered over the default severities. The strictest preset also
+// enables all additional checks, see applyPreset.
+var PRESET_SEVERITIES = map[string]map[string]string{
+	openapi3lint.PresetMinimal: {
+		"NotSupported": "info",
+		"NoExample":    "info",
+	},
+	openapi3lint.PresetRecommended: {},
+	openapi3lint.PresetStrictest: {
+		"NotSupported": "error",
+		"NoExample":    "error",
+	},
+}
+
+// Paginated list endpoints of the openEO API with the array property listing their items, the responses also need
+// a "links" array to navigate to the next page
+var PAGED_ENDPOINTS = map[string]string{
+	"/collections":    "collections",
+	"/jobs":           "jobs",
+	"/processes":      "processes",
+	"/process_graphs": "processes",
+	"/services":       "services",
+	"/files":          "files",
+}
+
+// Concurrent requests of a batch run if not configured
+const DEFAULT_BATCH_CONCURRENCY = 4
+
+// Endpoints which the openEO API must define for the conformance classes, the config can add further classes
+var DEFAULT_CONFORMANCE_CLASSES = map[string][]string{
+	"capabilities":           {"GET /", "GET /.well-known/openeo"},
+	"authentication-basic":   {"GET /credentials/basic"},
+	"authentication-oidc":    {"GET /credentials/oidc"},
+	"data-discovery":         {"GET /collections", "GET /collections/{collection_id}"},
+	"processes":              {"GET /processes"},
+	"file-formats":           {"GET /file_formats"},
+	"synchronous-processing": {"POST /result"},
+	"batch-jobs": {
+		"GET /jobs", "POST /jobs", "GET /jobs/{job_id}", "PATCH /jobs/{job_id}", "DELETE /jobs/{job_id}",
+		"POST /jobs/{job_id}/results", "GET /jobs/{job_id}/results", "DELETE /jobs/{job_id}/results",
+	},
+	"secondary-services": {
+		"GET /service_types", "GET /services", "POST /services",
+		"GET /services/{service_id}", "PATCH /services/{service_id}", "DELETE /services/{service_id}",
+	},
+	"user-defined-processes": {
+		"GET /process_graphs", "GET /process_graphs/{process_graph_id}",
+		"PUT /process_graphs/{process_graph_id}", "DELETE /process_graphs/{process_graph_id}",
+	},
+	"file-storage": {"GET /files", "GET /files/{path}", "PUT /files/{path}", "DELETE /files/{path}"},
 }
 
 var CAP_EXCEPTIONS = map[string]bool{
@@ -138,44 +277,19 @@ func (ct *ComplianceTest) validateAll() (map[string](map[string]string), *ErrorM
 
 	states := make(map[string](map[string]string))
 
-	token := ""
-
-	authentication_err := new(ErrorMessage)
-
-	// Set Authentication Token
-	if ct.username != "" && ct.password != "" && ct.authendpoint != "" {
-
-		client := &http.Client{}
-
-		httpReq, _ := http.NewRequest(http.MethodGet, build_url(ct.backend.url, ct.authendpoint), nil)
-		httpReq.SetBasicAuth(ct.username, ct.password)
-		resp, errResp := client.Do(httpReq)
-
-		if errResp != nil {
-			authentication_err.input = build_url(ct.backend.url, ct.authendpoint)
-			authentication_err.msg = "Error calling the authentication url! Wrong credentials?"
-			authentication_err.output = string(errResp.Error())
-
-		} else if resp.StatusCode == 200 {
-			body, _ := ioutil.ReadAll(resp.Body)
-			m := make(map[string]interface{})
-			json.Unmarshal(body, &m)
-			token, _ = m["access_token"].(string)
-			authentication_err = nil
-		} else {
-			authentication_err.input = build_url(ct.backend.url, ct.authendpoint)
-			authentication_err.msg = "Error calling the authentication url! Wrong credentials?"
-			authentication_err.output = ""
-		}
-	} else {
-		authentication_err = nil
-	}
+	token, authentication_err := ct.authenticate()
 
 	for _, endpoints := range ct.endpoints {
 		//Sorting within the group
 		sort.Sort(ByOrder(endpoints))
 
 		for _, endpoint := range endpoints {
+			if !ct.matchesPathFilter(endpoint.Url) {
+				states[endpoint.Id] = make(map[string]string)
+				states[endpoint.Id]["message"] = "Endpoint skipped, not matching the path filter"
+				states[endpoint.Id]["state"] = "Skipped"
+				continue
+			}
 			if (ct.checkCapability(endpoint) == false) && (!CAP_EXCEPTIONS[endpoint.Url]) {
 				states[endpoint.Id] = make(map[string]string)
 				states[endpoint.Id]["message"] = "Endpoint skipped, not listed in backend capabilities"
@@ -218,6 +332,43 @@ func (ct *ComplianceTest) validateAll() (map[string](map[string]string), *ErrorM
 	return states, authentication_err
 }
 
+// Requests an access token with the credentials of the config, the token is empty without credentials
+func (ct *ComplianceTest) authenticate() (string, *ErrorMessage) {
+	token := ""
+
+	authentication_err := new(ErrorMessage)
+
+	// Set Authentication Token
+	if ct.username != "" && ct.password != "" && ct.authendpoint != "" {
+
+		client := &http.Client{}
+
+		httpReq, _ := http.NewRequest(http.MethodGet, build_url(ct.backend.url, ct.authendpoint), nil)
+		httpReq.SetBasicAuth(ct.username, ct.password)
+		resp, errResp := client.Do(httpReq)
+
+		if errResp != nil {
+			authentication_err.input = build_url(ct.backend.url, ct.authendpoint)
+			authentication_err.msg = "Error calling the authentication url! Wrong credentials?"
+			authentication_err.output = string(errResp.Error())
+
+		} else if resp.StatusCode == 200 {
+			body, _ := ioutil.ReadAll(resp.Body)
+			m := make(map[string]interface{})
+			json.Unmarshal(body, &m)
+			token, _ = m["access_token"].(string)
+			authentication_err = nil
+		} else {
+			authentication_err.input = build_url(ct.backend.url, ct.authendpoint)
+			authentication_err.msg = "Error calling the authentication url! Wrong credentials?"
+			authentication_err.output = ""
+		}
+	} else {
+		authentication_err = nil
+	}
+	return token, authentication_err
+}
+
 func loadVariable(value string, variables map[string]string) string {
 	var_name := GetStringInBetween(value, "{", "}")
 	if var_name != "" {
@@ -345,6 +496,154 @@ func (ct *ComplianceTest) buildRequest(endpoint Endpoint, token string, abs_url
 
 // Validates a single endpoint defined as input parameter.
 // Returns the resulting state and an error message if something went wrong.
+// Reads the openEO API from the apifile, either a file or an url
+func (ct *ComplianceTest) loadAPI() (*openapi3.Swagger, *ErrorMessage) {
+	// Try to read the openapi3 file
+	swagger, err := ct.newLoader().LoadSwaggerFromFile(ct.apifile)
+
+	if err != nil {
+		// openapi3 file not found, assume it is an URI
+		apiReq, _ := http.NewRequest(http.MethodGet, ct.apifile, nil)
+		swagger, err = ct.newLoader().LoadSwaggerFromURI(apiReq.URL)
+	}
+
+	if err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = string(ct.apifile)
+		errormsg.msg = "Error reading the openEO API, neighter file nor url found"
+		errormsg.output = string(err.Error())
+		return nil, errormsg
+	}
+	return swagger, nil
+}
+
+// newLoader returns a loader of the openEO API, which records the external documents it loads
+func (ct *ComplianceTest) newLoader() *openapi3.SwaggerLoader {
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = ct.externalrefs
+	loader.ExternalRefLoaded = func(ref string, uri *url.URL) {
+		if ct.loadedrefs == nil {
+			ct.loadedrefs = make(map[string]string)
+		}
+		if _, loaded := ct.loadedrefs[uri.String()]; !loaded {
+			ct.loadedrefs[uri.String()] = ref
+		}
+	}
+	return loader
+}
+
+// externalRefsGroup returns the group of the external documents loaded for references of the openEO API,
+// which are notices that don't affect the verdict
+func (ct *ComplianceTest) externalRefsGroup() map[string]interface{} {
+	endpoints := make(map[string](map[string]string))
+	for uri, ref := range ct.loadedrefs {
+		endpoints[uri] = map[string]string{
+			"state":   "Loaded",
+			"message": "Loaded for the reference " + ref,
+			"url":     uri,
+			"type":    "GET",
+		}
+	}
+	group := make(map[string]interface{})
+	group["group_summary"] = "Valid"
+	group["endpoints"] = endpoints
+	return group
+}
+
+// lintGroup returns the group of the lint issues of the openEO API, by check code and pointer, with the rules and
+// severities of the preset. The states are "LintError", "LintWarning" and "LintInfo" by the severity of the issue.
+func (ct *ComplianceTest) lintGroup(swagger *openapi3.Swagger) map[string]interface{} {
+	preset := ct.preset
+	if preset == "" {
+		preset = openapi3lint.PresetRecommended
+	}
+	linter, err := openapi3lint.NewPresetLinter(preset)
+	if err != nil {
+		log.Fatal("Error: ", err)
+	}
+	endpoints := make(map[string](map[string]string))
+	group := make(map[string]interface{})
+	group["group_summary"] = "Valid"
+	group["endpoints"] = endpoints
+	for _, issue := range linter.Lint(context.Background(), swagger) {
+		id := issue.Code + " " + issue.Pointer
+		// Rules may report several issues at the same pointer
+		for i := 2; endpoints[id] != nil; i++ {
+			id = fmt.Sprintf("%s %s (%d)", issue.Code, issue.Pointer, i)
+		}
+		state := "LintInfo"
+		switch issue.Severity {
+		case openapi3lint.SeverityError:
+			state = "LintError"
+			group["group_summary"] = "Invalid"
+		case openapi3lint.SeverityWarning:
+			state = "LintWarning"
+		}
+		endpoints[id] = map[string]string{
+			"state":   state,
+			"code":    issue.Code,
+			"message": issue.Message,
+			"pointer": issue.Pointer,
+			"url":     ct.apifile,
+			"type":    "",
+		}
+	}
+	return group
+}
+
+// Returns a router of the openEO API, which validates the openEO API according to the config
+func (ct *ComplianceTest) newRouter(swagger *openapi3.Swagger) *openapi3filter.Router {
+	validationOptions := &openapi3.ValidationOptions{
+		IgnoredPointers: ct.ignorepointers,
+		Paths:           ct.pathfilter,
+		Concurrency:     runtime.NumCPU(),
+		Skipped: func(pointer string) {
+			if ct.debug {
+				log.Println("Skipped validation of the openEO API at " + pointer)
+			}
+		},
+	}
+	return openapi3filter.NewRouter().WithValidationCache(validationCache).WithValidationOptions(validationOptions).WithSwagger(swagger)
+}
+
+// Returns the options for the validation of the request and its response
+func (ct *ComplianceTest) filterOptions(httpReq *http.Request) *openapi3filter.Options {
+	return &openapi3filter.Options{
+		DisableFormatValidation: ct.disableformatvalidation,
+		FormatWarnings:          logFormatWarning,
+		IncludeResponseStatus:   ct.checkresponsestatus,
+		// Backends must respond with a media type the request accepts
+		IncludeResponseAccept: true,
+		MatchResponseProfile:  ct.matchprofiles,
+		// openEO updates resources with PATCH, sending only the changed properties
+		PartialRequestBody: httpReq.Method == http.MethodPatch,
+		AuthenticationFunc: func(c context.Context, input *openapi3filter.AuthenticationInput) error {
+			// TODO: support more schemes
+			sec := input.SecurityScheme
+			if sec.Type == "http" && sec.Scheme == "bearer" {
+				if httpReq.Header.Get("Authorization") == "" {
+					return nil //errors.New("Missing auth")
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// schemaValidationOptions returns the options for validating documents of the back end against schemas
+func (ct *ComplianceTest) schemaValidationOptions() []openapi3.SchemaValidationOption {
+	opts := []openapi3.SchemaValidationOption{openapi3.FormatWarnings(logFormatWarning)}
+	if ct.disableformatvalidation {
+		opts = append(opts, openapi3.DisableFormatValidation())
+	}
+	return opts
+}
+
+// logFormatWarning logs values which don't have a format configured as warning in formatseverities
+func logFormatWarning(err *openapi3.SchemaError) {
+	log.Println("Warning:", err.Error())
+}
+
 func (ct *ComplianceTest) validate(endpoint Endpoint, token string) (string, *ErrorMessage) {
 	//log.Println(openapi3.SchemaStringFormats)
 	//openapi3.DefineStringFormat("url", `^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
@@ -360,26 +659,13 @@ func (ct *ComplianceTest) validate(endpoint Endpoint, token string) (string, *Er
 		}
 	}
 
-	_, err := os.Stat(ct.apifile)
-
-	// Try to read the openapi3 file
-	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile(ct.apifile)
-
-	if err != nil {
-		// openapi3 file not found, assume it is an URI
-		apiReq, _ := http.NewRequest(http.MethodGet, ct.apifile, nil)
-		swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromURI(apiReq.URL)
-	}
+	swagger, errormsg := ct.loadAPI()
 
-	if err != nil {
-		errormsg := new(ErrorMessage)
-		errormsg.input = string(ct.apifile)
-		errormsg.msg = "Error reading the openEO API, neighter file nor url found"
-		errormsg.output = string(err.Error())
+	if errormsg != nil {
 		return "Error", errormsg
 	}
 
-	router := openapi3filter.NewRouter().WithSwagger(swagger)
+	router := ct.newRouter(swagger)
 	ct.router = router
 	ctx := context.TODO()
 
@@ -419,18 +705,7 @@ func (ct *ComplianceTest) validate(endpoint Endpoint, token string) (string, *Er
 	}
 
 	// Options for the validation
-	options := &openapi3filter.Options{
-		AuthenticationFunc: func(c context.Context, input *openapi3filter.AuthenticationInput) error {
-			// TODO: support more schemes
-			sec := input.SecurityScheme
-			if sec.Type == "http" && sec.Scheme == "bearer" {
-				if httpReq.Header.Get("Authorization") == "" {
-					return nil //errors.New("Missing auth")
-				}
-			}
-			return nil
-		},
-	}
+	options := ct.filterOptions(httpReq)
 
 	// Validate request
 	requestValidationInput := &openapi3filter.RequestValidationInput{
@@ -559,7 +834,8 @@ func (ct *ComplianceTest) validate(endpoint Endpoint, token string) (string, *Er
 	responseValidationInput := &openapi3filter.ResponseValidationInput{
 		RequestValidationInput: requestValidationInput,
 		Status:                 respStatus,
-		Header:                 respHeader}
+		Header:                 respHeader,
+		Options:                options}
 
 	if respBody != "" {
 		responseValidationInput.SetBodyBytes([]byte(respBody))
@@ -595,69 +871,556 @@ func (ct *ComplianceTest) validate(endpoint Endpoint, token string) (string, *Er
 	return "Valid", nil
 }
 
-// Reads info from config file
-func ReadConfig(config_file string) Config {
-	var configfile = config_file
-	var config Config
-
-	// Check if file exists
-	_, err := os.Stat(configfile)
+// Requests the path of the back end without authentication and returns the body of a successful response
+func (ct *ComplianceTest) fetchBackendJSON(path string) ([]byte, *ErrorMessage) {
+	resp, err := http.Get(build_url(ct.backend.url, path))
 	if err != nil {
-		log.Fatal("Config file is missing: ", configfile)
+		errormsg := new(ErrorMessage)
+		errormsg.input = "GET  " + path
+		errormsg.msg = "Error sending request to back end"
+		errormsg.output = err.Error()
+		return nil, errormsg
 	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
 
-	// Read file if TOML File
+	if resp.StatusCode != 200 {
+		errormsg := new(ErrorMessage)
+		errormsg.input = "GET  " + path
+		errormsg.msg = "Response Code " + strconv.Itoa(resp.StatusCode)
+		errormsg.output = string(body)
+		return nil, errormsg
+	}
+	return body, nil
+}
 
-	if _, err := toml.DecodeFile(configfile, &config); err != nil {
+// Returns the JSON schema of the 200 response of GET path in the openEO API, nil if there is none
+func getResponseSchema(swagger *openapi3.Swagger, path string) *openapi3.Schema {
+	if pathItem := swagger.Paths.Find(path); pathItem != nil && pathItem.Get != nil {
+		if response := pathItem.Get.Responses.Get(200); response != nil && response.Value != nil {
+			if mediaType := response.Value.Content.Get("application/json"); mediaType != nil && mediaType.Schema != nil {
+				return mediaType.Schema.Value
+			}
+		}
+	}
+	return nil
+}
 
-		//Read file as JSON File
-		data, err2 := ioutil.ReadFile(config_file)
+// Validates the capabilities document of the back end (GET /) against the schema of the openEO API
+// and cross-checks the endpoints it lists with the paths of the openEO API.
+// Returns a map of strings containing the states of both checks, like validateAll
+func (ct *ComplianceTest) checkCapabilitiesDocument() map[string](map[string]string) {
+	states := map[string](map[string]string){
+		"capabilities_schema":      {"state": "Valid", "message": ""},
+		"capabilities_endpoints":   {"state": "Valid", "message": ""},
+		"capabilities_api_version": {"state": "Valid", "message": ""},
+	}
+	setError := func(state string, errormsg *ErrorMessage) {
+		for id := range states {
+			states[id]["state"] = state
+			states[id]["message"] = errormsg.toString()
+		}
+	}
 
-		if err2 != nil {
-			log.Println("Error reading Config file as TOML: ", err)
-			log.Fatal("Error reading Config file as JSON: ", err2)
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		setError("Error", errormsg)
+		return states
+	}
+
+	body, errormsg := ct.fetchBackendJSON("/")
+	if errormsg != nil {
+		setError("Invalid", errormsg)
+		return states
+	}
+
+	var document interface{}
+	var capa Capability
+	err := json.Unmarshal(body, &document)
+	if err == nil {
+		err = json.Unmarshal(body, &capa)
+	}
+	if err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = "GET  /"
+		errormsg.msg = "Capabilities of the back end are not valid JSON"
+		errormsg.output = err.Error()
+		setError("Invalid", errormsg)
+		return states
+	}
+
+	// Validate against the schema of the GET / response of the openEO API
+	schema := getResponseSchema(swagger, "/")
+	opts := ct.schemaValidationOptions()
+	if schema == nil {
+		states["capabilities_schema"]["state"] = "Error"
+		states["capabilities_schema"]["message"] = "The openEO API defines no JSON schema for the GET / response"
+	} else if err := schema.VisitJSON(document, opts...); err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = "GET  /"
+		errormsg.msg = "Capabilities of the back end not valid"
+		errormsg.output = err.Error()
+		states["capabilities_schema"]["state"] = "Invalid"
+		states["capabilities_schema"]["message"] = errormsg.toString()
+	}
+
+	// Cross-check the listed endpoints with the openEO API
+	var mismatches []string
+	for _, cap_ep := range capa.Endpoints {
+		pathItem := swagger.Paths.Find(cap_ep.Path)
+		if pathItem == nil {
+			mismatches = append(mismatches, "Path "+cap_ep.Path+" is not defined in the openEO API")
+			continue
 		}
-		err2 = json.Unmarshal(data, &config)
-		if err2 != nil {
-			log.Println("Error reading Config file as TOML: ", err)
-			log.Fatal("Error reading Config file as JSON:", err2)
+		for _, met := range cap_ep.Methods {
+			if pathItem.GetOperation(strings.ToUpper(met)) == nil {
+				mismatches = append(mismatches, "Method "+met+" of path "+cap_ep.Path+" is not defined in the openEO API")
+			}
 		}
 	}
+	if len(mismatches) != 0 {
+		states["capabilities_endpoints"]["state"] = "Invalid"
+		states["capabilities_endpoints"]["message"] = "Endpoints listed in the capabilities do not match the openEO API: " + strings.Join(mismatches, "; ")
+	}
 
-	return config
+	// Cross-check the version of the back end with the version of the openEO API
+	spec_version := specApiVersion(swagger)
+	if capa.Api_version == "" {
+		states["capabilities_api_version"]["state"] = "Invalid"
+		states["capabilities_api_version"]["message"] = "The capabilities report no api_version, the openEO API has version " + spec_version
+	} else if version.Normalize(capa.Api_version) != version.Normalize(spec_version) {
+		states["capabilities_api_version"]["state"] = "Invalid"
+		states["capabilities_api_version"]["message"] = "The capabilities report api_version " + capa.Api_version + ", but the openEO API has version " + spec_version
+	}
+
+	return states
 }
 
-// Reads info from config file
-func ReturnConfigValue(config_item string) string {
-	var config_value = config_item
-	if strings.HasPrefix(config_value, "$") {
-		env_var := strings.Replace(config_value, "$", "", 1)
-		config_value = os.Getenv(env_var)
-		if len(config_value) == 0 {
-			log.Println("Warning: Environment variable does not exist or is empty:", string(env_var))
-			log.Println("Warning: Used raw input instead:", string(config_item))
-			return config_item
+// specApiVersion returns the version of the openEO API, its x-openeo-api-version extension of the info or else the info version
+func specApiVersion(swagger *openapi3.Swagger) string {
+	if swagger.Info == nil {
+		return ""
+	}
+	if raw, ok := swagger.Info.Extensions["x-openeo-api-version"].(json.RawMessage); ok {
+		var api_version string
+		if json.Unmarshal(raw, &api_version) == nil && api_version != "" {
+			return api_version
 		}
 	}
-	return config_value
+	return swagger.Info.Version
 }
 
-// GetStringInBetween Returns empty string if no start string found
-func GetStringInBetween(str string, start string, end string) (result string) {
-	s := strings.Index(str, start)
-	if s == -1 {
-		return
+// Checks that the openEO API defines all endpoints of the conformance classes of the config, e.g. "GET /jobs"
+// for the class "batch-jobs". Classes of the config take precedence over the default ones.
+func (ct *ComplianceTest) checkConformance() map[string](map[string]string) {
+	states := make(map[string](map[string]string))
+	for _, class := range ct.conformance {
+		states["conformance_"+class] = map[string]string{"state": "Valid", "message": ""}
 	}
-	s += len(start)
-	e := strings.Index(str, end)
-	if e == -1 {
-		return
+
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		for _, state := range states {
+			state["state"] = "Error"
+			state["message"] = errormsg.toString()
+		}
+		return states
 	}
-	return str[s:e]
+
+	for _, class := range ct.conformance {
+		state := states["conformance_"+class]
+		endpoints, ok := ct.conformanceclasses[class]
+		if !ok {
+			endpoints, ok = DEFAULT_CONFORMANCE_CLASSES[class]
+		}
+		if !ok {
+			state["state"] = "Error"
+			state["message"] = "Unknown conformance class " + class + ", it is neither a default class nor defined in the config"
+			continue
+		}
+		var missing []string
+		for _, endpoint := range endpoints {
+			method, path := "GET", endpoint
+			if fields := strings.Fields(endpoint); len(fields) == 2 {
+				method, path = strings.ToUpper(fields[0]), fields[1]
+			}
+			if pathItem := swagger.Paths.Find(path); pathItem == nil || pathItem.GetOperation(method) == nil {
+				missing = append(missing, method+" "+path)
+			}
+		}
+		if len(missing) != 0 {
+			state["state"] = "Invalid"
+			state["message"] = "Endpoints required by the conformance class " + class + " are not defined in the openEO API: " + strings.Join(missing, "; ")
+		}
+	}
+	return states
 }
 
-func (be *BackEnd) loadUrl() {
-	if be.version != "" {
+// conformanceGroup returns the result group of the conformance check
+func (ct *ComplianceTest) conformanceGroup() map[string]interface{} {
+	conformance_states := ct.checkConformance()
+	group := make(map[string]interface{})
+	group["group_summary"] = "Valid"
+	group["endpoints"] = conformance_states
+	for _, state := range conformance_states {
+		state["url"] = ct.apifile
+		state["type"] = ""
+		if state["state"] != "Valid" {
+			group["group_summary"] = "Invalid"
+		}
+	}
+	return group
+}
+
+// schemaProperty returns the schema of a property of the schema, also of the schemas it is composed of with allOf,
+// nil if there is none
+func schemaProperty(schema *openapi3.Schema, name string) *openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	if property := schema.Properties[name]; property != nil && property.Value != nil {
+		return property.Value
+	}
+	for _, ref := range schema.AllOf {
+		if property := schemaProperty(ref.Value, name); property != nil {
+			return property
+		}
+	}
+	return nil
+}
+
+// Checks that the 200 responses of the paginated list endpoints of the openEO API, e.g. GET /jobs, include the array of
+// the items and the "links" array for paging. Endpoints which the openEO API doesn't define are skipped.
+func (ct *ComplianceTest) checkPaging() map[string](map[string]string) {
+	states := make(map[string](map[string]string))
+	for path := range PAGED_ENDPOINTS {
+		states["paging"+strings.Replace(path, "/", "_", -1)] = map[string]string{"state": "Valid", "message": "", "url": path, "type": "GET"}
+	}
+
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		for _, state := range states {
+			state["state"] = "Error"
+			state["message"] = errormsg.toString()
+		}
+		return states
+	}
+
+	for path, items := range PAGED_ENDPOINTS {
+		state := states["paging"+strings.Replace(path, "/", "_", -1)]
+		if pathItem := swagger.Paths.Find(path); pathItem == nil || pathItem.Get == nil {
+			state["state"] = "Skipped"
+			state["message"] = "GET " + path + " is not defined in the openEO API"
+			continue
+		}
+		schema := getResponseSchema(swagger, path)
+		if schema == nil {
+			state["state"] = "Invalid"
+			state["message"] = "The openEO API defines no JSON schema for the GET " + path + " response"
+			continue
+		}
+		var missing []string
+		for _, name := range []string{items, "links"} {
+			if property := schemaProperty(schema, name); property == nil || property.Type != "array" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) != 0 {
+			state["state"] = "Invalid"
+			state["message"] = "The schema of the GET " + path + " response has no paging array properties " + strings.Join(missing, ", ")
+		}
+	}
+	return states
+}
+
+// pagingGroup returns the result group of the paging check
+func (ct *ComplianceTest) pagingGroup() map[string]interface{} {
+	paging_states := ct.checkPaging()
+	group := make(map[string]interface{})
+	group["group_summary"] = "Valid"
+	group["endpoints"] = paging_states
+	for _, state := range paging_states {
+		if state["state"] != "Valid" && state["state"] != "Skipped" {
+			group["group_summary"] = "Invalid"
+		}
+	}
+	return group
+}
+
+// Validates the process listing of the back end (GET /processes) against the schema of the openEO API,
+// checks that the schemas of the process parameters and return values are valid and that the process ids are unique.
+func (ct *ComplianceTest) checkProcessesDocument() map[string](map[string]string) {
+	states := map[string](map[string]string){
+		"processes_schema":     {"state": "Valid", "message": ""},
+		"processes_parameters": {"state": "Valid", "message": ""},
+		"processes_ids":        {"state": "Valid", "message": ""},
+	}
+	setError := func(state string, errormsg *ErrorMessage) {
+		for id := range states {
+			states[id]["state"] = state
+			states[id]["message"] = errormsg.toString()
+		}
+	}
+
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		setError("Error", errormsg)
+		return states
+	}
+
+	body, errormsg := ct.fetchBackendJSON("/processes")
+	if errormsg != nil {
+		setError("Invalid", errormsg)
+		return states
+	}
+
+	var document interface{}
+	var listing struct {
+		Processes []map[string]interface{} `json:"processes"`
+	}
+	err := json.Unmarshal(body, &document)
+	if err == nil {
+		err = json.Unmarshal(body, &listing)
+	}
+	if err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = "GET  /processes"
+		errormsg.msg = "Processes of the back end are not valid JSON"
+		errormsg.output = err.Error()
+		setError("Invalid", errormsg)
+		return states
+	}
+
+	// Validate against the schema of the GET /processes response of the openEO API
+	schema := getResponseSchema(swagger, "/processes")
+	opts := ct.schemaValidationOptions()
+	if schema == nil {
+		states["processes_schema"]["state"] = "Error"
+		states["processes_schema"]["message"] = "The openEO API defines no JSON schema for the GET /processes response"
+	} else if err := schema.VisitJSON(document, opts...); err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = "GET  /processes"
+		errormsg.msg = "Processes of the back end not valid"
+		errormsg.output = err.Error()
+		states["processes_schema"]["state"] = "Invalid"
+		states["processes_schema"]["message"] = errormsg.toString()
+	}
+
+	// Check the schemas of the parameters and return values, and the ids
+	var invalid, duplicates []string
+	ids := make(map[string]bool)
+	for i, process := range listing.Processes {
+		id, _ := process["id"].(string)
+		if id == "" {
+			id = "#" + strconv.Itoa(i)
+		} else if ids[id] {
+			duplicates = append(duplicates, id)
+		}
+		ids[id] = true
+
+		schemas := make(map[string]interface{})
+		switch parameters := process["parameters"].(type) {
+		case []interface{}:
+			// openEO API 1.0: list of parameters
+			for j, parameter := range parameters {
+				parameter, _ := parameter.(map[string]interface{})
+				name, _ := parameter["name"].(string)
+				if name == "" {
+					name = "#" + strconv.Itoa(j)
+				}
+				schemas["parameter "+name] = parameter["schema"]
+			}
+		case map[string]interface{}:
+			// openEO API 0.4: parameters by name
+			for name, parameter := range parameters {
+				parameter, _ := parameter.(map[string]interface{})
+				schemas["parameter "+name] = parameter["schema"]
+			}
+		}
+		if returns, ok := process["returns"].(map[string]interface{}); ok {
+			schemas["return value"] = returns["schema"]
+		}
+		names := make([]string, 0, len(schemas))
+		for name := range schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := validateJSONSchema(schemas[name]); err != nil {
+				invalid = append(invalid, "Schema of the "+name+" of process "+id+" is not valid: "+err.Error())
+			}
+		}
+	}
+	if len(invalid) != 0 {
+		states["processes_parameters"]["state"] = "Invalid"
+		states["processes_parameters"]["message"] = strings.Join(invalid, "; ")
+	}
+	if len(duplicates) != 0 {
+		states["processes_ids"]["state"] = "Invalid"
+		states["processes_ids"]["message"] = "Process ids are listed more than once: " + strings.Join(duplicates, ", ")
+	}
+
+	return states
+}
+
+// Checks that an openEO JSON schema of a process parameter or return value is a valid schema
+func validateJSONSchema(value interface{}) error {
+	if value == nil {
+		return errors.New("schema is missing")
+	}
+	data, err := json.Marshal(normalizeJSONSchema(value))
+	if err != nil {
+		return err
+	}
+	var schema openapi3.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return err
+	}
+	return schema.Validate(context.Background())
+}
+
+// Converts an openEO JSON schema to an OpenAPI schema: lists of schemas and types are
+// converted to anyOf, the type null to nullable and arrays without items get items
+// which allow any value, other keywords are kept.
+func normalizeJSONSchema(value interface{}) interface{} {
+	normalizeList := func(value interface{}) interface{} {
+		list, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		schemas := make([]interface{}, len(list))
+		for i, item := range list {
+			schemas[i] = normalizeJSONSchema(item)
+		}
+		return schemas
+	}
+
+	switch value := value.(type) {
+	case []interface{}:
+		return map[string]interface{}{"anyOf": normalizeList(value)}
+	case map[string]interface{}:
+		schema := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			switch key {
+			case "items":
+				if _, ok := item.([]interface{}); ok {
+					schema["prefixItems"] = normalizeList(item)
+				} else {
+					schema[key] = normalizeJSONSchema(item)
+				}
+			case "additionalProperties", "not", "if", "then", "else":
+				schema[key] = normalizeJSONSchema(item)
+			case "allOf", "anyOf", "oneOf":
+				schema[key] = normalizeList(item)
+			case "properties":
+				properties, _ := item.(map[string]interface{})
+				normalized := make(map[string]interface{}, len(properties))
+				for name, property := range properties {
+					normalized[name] = normalizeJSONSchema(property)
+				}
+				schema[key] = normalized
+			default:
+				schema[key] = item
+			}
+		}
+		switch types := value["type"].(type) {
+		case string:
+			if types == "null" {
+				delete(schema, "type")
+				schema["nullable"] = true
+			}
+		case []interface{}:
+			delete(schema, "type")
+			var anyOf []interface{}
+			for _, typ := range types {
+				if typ == "null" {
+					schema["nullable"] = true
+				} else if typ == "array" {
+					anyOf = append(anyOf, map[string]interface{}{"type": typ, "items": map[string]interface{}{}})
+				} else {
+					anyOf = append(anyOf, map[string]interface{}{"type": typ})
+				}
+			}
+			if len(anyOf) == 1 {
+				schema["type"] = anyOf[0].(map[string]interface{})["type"]
+			} else if len(anyOf) > 1 {
+				allOf, _ := schema["allOf"].([]interface{})
+				schema["allOf"] = append(allOf, map[string]interface{}{"anyOf": anyOf})
+			}
+		}
+		_, hasItems := schema["items"]
+		_, hasPrefixItems := schema["prefixItems"]
+		if schema["type"] == "array" && !hasItems && !hasPrefixItems {
+			schema["items"] = map[string]interface{}{}
+		}
+		return schema
+	}
+	return value
+}
+
+// Reads info from config file
+func ReadConfig(config_file string) Config {
+	var configfile = config_file
+	var config Config
+
+	// Check if file exists
+	_, err := os.Stat(configfile)
+	if err != nil {
+		log.Fatal("Config file is missing: ", configfile)
+	}
+
+	// Read file if TOML File
+
+	if _, err := toml.DecodeFile(configfile, &config); err != nil {
+
+		//Read file as JSON File
+		data, err2 := ioutil.ReadFile(config_file)
+
+		if err2 != nil {
+			log.Println("Error reading Config file as TOML: ", err)
+			log.Fatal("Error reading Config file as JSON: ", err2)
+		}
+		err2 = json.Unmarshal(data, &config)
+		if err2 != nil {
+			log.Println("Error reading Config file as TOML: ", err)
+			log.Fatal("Error reading Config file as JSON:", err2)
+		}
+	}
+
+	return config
+}
+
+// Reads info from config file
+func ReturnConfigValue(config_item string) string {
+	var config_value = config_item
+	if strings.HasPrefix(config_value, "$") {
+		env_var := strings.Replace(config_value, "$", "", 1)
+		config_value = os.Getenv(env_var)
+		if len(config_value) == 0 {
+			log.Println("Warning: Environment variable does not exist or is empty:", string(env_var))
+			log.Println("Warning: Used raw input instead:", string(config_item))
+			return config_item
+		}
+	}
+	return config_value
+}
+
+// GetStringInBetween Returns empty string if no start string found
+func GetStringInBetween(str string, start string, end string) (result string) {
+	s := strings.Index(str, start)
+	if s == -1 {
+		return
+	}
+	s += len(start)
+	e := strings.Index(str, end)
+	if e == -1 {
+		return
+	}
+	return str[s:e]
+}
+
+func (be *BackEnd) loadUrl() {
+	if be.version != "" {
 
 		// Get backend version
 		well_known := be.baseurl + "/.well-known/openeo"
@@ -697,6 +1460,34 @@ func (be *BackEnd) loadUrl() {
 
 }
 
+// Checks whether the url matches one of the path filter patterns, all urls match without a path filter
+func (ct *ComplianceTest) matchesPathFilter(url string) bool {
+	if len(ct.pathfilter) == 0 {
+		return true
+	}
+	for _, pattern := range ct.pathfilter {
+		if openapi3.MatchPathPattern(pattern, url) {
+			return true
+		}
+	}
+	return false
+}
+
+// Counts the paths of the openEO API which aren't validated because they don't match the path filter
+func (ct *ComplianceTest) countSkippedPaths() (int, *ErrorMessage) {
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		return 0, errormsg
+	}
+	skipped := 0
+	for path := range swagger.Paths {
+		if !ct.matchesPathFilter(path) {
+			skipped++
+		}
+	}
+	return skipped, nil
+}
+
 func (ct *ComplianceTest) checkCapability(ep Endpoint) bool {
 
 	if len(ct.capabilities.Endpoints) == 0 {
@@ -829,6 +1620,102 @@ func (ct *ComplianceTest) appendConfig(config Config) {
 		ct.password = ReturnConfigValue(config.Password)
 	}
 
+	if config.Disableformatvalidation {
+		ct.disableformatvalidation = true
+	}
+
+	// Format severities apply to all schemas, they're defined in the registry of formats by applyPreset
+	for format, value := range config.Formatseverities {
+		severity, err := openapi3.ParseFormatSeverity(strings.ToLower(ReturnConfigValue(value)))
+		if err != nil {
+			log.Fatal("Error in formatseverities: ", err)
+		}
+		if ct.formatseverities == nil {
+			ct.formatseverities = make(map[string]openapi3.FormatSeverity)
+		}
+		ct.formatseverities[format] = severity
+	}
+
+	if config.Checkcapabilities {
+		ct.checkcapabilities = true
+	}
+
+	if config.Checkresponsestatus {
+		ct.checkresponsestatus = true
+	}
+
+	if config.Matchprofiles {
+		ct.matchprofiles = true
+	}
+
+	if config.Checkprocesses {
+		ct.checkprocesses = true
+	}
+
+	if config.Checkpaging {
+		ct.checkpaging = true
+	}
+
+	if config.Externalrefs {
+		ct.externalrefs = true
+	}
+
+	if config.Preset != "" {
+		ct.preset = strings.ToLower(ReturnConfigValue(config.Preset))
+	}
+
+	for _, pointer := range config.Ignorepointers {
+		ct.ignorepointers = append(ct.ignorepointers, ReturnConfigValue(pointer))
+	}
+
+	for _, pattern := range config.Pathfilter {
+		ct.pathfilter = append(ct.pathfilter, ReturnConfigValue(pattern))
+	}
+
+	if ct.severities == nil {
+		ct.severities = make(map[string]string)
+	}
+	for state, severity := range config.Severities {
+		ct.severities[state] = strings.ToLower(ReturnConfigValue(severity))
+	}
+
+	for _, class := range config.Conformance {
+		ct.conformance = append(ct.conformance, ReturnConfigValue(class))
+	}
+
+	if ct.conformanceclasses == nil {
+		ct.conformanceclasses = make(map[string][]string)
+	}
+	for class, endpoints := range config.Conformanceclasses {
+		ct.conformanceclasses[class] = endpoints
+	}
+
+	for _, operation := range config.Batchoperations {
+		ct.batchoperations = append(ct.batchoperations, ReturnConfigValue(operation))
+	}
+
+	if config.Batchconcurrency != 0 {
+		ct.batchconcurrency = config.Batchconcurrency
+	}
+
+	if config.Batchrate != 0 {
+		ct.batchrate = config.Batchrate
+	}
+
+	if ct.batchhostrates == nil {
+		ct.batchhostrates = make(map[string]float64)
+	}
+	for host, rate := range config.Batchhostrates {
+		ct.batchhostrates[host] = rate
+	}
+
+	if ct.batchheaders == nil {
+		ct.batchheaders = make(map[string]string)
+	}
+	for name, value := range config.Batchheaders {
+		ct.batchheaders[name] = ReturnConfigValue(value)
+	}
+
 	if config.Endpoints != nil {
 		var ep_groups map[string][]Endpoint
 		ep_groups = make(map[string][]Endpoint)
@@ -868,54 +1755,950 @@ func (ct *ComplianceTest) appendConfig(config Config) {
 	ct.loadCapabilities()
 }
 
-// Main function
-func main() {
-	start_time := time.Now()
-	// Config file path
-	//var config Config
-	//var config_ep Config
-
-	ct := new(ComplianceTest)
-
-	// CLI handling
-	app := cli.NewApp()
-	app.Name = "openeoct"
-	app.Name = "openeoct"
-	app.Version = "1.0.0"
-	app.Usage = "validating a back end against an openapi description file!"
+// run validates all endpoints and runs the additional checks
+func (ct *ComplianceTest) run(start_time time.Time) *Result {
+	result, err := ct.validateAll()
 
-	app.Flags = []cli.Flag{
-		&cli.BoolFlag{
-			Name:  "debug",
-			Usage: "activate debug info",
-		},
+	if err != nil {
+		log.Println(err.toString())
 	}
-	// add config command
-	app.Commands = []*cli.Command{
-		{
-			Name:    "config",
-			Aliases: []string{"c"},
-			Usage:   "load from config file",
-			Action: func(c *cli.Context) error {
-				//configfile = c.Args().First()
-				for i := 0; i < c.Args().Len(); i++ {
-					ct.appendConfig(ReadConfig(c.Args().Get(i)))
 
-				}
-				if c.Bool("debug") {
-					ct.debug = true
-				}
-				//log.Println("Configfile1: ", config.Url)
-				return nil
-			},
-		},
+	end_time := time.Now()
+
+	result_json := make(map[string](map[string](map[string]interface{})))
+	result_json["result"] = make(map[string](map[string]interface{}))
+	result_json["stats"] = ct.stats(start_time, end_time)
+
+	// Report what the path filter excluded from the validation
+	if len(ct.pathfilter) != 0 {
+		skipped_endpoints := 0
+		for _, state := range result {
+			if state["state"] == "Skipped" {
+				skipped_endpoints++
+			}
+		}
+		result_json["stats"]["execution"]["pathfilter"] = ct.pathfilter
+		result_json["stats"]["execution"]["skipped_endpoints"] = skipped_endpoints
+		skipped_paths, errormsg := ct.countSkippedPaths()
+		if errormsg != nil {
+			log.Println(errormsg.toString())
+		} else {
+			result_json["stats"]["execution"]["skipped_paths"] = skipped_paths
+			log.Printf("Path filter skipped %d endpoints and %d paths of the openEO API\n", skipped_endpoints, skipped_paths)
+		}
 	}
 
-	// run CLI
-	apperr := app.Run(os.Args)
+	for group, endpoints := range ct.endpoints {
+		for _, ep := range endpoints {
+			ep.loadVariablesToEndpoint(*ct)
+			if result_json["result"][group] == nil {
+				result_json["result"][group] = make(map[string]interface{})
+				result_json["result"][group]["group_summary"] = ""
+				result_json["result"][group]["endpoints"] = make(map[string](map[string]string))
+			}
+
+			result_json["result"][group]["endpoints"].(map[string](map[string]string))[ep.Id] = result[ep.Id]
+			result_json["result"][group]["endpoints"].(map[string](map[string]string))[ep.Id]["url"] = ep.Url
+			result_json["result"][group]["endpoints"].(map[string](map[string]string))[ep.Id]["type"] = ep.Request_type
+			updateGroupSummary(result_json["result"][group], result[ep.Id]["state"])
+		}
+	}
+
+	// Add the capabilities document checks as a separate group
+	if ct.checkcapabilities {
+		capa_states := ct.checkCapabilitiesDocument()
+		group := make(map[string]interface{})
+		group["group_summary"] = "Valid"
+		group["endpoints"] = capa_states
+		for _, state := range capa_states {
+			state["url"] = "/"
+			state["type"] = "GET"
+			if state["state"] != "Valid" {
+				group["group_summary"] = "Invalid"
+			}
+		}
+		result_json["result"]["Capabilities Check"] = group
+	}
+
+	// Add the process listing checks as a separate group
+	if ct.checkprocesses {
+		process_states := ct.checkProcessesDocument()
+		group := make(map[string]interface{})
+		group["group_summary"] = "Valid"
+		group["endpoints"] = process_states
+		for _, state := range process_states {
+			state["url"] = "/processes"
+			state["type"] = "GET"
+			if state["state"] != "Valid" {
+				group["group_summary"] = "Invalid"
+			}
+		}
+		result_json["result"]["Processes Check"] = group
+	}
+
+	// Add the conformance class checks of the openEO API as a separate group
+	if len(ct.conformance) != 0 {
+		result_json["result"]["Conformance Check"] = ct.conformanceGroup()
+	}
+
+	// Add the paging checks of the list endpoints of the openEO API as a separate group
+	if ct.checkpaging {
+		result_json["result"]["Paging Check"] = ct.pagingGroup()
+	}
+
+	// Add the lint issues of the openEO API as a separate group
+	if swagger, errormsg := ct.loadAPI(); errormsg != nil {
+		log.Println(errormsg.toString())
+	} else {
+		result_json["result"]["Lint"] = ct.lintGroup(swagger)
+	}
+
+	// List the external documents of the openEO API for auditing
+	if len(ct.loadedrefs) != 0 {
+		result_json["result"]["External References"] = ct.externalRefsGroup()
+	}
+
+	return &Result{
+		Result:  result_json["result"],
+		Stats:   result_json["stats"],
+		Summary: ct.summarize(result_json["result"]),
+	}
+}
+
+// stats returns the stats of a run about the back end, the execution and the openEO API
+func (ct *ComplianceTest) stats(start_time time.Time, end_time time.Time) map[string](map[string]interface{}) {
+	stats := make(map[string](map[string]interface{}))
+	stats["backend"] = make(map[string]interface{})
+	stats["execution"] = make(map[string]interface{})
+	stats["spec"] = make(map[string]interface{})
+	stats["backend"]["url"] = ct.backend.url
+	stats["backend"]["baseurl"] = ct.backend.baseurl
+	stats["backend"]["version"] = ct.backend.version
+	stats["execution"]["start"] = start_time.Format("2006-01-02 15:04:05")
+	stats["execution"]["end"] = end_time.Format("2006-01-02 15:04:05")
+	stats["spec"]["apifile"] = ct.apifile
+	return stats
+}
+
+// updateGroupSummary updates the group summary with the state of an endpoint of the group
+func updateGroupSummary(group map[string]interface{}, state string) {
+	if state != "Valid" && state != "NotSupported" && state != "Skipped" {
+		group["group_summary"] = "Invalid"
+	} else if state == "Valid" {
+		if group["group_summary"] != "Invalid" {
+			group["group_summary"] = "Valid"
+		}
+	} else if state == "NotSupported" && group["group_summary"] == "" {
+		group["group_summary"] = "NotSupported"
+	}
+}
+
+// summarize counts the endpoint states of the result groups, and the check codes of the lint issues
+func (ct *ComplianceTest) summarize(groups map[string](map[string]interface{})) Summary {
+	summary := Summary{States: make(map[string]int), Codes: make(map[string]int), Verdict: "Passed"}
+	for _, group := range groups {
+		endpoints, _ := group["endpoints"].(map[string](map[string]string))
+		for _, state := range endpoints {
+			summary.States[state["state"]]++
+			if code := state["code"]; code != "" {
+				summary.Codes[code]++
+			}
+			severity := ct.severity(state["state"])
+			if state["state"] == "Skipped" || severity == "notice" {
+				continue
+			}
+			summary.Checks++
+			switch severity {
+			case "error":
+				summary.Errors++
+			case "warning":
+				summary.Warnings++
+			}
+		}
+	}
+	if summary.Errors != 0 {
+		summary.Verdict = "Failed"
+	}
+	return summary
+}
+
+// severity returns the configured severity of an endpoint state
+func (ct *ComplianceTest) severity(state string) string {
+	if severity, ok := ct.severities[state]; ok {
+		return severity
+	}
+	if severity, ok := PRESET_SEVERITIES[ct.preset][state]; ok {
+		return severity
+	}
+	if severity, ok := DEFAULT_SEVERITIES[state]; ok {
+		return severity
+	}
+	return "error"
+}
+
+// applyPreset checks the configured preset and enables the additional checks of the strictest preset, which also
+// validates all formats strictly, and lints the openEO API with all rules at error severity, see lintGroup.
+// The configured format severities override those of the preset, like the severities of the endpoint states.
+func (ct *ComplianceTest) applyPreset() {
+	if ct.preset != "" {
+		if _, ok := PRESET_SEVERITIES[ct.preset]; !ok {
+			log.Fatalf("Error: unknown preset %q, expected one of %s", ct.preset, strings.Join(openapi3lint.Presets(), ", "))
+		}
+	}
+	if ct.preset == openapi3lint.PresetStrictest {
+		ct.checkcapabilities = true
+		ct.checkresponsestatus = true
+		ct.checkprocesses = true
+		ct.checkpaging = true
+		for format := range openapi3.SchemaFormatSeverities {
+			openapi3.DefineFormatSeverity(format, openapi3.FormatSeverityError)
+		}
+	}
+	for format, severity := range ct.formatseverities {
+		openapi3.DefineFormatSeverity(format, severity)
+	}
+}
+
+// BatchOperation "class", an operation of the openEO API called with an example request by a batch run
+type BatchOperation struct {
+	Id     string
+	Group  string
+	Inputs *openapi3.RequiredInputs
+}
+
+// batchRun "class", the state shared by the concurrent requests of a batch run
+type batchRun struct {
+	ct      *ComplianceTest
+	router  *openapi3filter.Router
+	token   string
+	limiter *rateLimiter
+	// Routes are looked up and requests are validated one at a time
+	mutex sync.Mutex
+}
+
+// rateLimiter "class", spaces the requests to each host according to the requests per second
+type rateLimiter struct {
+	rate      float64
+	hostrates map[string]float64
+	mutex     sync.Mutex
+	next      map[string]time.Time
+}
+
+// wait blocks until the next request to the host is allowed
+func (rl *rateLimiter) wait(host string) {
+	rate, ok := rl.hostrates[host]
+	if !ok {
+		rate = rl.rate
+	}
+	if rate <= 0 {
+		return
+	}
+	rl.mutex.Lock()
+	now := time.Now()
+	slot := rl.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	rl.next[host] = slot.Add(time.Duration(float64(time.Second) / rate))
+	rl.mutex.Unlock()
+	time.Sleep(time.Until(slot))
+}
+
+// runBatch sends example requests of the selected operations of the openEO API concurrently and validates the responses
+func (ct *ComplianceTest) runBatch(start_time time.Time) *Result {
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		log.Fatal(errormsg.toString())
+	}
+
+	token, err := ct.authenticate()
+	if err != nil {
+		log.Println(err.toString())
+	}
+
+	run := &batchRun{
+		ct:      ct,
+		router:  ct.newRouter(swagger),
+		token:   token,
+		limiter: &rateLimiter{rate: ct.batchrate, hostrates: ct.batchhostrates, next: make(map[string]time.Time)},
+	}
+	concurrency := ct.batchconcurrency
+	if concurrency <= 0 {
+		concurrency = DEFAULT_BATCH_CONCURRENCY
+	}
+
+	operations := ct.batchOperations(swagger)
+	states := make([]map[string]string, len(operations))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				states[index] = run.validate(operations[index])
+			}
+		}()
+	}
+	for index := range operations {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	groups := make(map[string](map[string]interface{}))
+	for i, operation := range operations {
+		if groups[operation.Group] == nil {
+			groups[operation.Group] = make(map[string]interface{})
+			groups[operation.Group]["group_summary"] = ""
+			groups[operation.Group]["endpoints"] = make(map[string](map[string]string))
+		}
+		groups[operation.Group]["endpoints"].(map[string](map[string]string))[operation.Id] = states[i]
+		updateGroupSummary(groups[operation.Group], states[i]["state"])
+	}
+	if len(ct.conformance) != 0 {
+		groups["Conformance Check"] = ct.conformanceGroup()
+	}
+	if ct.checkpaging {
+		groups["Paging Check"] = ct.pagingGroup()
+	}
+	groups["Lint"] = ct.lintGroup(swagger)
+	if len(ct.loadedrefs) != 0 {
+		groups["External References"] = ct.externalRefsGroup()
+	}
+
+	stats := ct.stats(start_time, time.Now())
+	stats["execution"]["batch"] = map[string]interface{}{
+		"operations":  len(operations),
+		"concurrency": concurrency,
+		"rate":        ct.batchrate,
+	}
+	return &Result{
+		Result:  groups,
+		Stats:   stats,
+		Summary: ct.summarize(groups),
+	}
+}
+
+// batchOperations returns the operations of the openEO API selected by the config, by operationId or
+// "METHOD /path", all GET operations if none are selected
+func (ct *ComplianceTest) batchOperations(swagger *openapi3.Swagger) []*BatchOperation {
+	selected := make(map[string]bool, len(ct.batchoperations))
+	for _, operation := range ct.batchoperations {
+		selected[operation] = true
+	}
+	var operations []*BatchOperation
+	for _, inputs := range swagger.RequiredInputs() {
+		id := inputs.Method + " " + inputs.Path
+		if len(selected) == 0 && inputs.Method != http.MethodGet {
+			continue
+		}
+		if len(selected) != 0 && !selected[id] && !(inputs.OperationID != "" && selected[inputs.OperationID]) {
+			continue
+		}
+		delete(selected, id)
+		delete(selected, inputs.OperationID)
+		operations = append(operations, newBatchOperation(swagger, inputs))
+	}
+	for _, operation := range ct.batchoperations {
+		if selected[operation] {
+			log.Println("Warning: Batch operation not found in the openEO API: " + operation)
+		}
+	}
+	return operations
+}
+
+// newBatchOperation returns the operation with the required inputs, identified by its operationId or "METHOD /path"
+// and grouped by its first tag
+func newBatchOperation(swagger *openapi3.Swagger, inputs *openapi3.RequiredInputs) *BatchOperation {
+	operation := &BatchOperation{Id: inputs.Method + " " + inputs.Path, Group: "nogroup", Inputs: inputs}
+	if inputs.OperationID != "" {
+		operation.Id = inputs.OperationID
+	}
+	if tags := swagger.Paths[inputs.Path].GetOperation(inputs.Method).Tags; len(tags) != 0 {
+		operation.Group = tags[0]
+	}
+	return operation
+}
+
+// validate sends the example request of the operation to the back end and validates the request and the response.
+// Returns the state, the message and the url and method of the request.
+func (run *batchRun) validate(operation *BatchOperation) map[string]string {
+	state := map[string]string{"url": operation.Inputs.Path, "type": operation.Inputs.Method, "message": ""}
+	ct := run.ct
+	if !ct.matchesPathFilter(operation.Inputs.Path) {
+		state["message"] = "Endpoint skipped, not matching the path filter"
+		state["state"] = "Skipped"
+		return state
+	}
+	request, errormsg := ct.buildExampleRequest(operation, run.token)
+	if errormsg != nil {
+		state["message"] = errormsg.toString()
+		state["state"] = "NoExample"
+		return state
+	}
+	state["url"] = request.url
+	if !ct.checkCapability(Endpoint{Url: request.url, Request_type: operation.Inputs.Method}) && !CAP_EXCEPTIONS[request.url] {
+		state["message"] = "Endpoint skipped, not listed in backend capabilities"
+		state["state"] = "NotSupported"
+		return state
+	}
+	state["state"], errormsg = run.send(operation, request)
+	if errormsg != nil {
+		state["message"] = errormsg.toString()
+	}
+	return state
+}
+
+// send validates the example request, sends it to the back end and validates the response
+func (run *batchRun) send(operation *BatchOperation, request *exampleRequest) (string, *ErrorMessage) {
+	ct := run.ct
+	ctx := context.TODO()
+	httpReq := request.build("")
+	options := ct.filterOptions(httpReq)
+
+	run.mutex.Lock()
+	route, pathParams, err := run.router.FindRoute(httpReq.Method, httpReq.URL)
+	var requestValidationInput *openapi3filter.RequestValidationInput
+	if err == nil {
+		requestValidationInput = &openapi3filter.RequestValidationInput{
+			Request:    httpReq,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options}
+		err = openapi3filter.ValidateRequest(ctx, requestValidationInput)
+	}
+	run.mutex.Unlock()
+	if err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = httpReq.Method + "  " + request.url
+		errormsg.msg = "Error validating the example request"
+		errormsg.output = err.Error()
+		return "Invalid", errormsg
+	}
+
+	base := ct.backend.url
+	if strings.Contains(request.url, ".well-known") {
+		base = ct.backend.baseurl
+	}
+	execReq := request.build(base)
+	run.limiter.wait(execReq.URL.Host)
+	if ct.debug {
+		log.Println("---Batch Request " + operation.Id + "---")
+		log.Println("URL: ", execReq.URL.String())
+	}
+	resp, err := http.DefaultClient.Do(execReq)
+	if err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = execReq.Method + "  " + request.url
+		errormsg.msg = "Error sending request to back end"
+		errormsg.output = err.Error()
+		return "Error", errormsg
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = execReq.Method + "  " + request.url
+		errormsg.msg = "Error reading response from the back end"
+		errormsg.output = err.Error()
+		return "Invalid", errormsg
+	}
+
+	if resp.StatusCode >= 400 {
+		errormsg := new(ErrorMessage)
+		errormsg.input = execReq.Method + "  " + request.url
+		errormsg.msg = "Response Code " + strconv.Itoa(resp.StatusCode)
+		errormsg.output = string(body)
+		if resp.StatusCode == 401 {
+			errormsg.msg = "Error: Authentication failed."
+			return "Invalid", errormsg
+		}
+		if resp.StatusCode == 404 {
+			return "Missing", errormsg
+		}
+		return "Error", errormsg
+	}
+
+	responseValidationInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestValidationInput,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Options:                options}
+	if len(body) != 0 {
+		responseValidationInput.SetBodyBytes(body)
+	}
+	if err := openapi3filter.ValidateResponse(ctx, responseValidationInput); err != nil {
+		errormsg := new(ErrorMessage)
+		errormsg.input = execReq.Method + "  " + request.url
+		errormsg.msg = "Response of the back end not valid"
+		errormsg.output = err.Error()
+		return "Invalid", errormsg
+	}
+	return "Valid", nil
+}
+
+// exampleRequest "class", the parts of an example request, which is built once for the validation and once to be sent
+type exampleRequest struct {
+	method string
+	url    string
+	query  url.Values
+	header http.Header
+	body   []byte
+}
+
+// build returns the request, relative to the base url if it's empty
+func (request *exampleRequest) build(base string) *http.Request {
+	target := request.url
+	if base != "" {
+		target = build_url(base, request.url)
+	}
+	var body io.Reader
+	if request.body != nil {
+		body = bytes.NewReader(request.body)
+	}
+	httpReq, _ := http.NewRequest(request.method, target, body)
+	httpReq.URL.RawQuery = request.query.Encode()
+	for name, values := range request.header {
+		httpReq.Header[name] = values
+	}
+	return httpReq
+}
+
+// buildExampleRequest builds the request of the operation from the required inputs. The values of parameters are
+// taken from the variable of the same name or from the examples in the openEO API, bodies from the examples.
+func (ct *ComplianceTest) buildExampleRequest(operation *BatchOperation, token string) (*exampleRequest, *ErrorMessage) {
+	inputs := operation.Inputs
+	request := &exampleRequest{
+		method: inputs.Method,
+		url:    inputs.Path,
+		query:  make(url.Values),
+		header: make(http.Header),
+	}
+	missing := func(what string) *ErrorMessage {
+		errormsg := new(ErrorMessage)
+		errormsg.input = inputs.Method + "  " + inputs.Path
+		errormsg.msg = "No example of the required " + what + " in the openEO API or the variables"
+		return errormsg
+	}
+
+	for _, parameter := range inputs.Parameters {
+		value, ok := ct.variables[parameter.Name]
+		if !ok {
+			example, found := exampleOf(parameter.Example, parameter.Examples, parameter.Schema)
+			if !found {
+				return nil, missing(parameter.In + " parameter " + parameter.Name)
+			}
+			// Exploded arrays are repeated query parameters
+			if items, isArray := example.([]interface{}); isArray && parameter.In == openapi3.ParameterInQuery && (parameter.Explode == nil || *parameter.Explode) {
+				for _, item := range items {
+					request.query.Add(parameter.Name, exampleString(item))
+				}
+				continue
+			}
+			value = exampleString(example)
+		}
+		switch parameter.In {
+		case openapi3.ParameterInPath:
+			request.url = strings.Replace(request.url, "{"+parameter.Name+"}", url.PathEscape(value), -1)
+		case openapi3.ParameterInQuery:
+			request.query.Add(parameter.Name, value)
+		case openapi3.ParameterInHeader:
+			request.header.Set(parameter.Name, value)
+		case openapi3.ParameterInCookie:
+			request.header.Add("Cookie", (&http.Cookie{Name: parameter.Name, Value: value}).String())
+		}
+	}
+
+	if inputs.RequestBody != nil {
+		content_type := exampleContentType(inputs.RequestBody.Content)
+		if content_type == "" {
+			return nil, missing("request body")
+		}
+		media_type := inputs.RequestBody.Content[content_type]
+		example, found := exampleOf(media_type.Example, media_type.Examples, media_type.Schema)
+		if text, isString := example.(string); found && isString && !strings.Contains(content_type, "json") {
+			request.body = []byte(text)
+		} else if found && strings.Contains(content_type, "json") {
+			request.body, _ = json.Marshal(example)
+		} else {
+			return nil, missing("request body")
+		}
+		request.header.Set("Content-Type", content_type)
+	}
+
+	if token != "" {
+		request.header.Set("Authorization", "Bearer basic//"+token)
+	}
+	// Configured headers, e.g. for other authentication methods, take precedence
+	for name, value := range ct.batchheaders {
+		request.header.Set(name, value)
+	}
+	return request, nil
+}
+
+// exampleContentType returns the content type of the example request body, preferably JSON, else the most specific
+func exampleContentType(content openapi3.Content) string {
+	content_types := content.MediaTypes()
+	for _, content_type := range content_types {
+		if content_type == "application/json" {
+			return content_type
+		}
+	}
+	if len(content_types) == 0 {
+		return ""
+	}
+	return content_types[0]
+}
+
+// exampleOf returns the example, the first of the examples by name, or the example, first example, default or first enum value of the schema
+func exampleOf(example interface{}, examples map[string]*openapi3.ExampleRef, schema *openapi3.SchemaRef) (interface{}, bool) {
+	if example != nil {
+		return example, true
+	}
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ref := examples[name]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return ref.Value.Value, true
+		}
+	}
+	if schema != nil && schema.Value != nil {
+		if schema.Value.Example != nil {
+			return schema.Value.Example, true
+		}
+		if len(schema.Value.Examples) != 0 {
+			return schema.Value.Examples[0], true
+		}
+		if schema.Value.Default != nil {
+			return schema.Value.Default, true
+		}
+		if len(schema.Value.Enum) != 0 {
+			return schema.Value.Enum[0], true
+		}
+	}
+	return nil, false
+}
+
+// exampleString returns the example as parameter value, arrays as comma separated values
+func exampleString(example interface{}) string {
+	switch value := example.(type) {
+	case string:
+		return value
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, exampleString(item))
+		}
+		return strings.Join(items, ",")
+	}
+	data, _ := json.Marshal(example)
+	return string(data)
+}
+
+// runContract validates the example requests of all operations of the openEO API and the examples of their responses
+// against the openEO API itself, as contract tests without a back end
+func (ct *ComplianceTest) runContract(start_time time.Time) *Result {
+	swagger, errormsg := ct.loadAPI()
+	if errormsg != nil {
+		log.Fatal(errormsg.toString())
+	}
+	router := ct.newRouter(swagger)
+
+	groups := make(map[string](map[string]interface{}))
+	all_inputs := swagger.RequiredInputs()
+	for _, inputs := range all_inputs {
+		operation := newBatchOperation(swagger, inputs)
+		state := ct.validateContract(router, operation)
+		if groups[operation.Group] == nil {
+			groups[operation.Group] = make(map[string]interface{})
+			groups[operation.Group]["group_summary"] = ""
+			groups[operation.Group]["endpoints"] = make(map[string](map[string]string))
+		}
+		groups[operation.Group]["endpoints"].(map[string](map[string]string))[operation.Id] = state
+		updateGroupSummary(groups[operation.Group], state["state"])
+	}
+	if len(ct.conformance) != 0 {
+		groups["Conformance Check"] = ct.conformanceGroup()
+	}
+	if ct.checkpaging {
+		groups["Paging Check"] = ct.pagingGroup()
+	}
+	groups["Lint"] = ct.lintGroup(swagger)
+	if len(ct.loadedrefs) != 0 {
+		groups["External References"] = ct.externalRefsGroup()
+	}
+
+	stats := ct.stats(start_time, time.Now())
+	stats["execution"]["contract"] = map[string]interface{}{
+		"operations": len(all_inputs),
+	}
+	return &Result{
+		Result:  groups,
+		Stats:   stats,
+		Summary: ct.summarize(groups),
+	}
+}
+
+// validateContract validates the example request of the operation, that it matches the operation, and the examples
+// of the responses of the operation for the status each of them implies.
+// Returns the state, the message and the url and method of the request.
+func (ct *ComplianceTest) validateContract(router *openapi3filter.Router, operation *BatchOperation) map[string]string {
+	inputs := operation.Inputs
+	state := map[string]string{"url": inputs.Path, "type": inputs.Method, "message": ""}
+	if !ct.matchesPathFilter(inputs.Path) {
+		state["message"] = "Endpoint skipped, not matching the path filter"
+		state["state"] = "Skipped"
+		return state
+	}
+	request, errormsg := ct.buildExampleRequest(operation, "")
+	if errormsg != nil {
+		state["message"] = errormsg.toString()
+		state["state"] = "NoExample"
+		return state
+	}
+	state["url"] = request.url
+	invalid := func(msg string, output string) map[string]string {
+		errormsg := new(ErrorMessage)
+		errormsg.input = inputs.Method + "  " + request.url
+		errormsg.msg = msg
+		errormsg.output = output
+		state["message"] = errormsg.toString()
+		state["state"] = "Invalid"
+		return state
+	}
+
+	ctx := context.TODO()
+	httpReq := request.build("")
+	options := ct.filterOptions(httpReq)
+	// The examples must be declared for the status they imply
+	options.IncludeResponseStatus = true
+	route, pathParams, err := router.FindRoute(httpReq.Method, httpReq.URL)
+	if err != nil {
+		return invalid("Example request matches no operation of the openEO API", err.Error())
+	}
+	if route.Path != inputs.Path || route.Method != inputs.Method {
+		return invalid("Example request matches another operation of the openEO API", route.Method+" "+route.Path)
+	}
+	requestValidationInput := &openapi3filter.RequestValidationInput{
+		Request:    httpReq,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options}
+	if err := openapi3filter.ValidateRequest(ctx, requestValidationInput); err != nil {
+		return invalid("Error validating the example request", err.Error())
+	}
+
+	var problems []string
+	examples := 0
+	responses := route.Operation.Responses
+	for _, key := range sortedResponseKeys(responses) {
+		ref := responses[key]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		for _, content_type := range ref.Value.Content.MediaTypes() {
+			// Media ranges imply no content type of the response
+			if strings.Contains(content_type, "*") {
+				continue
+			}
+			media_type := ref.Value.Content[content_type]
+			example, found := exampleOf(media_type.Example, media_type.Examples, media_type.Schema)
+			if !found {
+				continue
+			}
+			examples++
+			status := impliedStatus(responses, key)
+			if status == 0 {
+				problems = append(problems, "Response "+key+" has an example, but applies to no status, it is no status code or covered by the other responses")
+				break
+			}
+			var body []byte
+			if text, isString := example.(string); isString && !strings.Contains(content_type, "json") {
+				body = []byte(text)
+			} else {
+				body, _ = json.Marshal(example)
+			}
+			responseValidationInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestValidationInput,
+				Status:                 status,
+				Header:                 http.Header{"Content-Type": {content_type}},
+				Options:                options}
+			responseValidationInput.SetBodyBytes(body)
+			if err := openapi3filter.ValidateResponse(ctx, responseValidationInput); err != nil {
+				problems = append(problems, "Example of response "+key+" ("+content_type+", status "+strconv.Itoa(status)+"): "+err.Error())
+			}
+		}
+	}
+	if len(problems) != 0 {
+		return invalid("Response examples not valid", strings.Join(problems, "; "))
+	}
+	if examples == 0 {
+		errormsg := new(ErrorMessage)
+		errormsg.input = inputs.Method + "  " + request.url
+		errormsg.msg = "No example of the responses in the openEO API"
+		state["message"] = errormsg.toString()
+		state["state"] = "NoExample"
+		return state
+	}
+	state["state"] = "Valid"
+	return state
+}
+
+// impliedStatus returns the status code which the response of the key applies to, the lowest one of its range or, for
+// the default response, of the 5XX and then the 4XX range which no other response covers. Returns 0 if there is none.
+func impliedStatus(responses openapi3.Responses, key string) int {
+	var candidates []int
+	switch {
+	case key == "default":
+		for status := 500; status < 600; status++ {
+			candidates = append(candidates, status)
+		}
+		for status := 400; status < 500; status++ {
+			candidates = append(candidates, status)
+		}
+	case len(key) == 3 && strings.ToUpper(key[1:]) == "XX" && key[0] >= '1' && key[0] <= '5':
+		base := int(key[0]-'0') * 100
+		for status := base; status < base+100; status++ {
+			candidates = append(candidates, status)
+		}
+	default:
+		if status, err := strconv.Atoi(key); err == nil && status >= 100 && status < 600 {
+			candidates = append(candidates, status)
+		}
+	}
+	for _, status := range candidates {
+		if responses.Status(status) == responses[key] {
+			return status
+		}
+	}
+	return 0
+}
+
+// sortedResponseKeys returns the keys of the responses, sorted
+func sortedResponseKeys(responses openapi3.Responses) []string {
+	keys := make([]string, 0, len(responses))
+	for key := range responses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Main function
+func main() {
+	start_time := time.Now()
+	// Config file path
+	//var config Config
+	//var config_ep Config
+
+	ct := new(ComplianceTest)
+	explained := false
+
+	// CLI handling
+	app := cli.NewApp()
+	app.Name = "openeoct"
+	app.Name = "openeoct"
+	app.Version = "1.0.0"
+	app.Usage = "validating a back end against an openapi description file!"
+
+	app.Flags = []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "debug",
+			Usage: "activate debug info",
+		},
+		&cli.StringFlag{
+			Name:  "preset",
+			Usage: "rigor of the checks: " + strings.Join(openapi3lint.Presets(), ", ") + " (overrides the preset of the config)",
+		},
+	}
+	// add config command
+	app.Commands = []*cli.Command{
+		{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "load from config file",
+			Action: func(c *cli.Context) error {
+				//configfile = c.Args().First()
+				for i := 0; i < c.Args().Len(); i++ {
+					ct.appendConfig(ReadConfig(c.Args().Get(i)))
+
+				}
+				if c.Bool("debug") {
+					ct.debug = true
+				}
+				if c.String("preset") != "" {
+					ct.preset = strings.ToLower(c.String("preset"))
+				}
+				//log.Println("Configfile1: ", config.Url)
+				return nil
+			},
+		},
+		{
+			Name:      "batch",
+			Usage:     "send example requests of the operations of the openEO API concurrently, configured by the config files",
+			ArgsUsage: "<config file>...",
+			Action: func(c *cli.Context) error {
+				for i := 0; i < c.Args().Len(); i++ {
+					ct.appendConfig(ReadConfig(c.Args().Get(i)))
+				}
+				if c.Bool("debug") {
+					ct.debug = true
+				}
+				if c.String("preset") != "" {
+					ct.preset = strings.ToLower(c.String("preset"))
+				}
+				ct.batch = true
+				return nil
+			},
+		},
+		{
+			Name:      "contract",
+			Usage:     "validate the example requests and responses of the openEO API against itself, without a back end",
+			ArgsUsage: "<config file>...",
+			Action: func(c *cli.Context) error {
+				for i := 0; i < c.Args().Len(); i++ {
+					ct.appendConfig(ReadConfig(c.Args().Get(i)))
+				}
+				if c.Bool("debug") {
+					ct.debug = true
+				}
+				if c.String("preset") != "" {
+					ct.preset = strings.ToLower(c.String("preset"))
+				}
+				ct.contract = true
+				return nil
+			},
+		},
+		{
+			Name:      "explain",
+			Usage:     "explain a check code, e.g. OAS-TAG-UNUSED",
+			ArgsUsage: "<code>",
+			Action: func(c *cli.Context) error {
+				explained = true
+				code := strings.ToUpper(c.Args().First())
+				rule := openapi3lint.FindRule(code)
+				if rule == nil {
+					return cli.Exit(fmt.Sprintf("Error: unknown check code %q", code), 1)
+				}
+				fmt.Print(rule.Explain())
+				return nil
+			},
+		},
+	}
+
+	// run CLI
+	apperr := app.Run(os.Args)
 	if apperr != nil {
 		log.Fatal(apperr)
 	}
+	if explained {
+		return
+	}
+	ct.applyPreset()
 
 	//ct.debug = true
 	//ct.appendConfig(ReadConfig("examples/gee_config_v1_0_0_external.toml"))
@@ -935,59 +2718,27 @@ func main() {
 	//ct.appendConfig(config)
 	//ct.appendConfig(config_ep)
 
-	// config file read correctly
-	if ct.backend.url == "" {
+	// config file read correctly, contract tests don't need a back end
+	if ct.contract {
+		if ct.apifile == "" {
+			log.Fatal("Error: No config file or openapi file specified")
+		}
+	} else if ct.backend.url == "" {
 		log.Fatal("Error: No config file or backend url specified")
 	}
 
 	// Run validation
-	result, err := ct.validateAll()
-
-	if err != nil {
-		log.Println(err.toString())
-	}
-
-	end_time := time.Now()
-
-	var result_json map[string](map[string](map[string]interface{}))
-	result_json = make(map[string](map[string](map[string]interface{})))
-	result_json["result"] = make(map[string](map[string]interface{}))
-	result_json["stats"] = make(map[string](map[string]interface{}))
-	result_json["stats"]["backend"] = make(map[string]interface{})
-	result_json["stats"]["execution"] = make(map[string]interface{})
-	result_json["stats"]["spec"] = make(map[string]interface{})
-	result_json["stats"]["backend"]["url"] = ct.backend.url
-	result_json["stats"]["backend"]["baseurl"] = ct.backend.baseurl
-	result_json["stats"]["backend"]["version"] = ct.backend.version
-	result_json["stats"]["execution"]["start"] = start_time.Format("2006-01-02 15:04:05")
-	result_json["stats"]["execution"]["end"] = end_time.Format("2006-01-02 15:04:05")
-	result_json["stats"]["spec"]["apifile"] = ct.apifile
-
-	for group, endpoints := range ct.endpoints {
-		for _, ep := range endpoints {
-			ep.loadVariablesToEndpoint(*ct)
-			if result_json["result"][group] == nil {
-				result_json["result"][group] = make(map[string]interface{})
-				result_json["result"][group]["group_summary"] = ""
-				result_json["result"][group]["endpoints"] = make(map[string](map[string]string))
-			}
-
-			result_json["result"][group]["endpoints"].(map[string](map[string]string))[ep.Id] = result[ep.Id]
-			result_json["result"][group]["endpoints"].(map[string](map[string]string))[ep.Id]["url"] = ep.Url
-			result_json["result"][group]["endpoints"].(map[string](map[string]string))[ep.Id]["type"] = ep.Request_type
-			if result[ep.Id]["state"] != "Valid" && result[ep.Id]["state"] != "NotSupported" {
-				result_json["result"][group]["group_summary"] = "Invalid"
-			} else if result[ep.Id]["state"] == "Valid" {
-				if result_json["result"][group]["group_summary"] != "Invalid" {
-					result_json["result"][group]["group_summary"] = "Valid"
-				}
-			} else if result[ep.Id]["state"] == "NotSupported" && result_json["result"][group]["group_summary"] == "" {
-				result_json["result"][group]["group_summary"] = "NotSupported"
-			}
-		}
+	var result *Result
+	if ct.contract {
+		result = ct.runContract(start_time)
+	} else if ct.batch {
+		result = ct.runBatch(start_time)
+	} else {
+		result = ct.run(start_time)
 	}
+	log.Printf("Validation %s: %d checks, %d errors, %d warnings\n", result.Summary.Verdict, result.Summary.Checks, result.Summary.Errors, result.Summary.Warnings)
 
-	jsonString, _ := json.MarshalIndent(result_json, "", "    ")
+	jsonString, _ := json.MarshalIndent(result, "", "    ")
 
 	output := ReturnConfigValue(ct.output)
 